@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	shared_middleware "github.com/fahrizalvianaz/shared-middleware"
+	genericResponse "github.com/fahrizalvianaz/shared-response/httputil"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrUnauthenticated = errors.New("authentication required")
+
+type contextKey struct{}
+
+type Identity struct {
+	Claims *shared_middleware.Claims
+	Token  string
+}
+
+// Middleware validates the bearer token when one is sent. Unlike the JWTAuth
+// middleware of the services it lets anonymous requests through, because some
+// operations such as register are public; resolvers call Require instead.
+func Middleware(secretKey string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authHeader := ctx.GetHeader("Authorization")
+		if authHeader == "" {
+			ctx.Next()
+			return
+		}
+
+		parts := strings.SplitN(authHeader, " ", 2)
+		if !(len(parts) == 2 && parts[0] == "Bearer") {
+			genericResponse.ErrorResponse(ctx, http.StatusUnauthorized, "invalid authorization format", nil)
+			ctx.Abort()
+			return
+		}
+
+		claims := &shared_middleware.Claims{}
+		token, err := jwt.ParseWithClaims(parts[1], claims, func(t *jwt.Token) (interface{}, error) {
+			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, jwt.ErrSignatureInvalid
+			}
+			return []byte(secretKey), nil
+		})
+		if err != nil || !token.Valid {
+			genericResponse.ErrorResponse(ctx, http.StatusUnauthorized, "invalid or expired token", nil)
+			ctx.Abort()
+			return
+		}
+
+		identity := &Identity{Claims: claims, Token: parts[1]}
+		ctx.Request = ctx.Request.WithContext(WithIdentity(ctx.Request.Context(), identity))
+		ctx.Next()
+	}
+}
+
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, identity)
+}
+
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(contextKey{}).(*Identity)
+	return identity, ok
+}
+
+func Require(ctx context.Context) (*Identity, error) {
+	identity, ok := FromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	return identity, nil
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Money is an amount in minor units of an ISO 4217 currency.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type Book struct {
+	ID          uint      `json:"id"`
+	Title       string    `json:"title"`
+	Author      string    `json:"author"`
+	Description string    `json:"description"`
+	Price       Money     `json:"price"`
+	Stock       int       `json:"stock"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type BookPage struct {
+	Books []Book `json:"books"`
+	Page  int    `json:"page"`
+	Limit int    `json:"limit"`
+	Total int64  `json:"total"`
+}
+
+type CreateBookRequest struct {
+	Title       string `json:"title"`
+	Author      string `json:"author"`
+	Description string `json:"description"`
+	Price       Money  `json:"price"`
+	Stock       int    `json:"stock"`
+}
+
+type BookClient interface {
+	List(ctx context.Context, page, limit int) (*BookPage, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]Book, error)
+	Create(ctx context.Context, request CreateBookRequest) (*Book, error)
+}
+
+type bookClient struct {
+	upstream
+}
+
+func NewBookClient(baseURL string) BookClient {
+	return &bookClient{newUpstream(baseURL)}
+}
+
+func (c *bookClient) List(ctx context.Context, page, limit int) (*BookPage, error) {
+	var result BookPage
+	path := fmt.Sprintf("/api/v1/books?page=%d&limit=%d", page, limit)
+	if err := c.do(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *bookClient) FindByIDs(ctx context.Context, ids []uint) ([]Book, error) {
+	var result []Book
+	if err := c.do(ctx, http.MethodGet, "/api/v1/books/batch?ids="+joinIDs(ids), nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *bookClient) Create(ctx context.Context, request CreateBookRequest) (*Book, error) {
+	// book-service answers /add with its legacy create payload, whose keys
+	// differ from the read model.
+	var result struct {
+		ID          uint      `json:"idBook"`
+		Title       string    `json:"tittle"`
+		Author      string    `json:"author"`
+		Description string    `json:"description"`
+		Price       Money     `json:"price"`
+		Stock       int       `json:"stock"`
+		CreatedAt   time.Time `json:"createdAt"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/api/v1/books/add", request, &result); err != nil {
+		return nil, err
+	}
+	book := Book(result)
+	return &book, nil
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"api-gateway/internal/auth"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"github.com/fahrizalvianaz/shared-observability/logging"
+	"github.com/fahrizalvianaz/shared-observability/tracing"
+)
+
+type envelope struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Status  bool            `json:"status"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// upstream talks to one of the services behind the gateway. Every call
+// forwards the caller's bearer token and unwraps the httputil envelope.
+type upstream struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func newUpstream(baseURL string) upstream {
+	return upstream{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout:   5 * time.Second,
+			Transport: tracing.NewTransport(nil),
+		},
+	}
+}
+
+func (u upstream) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if identity, ok := auth.FromContext(ctx); ok {
+		req.Header.Set("Authorization", "Bearer "+identity.Token)
+	}
+	if requestID := logging.RequestIDFromContext(ctx); requestID != "" {
+		req.Header.Set(logging.RequestIDHeader, requestID)
+	}
+
+	res, err := u.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("upstream unavailable: %w", err)
+	}
+	defer res.Body.Close()
+
+	var payload envelope
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		return fmt.Errorf("invalid upstream response: %w", err)
+	}
+	if !payload.Status {
+		return upstreamError(res.StatusCode, payload)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(payload.Data, out)
+}
+
+// upstreamError relays a failed envelope as a domain error so the GraphQL
+// response carries the upstream error code.
+func upstreamError(status int, payload envelope) error {
+	var body apperror.Body
+	json.Unmarshal(payload.Data, &body)
+	if body.Error == "" {
+		body.Error = apperror.CodeInternal
+	}
+	return apperror.New(apperror.KindForStatus(status), body.Error, payload.Message)
+}
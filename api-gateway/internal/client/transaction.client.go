@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Transaction struct {
+	ID        uint      `json:"id"`
+	BookID    uint      `json:"bookId"`
+	UserID    uint      `json:"userId"`
+	Quantity  int       `json:"quantity"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type TransactionPage struct {
+	Transactions []Transaction `json:"transactions"`
+	Page         int           `json:"page"`
+	Limit        int           `json:"limit"`
+	Total        int64         `json:"total"`
+}
+
+type PurchaseRequest struct {
+	BookID   uint `json:"bookId"`
+	Quantity int  `json:"quantity"`
+}
+
+type TransactionClient interface {
+	List(ctx context.Context, page, limit int) (*TransactionPage, error)
+	Purchase(ctx context.Context, request PurchaseRequest) (*Transaction, error)
+}
+
+type transactionClient struct {
+	upstream
+}
+
+func NewTransactionClient(baseURL string) TransactionClient {
+	return &transactionClient{newUpstream(baseURL)}
+}
+
+func (c *transactionClient) List(ctx context.Context, page, limit int) (*TransactionPage, error) {
+	var result TransactionPage
+	path := fmt.Sprintf("/api/v1/transactions?page=%d&limit=%d", page, limit)
+	if err := c.do(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *transactionClient) Purchase(ctx context.Context, request PurchaseRequest) (*Transaction, error) {
+	var result Transaction
+	if err := c.do(ctx, http.MethodPost, "/api/v1/transactions/purchase", request, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
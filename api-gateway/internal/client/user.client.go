@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type User struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Email    string `json:"email,omitempty"`
+}
+
+type RegisterRequest struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type UserClient interface {
+	Register(ctx context.Context, request RegisterRequest) (*User, error)
+	Profile(ctx context.Context) (*User, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]User, error)
+}
+
+type userClient struct {
+	upstream
+}
+
+func NewUserClient(baseURL string) UserClient {
+	return &userClient{newUpstream(baseURL)}
+}
+
+func (c *userClient) Register(ctx context.Context, request RegisterRequest) (*User, error) {
+	var result User
+	if err := c.do(ctx, http.MethodPost, "/api/v1/users/register", request, &result); err != nil {
+		return nil, err
+	}
+	result.Name = request.Name
+	return &result, nil
+}
+
+func (c *userClient) Profile(ctx context.Context) (*User, error) {
+	var result User
+	if err := c.do(ctx, http.MethodGet, "/api/v1/users/profile", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *userClient) FindByIDs(ctx context.Context, ids []uint) ([]User, error) {
+	var result []User
+	if err := c.do(ctx, http.MethodGet, "/api/v1/users/batch?ids="+joinIDs(ids), nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func joinIDs(ids []uint) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatUint(uint64(id), 10)
+	}
+	return strings.Join(parts, ",")
+}
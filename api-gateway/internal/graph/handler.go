@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"api-gateway/internal/client"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	genericResponse "github.com/fahrizalvianaz/shared-response/httputil"
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/dataloader/v7"
+	"github.com/graphql-go/graphql"
+)
+
+type request struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type Handler struct {
+	schema graphql.Schema
+	books  client.BookClient
+	users  client.UserClient
+}
+
+func NewHandler(schema graphql.Schema, books client.BookClient, users client.UserClient) *Handler {
+	return &Handler{
+		schema: schema,
+		books:  books,
+		users:  users,
+	}
+}
+
+func (h *Handler) Serve(ctx *gin.Context) {
+	var req request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		genericResponse.BadRequestResponse(ctx, "Invalid Request format", err.Error())
+		return
+	}
+
+	// Loaders cache per request only, so every request gets fresh ones.
+	requestCtx := WithLoaders(ctx.Request.Context(), NewLoaders(h.books, h.users))
+
+	result := graphql.Do(graphql.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        requestCtx,
+	})
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// load adapts a dataloader thunk to the lazy resolver value graphql-go
+// understands, which lets sibling fields queue up in the same batch.
+func load[T any](thunk dataloader.Thunk[T]) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		return thunk()
+	}
+}
+
+func parseID(value interface{}) (uint, error) {
+	id, err := strconv.ParseUint(fmt.Sprint(value), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid id %v", value)
+	}
+	return uint(id), nil
+}
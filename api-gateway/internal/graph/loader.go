@@ -0,0 +1,76 @@
+package graph
+
+import (
+	"api-gateway/internal/client"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+const loaderWait = 2 * time.Millisecond
+
+type loadersKey struct{}
+
+// Loaders batches the per-field lookups of a single GraphQL request so that a
+// list of N transactions costs one call to book-service and one to
+// user-service instead of 2N.
+type Loaders struct {
+	Book *dataloader.Loader[uint, *client.Book]
+	User *dataloader.Loader[uint, *client.User]
+}
+
+func NewLoaders(books client.BookClient, users client.UserClient) *Loaders {
+	return &Loaders{
+		Book: dataloader.NewBatchedLoader(batchBooks(books), dataloader.WithWait[uint, *client.Book](loaderWait)),
+		User: dataloader.NewBatchedLoader(batchUsers(users), dataloader.WithWait[uint, *client.User](loaderWait)),
+	}
+}
+
+func WithLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, loaders)
+}
+
+func loadersFrom(ctx context.Context) *Loaders {
+	return ctx.Value(loadersKey{}).(*Loaders)
+}
+
+func batchBooks(books client.BookClient) dataloader.BatchFunc[uint, *client.Book] {
+	return func(ctx context.Context, ids []uint) []*dataloader.Result[*client.Book] {
+		found, err := books.FindByIDs(ctx, ids)
+		byID := make(map[uint]*client.Book, len(found))
+		for i := range found {
+			byID[found[i].ID] = &found[i]
+		}
+		return collect(ids, byID, err, "book")
+	}
+}
+
+func batchUsers(users client.UserClient) dataloader.BatchFunc[uint, *client.User] {
+	return func(ctx context.Context, ids []uint) []*dataloader.Result[*client.User] {
+		found, err := users.FindByIDs(ctx, ids)
+		byID := make(map[uint]*client.User, len(found))
+		for i := range found {
+			byID[found[i].ID] = &found[i]
+		}
+		return collect(ids, byID, err, "user")
+	}
+}
+
+// collect orders batch results to match the requested keys, as dataloader
+// requires.
+func collect[T any](ids []uint, byID map[uint]*T, err error, kind string) []*dataloader.Result[*T] {
+	results := make([]*dataloader.Result[*T], len(ids))
+	for i, id := range ids {
+		switch item, ok := byID[id]; {
+		case err != nil:
+			results[i] = &dataloader.Result[*T]{Error: err}
+		case !ok:
+			results[i] = &dataloader.Result[*T]{Error: fmt.Errorf("%s %d not found", kind, id)}
+		default:
+			results[i] = &dataloader.Result[*T]{Data: item}
+		}
+	}
+	return results
+}
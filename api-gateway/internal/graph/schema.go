@@ -0,0 +1,275 @@
+package graph
+
+import (
+	"api-gateway/internal/auth"
+	"api-gateway/internal/client"
+
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	defaultPage  = 1
+	defaultLimit = 10
+)
+
+type Resolver struct {
+	books        client.BookClient
+	users        client.UserClient
+	transactions client.TransactionClient
+}
+
+func NewResolver(books client.BookClient, users client.UserClient, transactions client.TransactionClient) *Resolver {
+	return &Resolver{
+		books:        books,
+		users:        users,
+		transactions: transactions,
+	}
+}
+
+var moneyType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Money",
+	Description: "An amount in minor units of an ISO 4217 currency.",
+	Fields: graphql.Fields{
+		"amount":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"currency": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+	},
+})
+
+var moneyInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "MoneyInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"amount":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+		"currency": &graphql.InputObjectFieldConfig{Type: graphql.String},
+	},
+})
+
+var bookType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Book",
+	Fields: graphql.Fields{
+		"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"title":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"author":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"description": &graphql.Field{Type: graphql.String},
+		"price":       &graphql.Field{Type: graphql.NewNonNull(moneyType)},
+		"stock":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"createdAt":   &graphql.Field{Type: graphql.DateTime},
+	},
+})
+
+var userType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "User",
+	Fields: graphql.Fields{
+		"id":       &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"name":     &graphql.Field{Type: graphql.String},
+		"username": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"email":    &graphql.Field{Type: graphql.String},
+	},
+})
+
+var pageArgs = graphql.FieldConfigArgument{
+	"page":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPage},
+	"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultLimit},
+}
+
+func pageType(name string, item graphql.Output) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"items": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(item)))},
+			"page":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"limit": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"total": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+}
+
+type page struct {
+	Items interface{} `json:"items"`
+	Page  int         `json:"page"`
+	Limit int         `json:"limit"`
+	Total int64       `json:"total"`
+}
+
+func (r *Resolver) Schema() (graphql.Schema, error) {
+	transactionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Transaction",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"quantity":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"createdAt": &graphql.Field{Type: graphql.DateTime},
+			"book":      &graphql.Field{Type: bookType, Resolve: r.transactionBook},
+			"user":      &graphql.Field{Type: userType, Resolve: r.transactionUser},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"books": &graphql.Field{
+				Type:    graphql.NewNonNull(pageType("BookPage", bookType)),
+				Args:    pageArgs,
+				Resolve: r.listBooks,
+			},
+			"book": &graphql.Field{
+				Type: bookType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: r.book,
+			},
+			"me": &graphql.Field{
+				Type:    userType,
+				Resolve: r.me,
+			},
+			"transactions": &graphql.Field{
+				Type:    graphql.NewNonNull(pageType("TransactionPage", transactionType)),
+				Args:    pageArgs,
+				Resolve: r.listTransactions,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createBook": &graphql.Field{
+				Type: bookType,
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.NewInputObject(graphql.InputObjectConfig{
+						Name: "CreateBookInput",
+						Fields: graphql.InputObjectConfigFieldMap{
+							"title":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+							"author":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+							"description": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+							"price":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(moneyInputType)},
+							"stock":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+						},
+					}))},
+				},
+				Resolve: r.createBook,
+			},
+			"register": &graphql.Field{
+				Type: userType,
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.NewInputObject(graphql.InputObjectConfig{
+						Name: "RegisterInput",
+						Fields: graphql.InputObjectConfigFieldMap{
+							"name":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+							"username": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+							"email":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+							"password": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+						},
+					}))},
+				},
+				Resolve: r.register,
+			},
+			"purchase": &graphql.Field{
+				Type: transactionType,
+				Args: graphql.FieldConfigArgument{
+					"bookId":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"quantity": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+				},
+				Resolve: r.purchase,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+	})
+}
+
+func (r *Resolver) listBooks(p graphql.ResolveParams) (interface{}, error) {
+	result, err := r.books.List(p.Context, p.Args["page"].(int), p.Args["limit"].(int))
+	if err != nil {
+		return nil, err
+	}
+	return page{Items: result.Books, Page: result.Page, Limit: result.Limit, Total: result.Total}, nil
+}
+
+func (r *Resolver) book(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+	return load(loadersFrom(p.Context).Book.Load(p.Context, id)), nil
+}
+
+func (r *Resolver) me(p graphql.ResolveParams) (interface{}, error) {
+	if _, err := auth.Require(p.Context); err != nil {
+		return nil, err
+	}
+	return r.users.Profile(p.Context)
+}
+
+func (r *Resolver) listTransactions(p graphql.ResolveParams) (interface{}, error) {
+	if _, err := auth.Require(p.Context); err != nil {
+		return nil, err
+	}
+	result, err := r.transactions.List(p.Context, p.Args["page"].(int), p.Args["limit"].(int))
+	if err != nil {
+		return nil, err
+	}
+	return page{Items: result.Transactions, Page: result.Page, Limit: result.Limit, Total: result.Total}, nil
+}
+
+func (r *Resolver) transactionBook(p graphql.ResolveParams) (interface{}, error) {
+	transaction := p.Source.(client.Transaction)
+	return load(loadersFrom(p.Context).Book.Load(p.Context, transaction.BookID)), nil
+}
+
+func (r *Resolver) transactionUser(p graphql.ResolveParams) (interface{}, error) {
+	transaction := p.Source.(client.Transaction)
+	return load(loadersFrom(p.Context).User.Load(p.Context, transaction.UserID)), nil
+}
+
+func (r *Resolver) createBook(p graphql.ResolveParams) (interface{}, error) {
+	if _, err := auth.Require(p.Context); err != nil {
+		return nil, err
+	}
+	input := p.Args["input"].(map[string]interface{})
+	return r.books.Create(p.Context, client.CreateBookRequest{
+		Title:       input["title"].(string),
+		Author:      input["author"].(string),
+		Description: input["description"].(string),
+		Price:       moneyInput(input["price"].(map[string]interface{})),
+		Stock:       input["stock"].(int),
+	})
+}
+
+func moneyInput(input map[string]interface{}) client.Money {
+	price := client.Money{Amount: int64(input["amount"].(int))}
+	if currency, ok := input["currency"].(string); ok {
+		price.Currency = currency
+	}
+	return price
+}
+
+func (r *Resolver) register(p graphql.ResolveParams) (interface{}, error) {
+	input := p.Args["input"].(map[string]interface{})
+	return r.users.Register(p.Context, client.RegisterRequest{
+		Name:     input["name"].(string),
+		Username: input["username"].(string),
+		Email:    input["email"].(string),
+		Password: input["password"].(string),
+	})
+}
+
+func (r *Resolver) purchase(p graphql.ResolveParams) (interface{}, error) {
+	if _, err := auth.Require(p.Context); err != nil {
+		return nil, err
+	}
+	bookID, err := parseID(p.Args["bookId"])
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := r.transactions.Purchase(p.Context, client.PurchaseRequest{
+		BookID:   bookID,
+		Quantity: p.Args["quantity"].(int),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return *transaction, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"api-gateway/routes"
+	"context"
+	"net/http"
+	"os"
+
+	configs "github.com/fahrizalvianaz/shared-configuration/configs"
+	"github.com/fahrizalvianaz/shared-observability/logging"
+	"github.com/fahrizalvianaz/shared-observability/tracing"
+	"github.com/fahrizalvianaz/shared-server/health"
+	"github.com/fahrizalvianaz/shared-server/server"
+)
+
+func main() {
+	logging.Setup("api-gateway")
+
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		logging.Fatal("Failed to load config", err)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.LoadConfig("api-gateway"))
+	if err != nil {
+		logging.Fatal("Failed to set up tracing", err)
+	}
+
+	checker := health.New(health.DefaultTimeout)
+	for name, url := range map[string]string{
+		"book-service":         os.Getenv("BOOK_SERVICE_URL"),
+		"user-service":         os.Getenv("USER_SERVICE_URL"),
+		"transactions-service": os.Getenv("TRANSACTION_SERVICE_URL"),
+	} {
+		checker.Add(name, health.HTTP(http.DefaultClient, url+"/healthz"))
+	}
+
+	router := routes.Router(cfg, checker)
+	router.SetTrustedProxies(nil)
+
+	runner := server.New(server.LoadConfig(), router)
+	runner.OnDrain(checker.Drain)
+	runner.OnShutdown("tracing", shutdownTracing)
+
+	if err := runner.Run(context.Background()); err != nil {
+		logging.Fatal("Server stopped with error", err)
+	}
+}
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"api-gateway/internal/auth"
+	"api-gateway/internal/client"
+	"api-gateway/internal/graph"
+	"os"
+
+	configs "github.com/fahrizalvianaz/shared-configuration/configs"
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"github.com/fahrizalvianaz/shared-observability/logging"
+	"github.com/fahrizalvianaz/shared-observability/metrics"
+	"github.com/fahrizalvianaz/shared-observability/tracing"
+	"github.com/fahrizalvianaz/shared-server/health"
+	"github.com/gin-gonic/gin"
+)
+
+func Router(cfg *configs.Config, checker *health.Checker) *gin.Engine {
+	router := gin.New()
+	router.Use(logging.RequestID())
+	router.Use(tracing.Middleware("api-gateway")...)
+	router.Use(metrics.Middleware("api-gateway"))
+	router.Use(logging.Middleware(), logging.Recovery())
+	router.NoRoute(apperror.NoRoute)
+	router.GET("/metrics", metrics.Handler())
+	router.GET("/healthz", gin.WrapF(checker.Liveness))
+	router.GET("/readyz", gin.WrapF(checker.Readiness))
+
+	bookClient := client.NewBookClient(os.Getenv("BOOK_SERVICE_URL"))
+	userClient := client.NewUserClient(os.Getenv("USER_SERVICE_URL"))
+	transactionClient := client.NewTransactionClient(os.Getenv("TRANSACTION_SERVICE_URL"))
+
+	schema, err := graph.NewResolver(bookClient, userClient, transactionClient).Schema()
+	if err != nil {
+		logging.Fatal("Failed to build graphql schema", err)
+	}
+	graphHandler := graph.NewHandler(schema, bookClient, userClient)
+
+	router.POST("/graphql", auth.Middleware(cfg.SecretKey), graphHandler.Serve)
+
+	return router
+}
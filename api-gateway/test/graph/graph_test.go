@@ -0,0 +1,95 @@
+package graph_test
+
+import (
+	"api-gateway/internal/auth"
+	"api-gateway/internal/client"
+	"api-gateway/internal/graph"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupUpstream(t *testing.T, bookCalls, userCalls *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/transactions":
+			fmt.Fprint(w, `{"code":200,"status":true,"data":{"transactions":[
+				{"id":1,"bookId":1,"userId":7,"quantity":1},
+				{"id":2,"bookId":2,"userId":7,"quantity":1},
+				{"id":3,"bookId":1,"userId":8,"quantity":2}],"page":1,"limit":10,"total":3}}`)
+		case "/api/v1/books/batch":
+			atomic.AddInt32(bookCalls, 1)
+			assert.Equal(t, "1,2", r.URL.Query().Get("ids"))
+			fmt.Fprint(w, `{"code":200,"status":true,"data":[
+				{"id":1,"title":"First","author":"a","price":{"amount":10000,"currency":"IDR"},"stock":1},
+				{"id":2,"title":"Second","author":"b","price":{"amount":20000,"currency":"IDR"},"stock":1}]}`)
+		case "/api/v1/users/batch":
+			atomic.AddInt32(userCalls, 1)
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			fmt.Fprint(w, `{"code":200,"status":true,"data":[{"id":7,"username":"seven"},{"id":8,"username":"eight"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGraph_TransactionsAreBatched(t *testing.T) {
+	var bookCalls, userCalls int32
+	server := setupUpstream(t, &bookCalls, &userCalls)
+
+	books := client.NewBookClient(server.URL)
+	users := client.NewUserClient(server.URL)
+	transactions := client.NewTransactionClient(server.URL)
+
+	schema, err := graph.NewResolver(books, users, transactions).Schema()
+	require.NoError(t, err)
+
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{Token: "token"})
+	ctx = graph.WithLoaders(ctx, graph.NewLoaders(books, users))
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		Context:       ctx,
+		RequestString: `{ transactions { total items { id book { title price { amount currency } } user { username } } } }`,
+	})
+
+	require.Empty(t, result.Errors)
+	assert.Equal(t, int32(1), bookCalls)
+	assert.Equal(t, int32(1), userCalls)
+
+	items := result.Data.(map[string]interface{})["transactions"].(map[string]interface{})["items"].([]interface{})
+	require.Len(t, items, 3)
+	book := items[2].(map[string]interface{})["book"].(map[string]interface{})
+	assert.Equal(t, "First", book["title"])
+	assert.Equal(t, map[string]interface{}{"amount": 10000, "currency": "IDR"}, book["price"])
+	assert.Equal(t, "eight", items[2].(map[string]interface{})["user"].(map[string]interface{})["username"])
+}
+
+func TestGraph_RequiresAuthentication(t *testing.T) {
+	var bookCalls, userCalls int32
+	server := setupUpstream(t, &bookCalls, &userCalls)
+
+	books := client.NewBookClient(server.URL)
+	users := client.NewUserClient(server.URL)
+
+	schema, err := graph.NewResolver(books, users, client.NewTransactionClient(server.URL)).Schema()
+	require.NoError(t, err)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		Context:       graph.WithLoaders(context.Background(), graph.NewLoaders(books, users)),
+		RequestString: `mutation { purchase(bookId: "1") { id } }`,
+	})
+
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, auth.ErrUnauthenticated.Error(), result.Errors[0].Message)
+}
@@ -3,33 +3,42 @@ package api
 import (
 	"book-service/internal"
 	"book-service/internal/api/dto"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/fahrizalvianaz/shared-errors/apperror"
 	genericResponse "github.com/fahrizalvianaz/shared-response/httputil"
 	"github.com/gin-gonic/gin"
 )
 
 type BookHandler struct {
 	bookService internal.BookService
+	maxAge      time.Duration
 }
 
-func NewBookHandler(bookService internal.BookService) *BookHandler {
+func NewBookHandler(bookService internal.BookService, maxAge time.Duration) *BookHandler {
 	return &BookHandler{
 		bookService: bookService,
+		maxAge:      maxAge,
 	}
 }
 
 func (b *BookHandler) Create(ctx *gin.Context) {
 	var req dto.CreateRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		genericResponse.BadRequestResponse(ctx, "Invalid Request format", err.Error())
+		apperror.Respond(ctx, apperror.BindError(err))
 		return
 	}
 
 	response, err := b.bookService.Create(ctx.Request.Context(), req)
 	if err != nil {
-		genericResponse.ErrorResponse(ctx, http.StatusBadRequest, err.Error(), nil)
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -40,15 +49,144 @@ func (b *BookHandler) FindByID(ctx *gin.Context) {
 	id := ctx.Param("id")
 	idUint, err := strconv.ParseUint(id, 10, 64)
 	if err != nil {
-		genericResponse.BadRequestResponse(ctx, "Invalid ID format", err.Error())
+		apperror.Respond(ctx, apperror.ErrInvalidID.Wrap(err))
 		return
 	}
 	response, err := b.bookService.FindByID(ctx.Request.Context(), uint(idUint))
 	if err != nil {
-		genericResponse.ErrorResponse(ctx, http.StatusBadRequest, err.Error(), nil)
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	display := ""
+	if response.Display != nil {
+		display = response.Display.Currency
+	}
+	if notModified(ctx, etag(response.Version, display), b.maxAge) {
 		return
 	}
 
 	genericResponse.SuccessResponse(ctx, 200, "Book found", response)
 
 }
+
+func (b *BookHandler) FindByISBN(ctx *gin.Context) {
+	response, err := b.bookService.FindByISBN(ctx.Request.Context(), ctx.Param("isbn"))
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	if notModified(ctx, responseTag(response), b.maxAge) {
+		return
+	}
+	genericResponse.OkResponse(ctx, "Book found", response)
+}
+
+// Export streams the catalogue as an attachment. Errors after the first
+// byte can no longer change the status, so they cut the download short and
+// are logged.
+func (b *BookHandler) Export(ctx *gin.Context) {
+	var req dto.ExportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	// Large exports outlive the server's write timeout.
+	http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{})
+
+	filename := fmt.Sprintf("books-%s.%s", time.Now().UTC().Format("20060102"), req.Format)
+	ctx.Header("Content-Type", internal.ExportContentType(req.Format))
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Status(http.StatusOK)
+
+	if err := b.bookService.Export(ctx.Request.Context(), req, ctx.Writer); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "export interrupted", "format", req.Format, "error", err)
+		ctx.Abort()
+	}
+}
+
+// Update replaces a book. The If-Match header must carry the ETag from the
+// last read so concurrent edits are rejected instead of overwritten.
+func (b *BookHandler) Update(ctx *gin.Context) {
+	idUint, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apperror.Respond(ctx, apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	header := ctx.GetHeader("If-Match")
+	if header == "" {
+		apperror.Respond(ctx, internal.ErrIfMatchRequired)
+		return
+	}
+	version, ok := ifMatchVersion(header)
+	if !ok {
+		apperror.Respond(ctx, internal.ErrBookModified)
+		return
+	}
+
+	var req dto.UpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	response, err := b.bookService.Update(ctx.Request.Context(), uint(idUint), version, req)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	ctx.Header("ETag", responseTag(response))
+	ctx.Header("Cache-Control", "no-store")
+	genericResponse.OkResponse(ctx, "Book updated successfully", response)
+}
+
+func (b *BookHandler) FindAll(ctx *gin.Context) {
+	var req dto.ListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	response, err := b.bookService.FindAll(ctx.Request.Context(), req)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", cacheControl(b.maxAge))
+	genericResponse.OkResponse(ctx, "Books found", response)
+}
+
+// FindByIDs serves batched lookups such as /books/batch?ids=1,2,3 so callers
+// resolving many books at once need a single round trip.
+func (b *BookHandler) FindByIDs(ctx *gin.Context) {
+	var ids []uint
+	for _, part := range strings.Split(ctx.Query("ids"), ",") {
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			apperror.Respond(ctx, apperror.ErrInvalidID.Wrap(err))
+			return
+		}
+		ids = append(ids, uint(id))
+	}
+	if len(ids) == 0 {
+		apperror.Respond(ctx, apperror.BindError(errors.New("ids is required")))
+		return
+	}
+
+	response, err := b.bookService.FindByIDs(ctx.Request.Context(), ids)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Books found", response)
+}
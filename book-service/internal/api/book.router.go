@@ -2,17 +2,48 @@ package api
 
 import (
 	"book-service/internal"
+	"book-service/pkg/blob"
+	"net/http"
 
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-middleware/middleware"
+	"github.com/fahrizalvianaz/shared-server/idempotency"
+	"github.com/fahrizalvianaz/shared-server/roles"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func BookRoutes(router *gin.RouterGroup, db *gorm.DB) {
-	bookRepository := internal.NewBookRepository(db)
-	bookService := internal.NewBookService(bookRepository)
-	bookHandler := NewBookHandler(bookService)
+func BookRoutes(router *gin.RouterGroup, db *gorm.DB, bookRepository internal.BookRepository, covers blob.Store, rates money.Rates, admins roles.Admins) {
+	bookService := internal.NewBookService(bookRepository, internal.NewCategoryRepository(db), covers, rates)
+	bookHandler := NewBookHandler(bookService, MaxAgeFromEnv())
+	importService := internal.NewImportService(bookRepository, internal.NewImportJobRepository(db), internal.ImportDirFromEnv())
+	importHandler := NewImportHandler(importService, ImportLimitsFromEnv())
+	coverMaxBytes := internal.CoverMaxBytesFromEnv()
+	priceHandler := NewPriceHandler(internal.NewPriceService(bookRepository, internal.NewPriceRepository(db), internal.PriceApplyIntervalFromEnv()))
+	coverHandler := NewCoverHandler(internal.NewCoverService(bookRepository, covers, coverMaxBytes), coverMaxBytes)
 
-	router.POST("/add", bookHandler.Create)
+	// Reads are public; changing the catalogue is for administrators.
+	admin := router.Group("", middleware.JWTAuth(), roles.RequireAdmin(admins))
+
+	router.GET("", bookHandler.FindAll)
+	router.GET("/batch", bookHandler.FindByIDs)
+	router.GET("/export", bookHandler.Export)
+	admin.POST("/add", idempotency.Middleware(idempotency.NewGormStore(db), idempotency.TTLFromEnv()), bookHandler.Create)
+	admin.POST("/import", importHandler.Import)
+	admin.GET("/import/:id", importHandler.FindJob)
+	router.GET("/isbn/:isbn", bookHandler.FindByISBN)
 	router.GET("/:id", bookHandler.FindByID)
+	admin.PUT("/:id", bookHandler.Update)
+	router.GET("/:id/price-history", priceHandler.History)
+	admin.POST("/:id/prices", priceHandler.Schedule)
+	admin.DELETE("/:id/prices/:priceId", priceHandler.Cancel)
+	admin.POST("/:id/cover", coverHandler.Upload)
+	admin.DELETE("/:id/cover", coverHandler.Delete)
+
+	// The local backend serves the images itself; other backends link
+	// straight to their own URLs.
+	if files, ok := covers.(http.Handler); ok {
+		router.GET("/covers/*key", gin.WrapH(http.StripPrefix(router.BasePath()+"/covers", files)))
+	}
 
 }
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"book-service/internal"
+	"book-service/internal/api/dto"
+	"net/http"
+	"strconv"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	genericResponse "github.com/fahrizalvianaz/shared-response/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+type CategoryHandler struct {
+	categoryService internal.CategoryService
+	bookService     internal.BookService
+}
+
+func NewCategoryHandler(categoryService internal.CategoryService, bookService internal.BookService) *CategoryHandler {
+	return &CategoryHandler{
+		categoryService: categoryService,
+		bookService:     bookService,
+	}
+}
+
+func (c *CategoryHandler) Create(ctx *gin.Context) {
+	var req dto.CategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	response, err := c.categoryService.Create(ctx.Request.Context(), req)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.CreatedResponse(ctx, "Category created successfully", response)
+}
+
+func (c *CategoryHandler) FindTree(ctx *gin.Context) {
+	response, err := c.categoryService.FindTree(ctx.Request.Context())
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Categories found", response)
+}
+
+func (c *CategoryHandler) FindByID(ctx *gin.Context) {
+	id, ok := categoryID(ctx)
+	if !ok {
+		return
+	}
+
+	response, err := c.categoryService.FindByID(ctx.Request.Context(), id)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Category found", response)
+}
+
+func (c *CategoryHandler) Update(ctx *gin.Context) {
+	id, ok := categoryID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.CategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	response, err := c.categoryService.Update(ctx.Request.Context(), id, req)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Category updated successfully", response)
+}
+
+func (c *CategoryHandler) Delete(ctx *gin.Context) {
+	id, ok := categoryID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.categoryService.Delete(ctx.Request.Context(), id); err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// FindBooks lists the books in the category and its subcategories, with
+// the same paging and tag filters as the book list.
+func (c *CategoryHandler) FindBooks(ctx *gin.Context) {
+	id, ok := categoryID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.ListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	response, err := c.bookService.FindByCategory(ctx.Request.Context(), id, req)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Books found", response)
+}
+
+func categoryID(ctx *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apperror.Respond(ctx, apperror.ErrInvalidID.Wrap(err))
+		return 0, false
+	}
+	return uint(id), true
+}
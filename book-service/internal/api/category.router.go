@@ -0,0 +1,28 @@
+package api
+
+import (
+	"book-service/internal"
+	"book-service/pkg/blob"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-middleware/middleware"
+	"github.com/fahrizalvianaz/shared-server/roles"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func CategoryRoutes(router *gin.RouterGroup, db *gorm.DB, bookRepository internal.BookRepository, covers blob.Store, rates money.Rates, admins roles.Admins) {
+	categoryRepository := internal.NewCategoryRepository(db)
+	categoryService := internal.NewCategoryService(categoryRepository)
+	bookService := internal.NewBookService(bookRepository, categoryRepository, covers, rates)
+	categoryHandler := NewCategoryHandler(categoryService, bookService)
+
+	admin := router.Group("", middleware.JWTAuth(), roles.RequireAdmin(admins))
+
+	router.GET("", categoryHandler.FindTree)
+	admin.POST("", categoryHandler.Create)
+	router.GET("/:id", categoryHandler.FindByID)
+	admin.PUT("/:id", categoryHandler.Update)
+	admin.DELETE("/:id", categoryHandler.Delete)
+	router.GET("/:id/books", categoryHandler.FindBooks)
+}
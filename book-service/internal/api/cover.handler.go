@@ -0,0 +1,78 @@
+package api
+
+import (
+	"book-service/internal"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	genericResponse "github.com/fahrizalvianaz/shared-response/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead leaves room for the form boundaries and headers around
+// the file itself.
+const multipartOverhead = 64 << 10
+
+type CoverHandler struct {
+	coverService internal.CoverService
+	maxBytes     int64
+}
+
+func NewCoverHandler(coverService internal.CoverService, maxBytes int64) *CoverHandler {
+	return &CoverHandler{
+		coverService: coverService,
+		maxBytes:     maxBytes,
+	}
+}
+
+// Upload replaces the book's cover with the multipart "file" field.
+func (h *CoverHandler) Upload(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apperror.Respond(ctx, apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, h.maxBytes+multipartOverhead)
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			apperror.Respond(ctx, internal.ErrCoverTooLarge.Wrap(err))
+			return
+		}
+		apperror.Respond(ctx, internal.ErrCoverRequired.Wrap(err))
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		apperror.Respond(ctx, apperror.Internal(err))
+		return
+	}
+	defer file.Close()
+
+	response, err := h.coverService.Upload(ctx.Request.Context(), uint(id), file)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Cover uploaded", response)
+}
+
+func (h *CoverHandler) Delete(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apperror.Respond(ctx, apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	if err := h.coverService.Delete(ctx.Request.Context(), uint(id)); err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Cover removed", nil)
+}
@@ -1,9 +1,47 @@
 package dto
 
 type CreateRequest struct {
-	Title       string `json:"title" binding:"required" example:"Rich Dad, Poor Dad"`
-	Author      string `json:"author" binding:"required" example:"Robert T Kiyosaki"`
-	Description string `json:"description" binding:"required" example:"Learn from rich dad and poor dad about financial management"`
-	Price       int    `json:"price" binding:"required" example:"100000"`
-	Stock       int    `json:"stock" binding:"required" example:"50"`
+	Title       string       `json:"title" binding:"required,notblank,max=255" example:"Rich Dad, Poor Dad"`
+	Author      string       `json:"author" binding:"required,notblank,max=255" example:"Robert T Kiyosaki"`
+	Description string       `json:"description" binding:"required,notblank,max=2000" example:"Learn from rich dad and poor dad about financial management"`
+	ISBN        string       `json:"isbn" binding:"omitempty,isbn" example:"978-1-61268-019-4"`
+	CategoryID  *uint        `json:"categoryId" binding:"omitempty,min=1" example:"1"`
+	Tags        []string     `json:"tags" binding:"omitempty,max=20,dive,notblank,max=50" example:"finance,self-help"`
+	Price       MoneyRequest `json:"price"`
+	Stock       int          `json:"stock" binding:"min=0,max=1000000" example:"50"`
+}
+
+type UpdateRequest struct {
+	Title       string       `json:"title" binding:"required,notblank,max=255" example:"Rich Dad, Poor Dad"`
+	Author      string       `json:"author" binding:"required,notblank,max=255" example:"Robert T Kiyosaki"`
+	Description string       `json:"description" binding:"required,notblank,max=2000" example:"Learn from rich dad and poor dad about financial management"`
+	ISBN        string       `json:"isbn" binding:"omitempty,isbn" example:"978-1-61268-019-4"`
+	CategoryID  *uint        `json:"categoryId" binding:"omitempty,min=1" example:"1"`
+	Tags        []string     `json:"tags" binding:"omitempty,max=20,dive,notblank,max=50" example:"finance,self-help"`
+	Price       MoneyRequest `json:"price"`
+	Stock       int          `json:"stock" binding:"min=0,max=1000000" example:"50"`
+}
+
+type ListRequest struct {
+	Page  int `form:"page" binding:"omitempty,min=1" example:"1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100" example:"10"`
+	// Tags is a comma separated list; only books carrying all of them match.
+	Tags string `form:"tags" binding:"omitempty,max=1000" example:"finance,self-help"`
+}
+
+type ExportRequest struct {
+	Format string `form:"format" binding:"required,oneof=csv ndjson xml" example:"csv"`
+	// Tags filters like the book list.
+	Tags string `form:"tags" binding:"omitempty,max=1000" example:"finance,self-help"`
+}
+
+// MoneyRequest is an amount in minor units of an ISO 4217 currency, such as
+// sen for IDR. The currency defaults to IDR.
+type MoneyRequest struct {
+	Amount   int64  `json:"amount" binding:"min=1,max=100000000000" example:"10000000"`
+	Currency string `json:"currency" binding:"omitempty,iso4217" example:"IDR"`
+}
+
+type ReserveRequest struct {
+	Quantity int `json:"quantity" binding:"required,min=1,max=1000" example:"1"`
 }
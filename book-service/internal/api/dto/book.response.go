@@ -1,13 +1,55 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+)
 
 type CreateResponse struct {
-	ID          uint      `json:"idBook"`
-	Title       string    `json:"tittle"`
-	Author      string    `json:"author"`
-	Description string    `json:"description"`
-	Price       int       `json:"price"`
-	Stock       int       `json:"stock"`
-	CreatedAt   time.Time `json:"createdAt"`
+	ID          uint         `json:"idBook"`
+	Title       string       `json:"tittle"`
+	Author      string       `json:"author"`
+	Description string       `json:"description"`
+	ISBN10      string       `json:"isbn10,omitempty"`
+	ISBN13      string       `json:"isbn13,omitempty"`
+	CategoryID  *uint        `json:"categoryId,omitempty"`
+	Tags        []string     `json:"tags"`
+	Price       money.Money  `json:"price"`
+	BasePrice   *money.Money `json:"basePrice,omitempty"`
+	Stock       int          `json:"stock"`
+	CreatedAt   time.Time    `json:"createdAt"`
+}
+
+type BookResponse struct {
+	ID          uint     `json:"id"`
+	Title       string   `json:"title"`
+	Author      string   `json:"author"`
+	Description string   `json:"description"`
+	ISBN10      string   `json:"isbn10,omitempty"`
+	ISBN13      string   `json:"isbn13,omitempty"`
+	CategoryID  *uint    `json:"categoryId,omitempty"`
+	Tags        []string `json:"tags"`
+	// Price is in the currency the client asked for; BasePrice is then the
+	// stored price it was converted from.
+	Price     money.Money    `json:"price"`
+	BasePrice *money.Money   `json:"basePrice,omitempty"`
+	Stock     int            `json:"stock"`
+	Cover     *CoverResponse `json:"cover,omitempty"`
+	Version   int            `json:"version"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+// CoverResponse links to the uploaded cover and its resized variants.
+type CoverResponse struct {
+	Original  string `json:"original"`
+	Medium    string `json:"medium"`
+	Thumbnail string `json:"thumbnail"`
+}
+
+type ListResponse struct {
+	Books []BookResponse `json:"books"`
+	Page  int            `json:"page"`
+	Limit int            `json:"limit"`
+	Total int64          `json:"total"`
 }
@@ -0,0 +1,6 @@
+package dto
+
+type CategoryRequest struct {
+	Name     string `json:"name" binding:"required,notblank,max=100" example:"Fiction"`
+	ParentID *uint  `json:"parentId" binding:"omitempty,min=1" example:"1"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+type CategoryResponse struct {
+	ID       uint               `json:"id"`
+	Name     string             `json:"name"`
+	ParentID *uint              `json:"parentId"`
+	Depth    int                `json:"depth"`
+	Children []CategoryResponse `json:"children,omitempty"`
+}
@@ -0,0 +1,68 @@
+package dto
+
+import "time"
+
+// ImportRow is one line of a CSV or NDJSON catalogue upload. CSV files use
+// the json names as column headers, separate tags with semicolons and give
+// the price in major units with an optional currency column.
+type ImportRow struct {
+	Title       string       `json:"title" binding:"required,notblank,max=255"`
+	Author      string       `json:"author" binding:"required,notblank,max=255"`
+	Description string       `json:"description" binding:"required,notblank,max=2000"`
+	ISBN        string       `json:"isbn" binding:"required,isbn"`
+	CategoryID  *uint        `json:"categoryId" binding:"omitempty,min=1"`
+	Tags        []string     `json:"tags" binding:"omitempty,max=20,dive,notblank,max=50"`
+	Price       MoneyRequest `json:"price"`
+	Stock       int          `json:"stock" binding:"min=0,max=1000000"`
+}
+
+const (
+	ImportCreated = "created"
+	ImportUpdated = "updated"
+	ImportSkipped = "skipped"
+	ImportError   = "error"
+)
+
+type ImportRowResult struct {
+	Row    int    `json:"row"`
+	ISBN   string `json:"isbn,omitempty"`
+	BookID uint   `json:"bookId,omitempty"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type ImportReport struct {
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Skipped int               `json:"skipped"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+// Add records a row outcome and keeps the counters in step.
+func (r *ImportReport) Add(result ImportRowResult) {
+	r.Total++
+	switch result.Status {
+	case ImportCreated:
+		r.Created++
+	case ImportUpdated:
+		r.Updated++
+	case ImportSkipped:
+		r.Skipped++
+	default:
+		r.Failed++
+	}
+	r.Rows = append(r.Rows, result)
+}
+
+type ImportJobResponse struct {
+	ID         uint          `json:"id"`
+	Status     string        `json:"status"`
+	Format     string        `json:"format"`
+	Error      string        `json:"error,omitempty"`
+	Report     *ImportReport `json:"report,omitempty"`
+	CreatedAt  time.Time     `json:"createdAt"`
+	StartedAt  *time.Time    `json:"startedAt,omitempty"`
+	FinishedAt *time.Time    `json:"finishedAt,omitempty"`
+}
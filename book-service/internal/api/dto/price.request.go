@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+type SchedulePriceRequest struct {
+	// Price defaults to the book's currency and must match it.
+	Price         MoneyRequest `json:"price"`
+	EffectiveFrom time.Time    `json:"effectiveFrom" binding:"required" example:"2025-04-01T00:00:00Z"`
+	EffectiveTo   *time.Time   `json:"effectiveTo" binding:"omitempty,gtfield=EffectiveFrom" example:"2025-04-08T00:00:00Z"`
+}
+
+type PriceHistoryRequest struct {
+	Page  int `form:"page" binding:"omitempty,min=1" example:"1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100" example:"10"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+)
+
+// Price statuses relative to the time of the request.
+const (
+	PriceScheduled = "scheduled"
+	PriceActive    = "active"
+	PriceInactive  = "inactive"
+)
+
+type PriceResponse struct {
+	ID            uint        `json:"id"`
+	BookID        uint        `json:"bookId"`
+	Price         money.Money `json:"price"`
+	EffectiveFrom time.Time   `json:"effectiveFrom"`
+	EffectiveTo   *time.Time  `json:"effectiveTo,omitempty"`
+	Status        string      `json:"status"`
+	CreatedAt     time.Time   `json:"createdAt"`
+}
+
+type PriceHistoryResponse struct {
+	BookID       uint            `json:"bookId"`
+	CurrentPrice money.Money     `json:"currentPrice"`
+	Prices       []PriceResponse `json:"prices"`
+	Page         int             `json:"page"`
+	Limit        int             `json:"limit"`
+	Total        int64           `json:"total"`
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"book-service/internal/api/dto"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const DefaultMaxAge = time.Minute
+
+// MaxAgeFromEnv reads BOOK_HTTP_MAX_AGE, the Cache-Control max-age for
+// book reads. Zero disables client caching.
+func MaxAgeFromEnv() time.Duration {
+	maxAge, err := time.ParseDuration(os.Getenv("BOOK_HTTP_MAX_AGE"))
+	if err != nil || maxAge < 0 {
+		return DefaultMaxAge
+	}
+	return maxAge
+}
+
+// cacheControl lets clients and shared caches keep a book read for maxAge,
+// after which they revalidate with the ETag.
+func cacheControl(maxAge time.Duration) string {
+	if maxAge <= 0 {
+		return "no-cache"
+	}
+	return "public, max-age=" + strconv.Itoa(int(maxAge.Seconds())) + ", must-revalidate"
+}
+
+// etag is the strong entity tag for a book version. A price converted to
+// another currency makes a different representation, so the display
+// currency is appended, as in "5-USD".
+func etag(version int, display string) string {
+	if display != "" {
+		return `"` + strconv.Itoa(version) + "-" + display + `"`
+	}
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// responseTag is the entity tag of a book response.
+func responseTag(book *dto.BookResponse) string {
+	if book.BasePrice != nil {
+		return etag(book.Version, book.Price.Currency)
+	}
+	return etag(book.Version, "")
+}
+
+// notModified sends the validators of a book read and answers 304 Not
+// Modified when If-None-Match names tag, reporting whether it did. The
+// caller writes the body otherwise.
+func notModified(ctx *gin.Context, tag string, maxAge time.Duration) bool {
+	ctx.Header("ETag", tag)
+	ctx.Header("Cache-Control", cacheControl(maxAge))
+	if noneMatch(ctx.GetHeader("If-None-Match"), tag) {
+		ctx.Status(http.StatusNotModified)
+		return true
+	}
+	return false
+}
+
+// noneMatch reports whether an If-None-Match header matches tag. Weak
+// comparison applies, as RFC 9110 requires for If-None-Match.
+func noneMatch(header, tag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersion reads the version an update is conditional on. "*" yields
+// zero, meaning any current version. If-Match compares strongly, so weak
+// and malformed tags never match; a list may name several representations
+// of one version, but not several versions, since only one can be current.
+func ifMatchVersion(header string) (int, bool) {
+	version := 0
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return 0, true
+		}
+		tagged, ok := tagVersion(candidate)
+		switch {
+		case !ok:
+			continue
+		case version != 0 && tagged != version:
+			return 0, false
+		}
+		version = tagged
+	}
+	return version, version != 0
+}
+
+// tagVersion reads the version out of a strong entity tag. Any
+// representation of the version will do.
+func tagVersion(tag string) (int, bool) {
+	if len(tag) < 3 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+	value, _, _ := strings.Cut(tag[1:len(tag)-1], "-")
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
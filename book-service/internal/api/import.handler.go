@@ -0,0 +1,161 @@
+package api
+
+import (
+	"book-service/internal"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	genericResponse "github.com/fahrizalvianaz/shared-response/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+// ImportLimits bounds catalogue uploads. Uploads over SyncMaxBytes, or of
+// unknown length, are queued as jobs instead of being imported inline.
+type ImportLimits struct {
+	MaxBytes     int64
+	SyncMaxBytes int64
+}
+
+// ImportLimitsFromEnv reads IMPORT_MAX_BYTES (50 MiB) and
+// IMPORT_SYNC_MAX_BYTES (1 MiB).
+func ImportLimitsFromEnv() ImportLimits {
+	return ImportLimits{
+		MaxBytes:     envBytes("IMPORT_MAX_BYTES", 50<<20),
+		SyncMaxBytes: envBytes("IMPORT_SYNC_MAX_BYTES", 1<<20),
+	}
+}
+
+func envBytes(key string, fallback int64) int64 {
+	value, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+type ImportHandler struct {
+	importService internal.ImportService
+	limits        ImportLimits
+}
+
+func NewImportHandler(importService internal.ImportService, limits ImportLimits) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+		limits:        limits,
+	}
+}
+
+// Import accepts a CSV or NDJSON catalogue, either as the request body or
+// as the "file" field of a multipart form. Small uploads are imported
+// inline and answered with the row report; large ones, or any upload with
+// ?async=true, are queued and answered with 202 and the job to poll.
+func (h *ImportHandler) Import(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, h.limits.MaxBytes)
+
+	body, size, name, contentType, err := importUpload(ctx)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+	defer body.Close()
+
+	format := importFormat(ctx.Query("format"), name, contentType)
+	if format == "" {
+		apperror.Respond(ctx, internal.ErrImportFormat)
+		return
+	}
+
+	async := ctx.Query("async") == "true" || size < 0 || size > h.limits.SyncMaxBytes
+	if async {
+		job, err := h.importService.Enqueue(ctx.Request.Context(), format, body)
+		if err != nil {
+			apperror.Respond(ctx, err)
+			return
+		}
+		ctx.Header("Location", ctx.Request.URL.Path+"/"+strconv.FormatUint(uint64(job.ID), 10))
+		genericResponse.SuccessResponse(ctx, http.StatusAccepted, "Import queued", job)
+		return
+	}
+
+	report, err := h.importService.Import(ctx.Request.Context(), format, body)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Import completed", report)
+}
+
+func (h *ImportHandler) FindJob(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apperror.Respond(ctx, apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	response, err := h.importService.FindJob(ctx.Request.Context(), uint(id))
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Import job found", response)
+}
+
+// importUpload returns the uploaded content with its size (-1 when
+// unknown), file name and content type.
+func importUpload(ctx *gin.Context) (io.ReadCloser, int64, string, string, error) {
+	mediaType, _, _ := mime.ParseMediaType(ctx.ContentType())
+	if mediaType != "multipart/form-data" {
+		return ctx.Request.Body, ctx.Request.ContentLength, "", mediaType, nil
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, 0, "", "", internal.ErrImportTooLarge.Wrap(err)
+		}
+		return nil, 0, "", "", apperror.Validation("IMPORT_FILE_REQUIRED", "Multipart upload needs a file field").Wrap(err)
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, 0, "", "", apperror.Internal(err)
+	}
+	return file, header.Size, header.Filename, header.Header.Get("Content-Type"), nil
+}
+
+// importFormat picks the format from ?format, then the file extension,
+// then the content type.
+func importFormat(query, name, contentType string) string {
+	switch strings.ToLower(query) {
+	case internal.FormatCSV, internal.FormatNDJSON:
+		return strings.ToLower(query)
+	case "":
+	default:
+		return ""
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return internal.FormatCSV
+	case ".ndjson", ".jsonl":
+		return internal.FormatNDJSON
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv", "application/csv":
+		return internal.FormatCSV
+	case "application/x-ndjson", "application/ndjson", "application/jsonl", "application/x-jsonlines":
+		return internal.FormatNDJSON
+	}
+	return ""
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"book-service/internal"
+	"book-service/internal/api/dto"
+	"strconv"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	genericResponse "github.com/fahrizalvianaz/shared-response/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+type PriceHandler struct {
+	priceService internal.PriceService
+}
+
+func NewPriceHandler(priceService internal.PriceService) *PriceHandler {
+	return &PriceHandler{
+		priceService: priceService,
+	}
+}
+
+// Schedule adds a price for a future range. The book's price changes when
+// the range starts and reverts when it ends.
+func (h *PriceHandler) Schedule(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apperror.Respond(ctx, apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	var req dto.SchedulePriceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	response, err := h.priceService.Schedule(ctx.Request.Context(), uint(id), req)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.CreatedResponse(ctx, "Price scheduled", response)
+}
+
+func (h *PriceHandler) Cancel(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apperror.Respond(ctx, apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+	priceID, err := strconv.ParseUint(ctx.Param("priceId"), 10, 64)
+	if err != nil {
+		apperror.Respond(ctx, apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	if err := h.priceService.Cancel(ctx.Request.Context(), uint(id), uint(priceID)); err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Price cancelled", nil)
+}
+
+func (h *PriceHandler) History(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apperror.Respond(ctx, apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	var req dto.PriceHistoryRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	response, err := h.priceService.History(ctx.Request.Context(), uint(id), req)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Price history found", response)
+}
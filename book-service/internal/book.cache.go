@@ -0,0 +1,185 @@
+package internal
+
+import (
+	"book-service/internal/api/dto"
+	"book-service/pkg/cache"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const DefaultBookCacheTTL = 5 * time.Minute
+
+// BookCacheTTLFromEnv reads BOOK_CACHE_TTL, falling back to
+// DefaultBookCacheTTL.
+func BookCacheTTLFromEnv() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("BOOK_CACHE_TTL"))
+	if err != nil || ttl <= 0 {
+		return DefaultBookCacheTTL
+	}
+	return ttl
+}
+
+// bookCacheStripes is how many generation counters invalidations are
+// spread over. Books sharing a stripe only cost each other a cache fill.
+const bookCacheStripes = 256
+
+// cachedBookRepository is a cache-aside decorator. FindByID reads through
+// the cache, concurrent misses for the same book share one query, and every
+// write evicts the entry. Cache failures are logged and fall back to the
+// database so an unhealthy cache never fails a request.
+//
+// A load that read the row before a write committed must not put it back
+// after the write evicted it, so each eviction bumps a generation and loads
+// from an older generation neither fill the cache nor serve later callers.
+// The generations live in the decorator, so the guard only covers writes
+// made through the same instance: a process builds one and shares it, and
+// across replicas the TTL bounds staleness.
+type cachedBookRepository struct {
+	BookRepository
+	cache       cache.Cache
+	ttl         time.Duration
+	loads       singleflight.Group
+	generations [bookCacheStripes]atomic.Uint64
+}
+
+func NewCachedBookRepository(repository BookRepository, bookCache cache.Cache, ttl time.Duration) BookRepository {
+	return &cachedBookRepository{
+		BookRepository: repository,
+		cache:          bookCache,
+		ttl:            ttl,
+	}
+}
+
+func (c *cachedBookRepository) FindByID(ctx context.Context, id uint) (*Book, error) {
+	key := bookCacheKey(id)
+
+	payload, err := c.cache.Get(ctx, key)
+	if err == nil {
+		var book Book
+		if err := json.Unmarshal(payload, &book); err == nil {
+			bookCacheRequestsTotal.WithLabelValues("hit").Inc()
+			return &book, nil
+		}
+	} else if !errors.Is(err, cache.ErrMiss) {
+		slog.WarnContext(ctx, "book cache read failed", "key", key, "error", err)
+	}
+	bookCacheRequestsTotal.WithLabelValues("miss").Inc()
+
+	generation := c.generation(id).Load()
+	flight := key + "@" + strconv.FormatUint(generation, 10)
+	loaded, err, _ := c.loads.Do(flight, func() (interface{}, error) {
+		// The load outlives the caller that started it when others share it.
+		ctx := context.WithoutCancel(ctx)
+		book, err := c.BookRepository.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		c.fill(ctx, id, generation, book)
+		return book, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Callers sharing a load each get their own copy.
+	book := *loaded.(*Book)
+	return &book, nil
+}
+
+func (c *cachedBookRepository) Update(ctx context.Context, book *Book, version int) (*Book, error) {
+	defer c.invalidate(ctx, book.ID)
+	return c.BookRepository.Update(ctx, book, version)
+}
+
+func (c *cachedBookRepository) UpsertByISBN(ctx context.Context, books []Book) ([]UpsertResult, error) {
+	results, err := c.BookRepository.UpsertByISBN(ctx, books)
+	for _, result := range results {
+		if result.Status == dto.ImportUpdated {
+			c.invalidate(ctx, result.ID)
+		}
+	}
+	return results, err
+}
+
+func (c *cachedBookRepository) DecreaseStock(ctx context.Context, id uint, quantity int) (*Book, error) {
+	defer c.invalidate(ctx, id)
+	return c.BookRepository.DecreaseStock(ctx, id, quantity)
+}
+
+func (c *cachedBookRepository) DecreaseStocks(ctx context.Context, quantities map[uint]int) ([]Book, error) {
+	defer func() {
+		for id := range quantities {
+			c.invalidate(ctx, id)
+		}
+	}()
+	return c.BookRepository.DecreaseStocks(ctx, quantities)
+}
+
+func (c *cachedBookRepository) IncreaseStock(ctx context.Context, id uint, quantity int) (*Book, error) {
+	defer c.invalidate(ctx, id)
+	return c.BookRepository.IncreaseStock(ctx, id, quantity)
+}
+
+func (c *cachedBookRepository) SetCover(ctx context.Context, id uint, key *string) (*string, error) {
+	defer c.invalidate(ctx, id)
+	return c.BookRepository.SetCover(ctx, id, key)
+}
+
+func (c *cachedBookRepository) ApplyScheduledPrices(ctx context.Context, at time.Time) ([]uint, error) {
+	ids, err := c.BookRepository.ApplyScheduledPrices(ctx, at)
+	for _, id := range ids {
+		c.invalidate(ctx, id)
+	}
+	return ids, err
+}
+
+// fill caches book unless it was invalidated since generation was read. An
+// invalidation racing the write is caught by checking again afterwards.
+func (c *cachedBookRepository) fill(ctx context.Context, id uint, generation uint64, book *Book) {
+	if c.generation(id).Load() != generation {
+		return
+	}
+	payload, err := json.Marshal(book)
+	if err != nil {
+		return
+	}
+	key := bookCacheKey(id)
+	if err := c.cache.Set(ctx, key, payload, c.ttl); err != nil {
+		slog.WarnContext(ctx, "book cache write failed", "key", key, "error", err)
+		return
+	}
+	if c.generation(id).Load() != generation {
+		c.evict(ctx, key)
+	}
+}
+
+func (c *cachedBookRepository) generation(id uint) *atomic.Uint64 {
+	return &c.generations[id%bookCacheStripes]
+}
+
+// invalidate runs once the write has committed. Bumping the generation
+// first means a load that has not filled the cache yet never will.
+func (c *cachedBookRepository) invalidate(ctx context.Context, id uint) {
+	c.generation(id).Add(1)
+	c.evict(ctx, bookCacheKey(id))
+}
+
+func (c *cachedBookRepository) evict(ctx context.Context, key string) {
+	if err := c.cache.Delete(context.WithoutCancel(ctx), key); err != nil {
+		slog.WarnContext(ctx, "book cache invalidation failed", "key", key, "error", err)
+	}
+}
+
+// bookCacheKey is versioned so entries cached before prices gained a
+// currency are never read back.
+func bookCacheKey(id uint) string {
+	return "book:v2:" + strconv.FormatUint(uint64(id), 10)
+}
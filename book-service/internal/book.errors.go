@@ -0,0 +1,37 @@
+package internal
+
+import (
+	"errors"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBookNotFound      = apperror.NotFound("BOOK_NOT_FOUND", "Book not found")
+	ErrBookAlreadyExists = apperror.Conflict("BOOK_ALREADY_EXISTS", "A book with this ISBN, author or description already exists")
+	ErrISBNAlreadyExists = apperror.Conflict("ISBN_ALREADY_EXISTS", "A book with this ISBN already exists")
+	ErrInvalidISBN       = apperror.Validation("INVALID_ISBN", "Invalid ISBN")
+	ErrInvalidCurrency   = apperror.Validation("CURRENCY_UNSUPPORTED", "Price currency is not supported")
+	ErrOutOfStock        = apperror.Conflict("INSUFFICIENT_STOCK", "Not enough stock for this book")
+	ErrBookModified      = apperror.PreconditionFailed("BOOK_VERSION_MISMATCH", "Book was modified since it was last fetched")
+	ErrIfMatchRequired   = apperror.PreconditionRequired("IF_MATCH_REQUIRED", "If-Match header is required to update a book")
+)
+
+// bookError translates repository errors into domain errors.
+func bookError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrBookNotFound.Wrap(err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return ErrBookAlreadyExists.Wrap(err)
+	case errors.Is(err, ErrInsufficientStock):
+		return ErrOutOfStock.Wrap(err)
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return ErrCategoryNotFound.Wrap(err)
+	case errors.Is(err, ErrVersionConflict):
+		return ErrBookModified.Wrap(err)
+	default:
+		return apperror.Internal(err)
+	}
+}
@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"github.com/fahrizalvianaz/shared-observability/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	booksCreatedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "books_created_total",
+		Help:      "Number of books added to the catalogue.",
+	})
+
+	stockOutsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "book_stock_outs_total",
+		Help:      "Number of times a reservation left a book without stock.",
+	})
+
+	bookCacheRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "book_cache_requests_total",
+		Help:      "Number of book cache lookups by result (hit or miss).",
+	}, []string{"result"})
+
+	importRowsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "book_import_rows_total",
+		Help:      "Number of imported catalogue rows by outcome.",
+	}, []string{"status"})
+
+	exportedBooksTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "books_exported_total",
+		Help:      "Number of books written to catalogue exports by format.",
+	}, []string{"format"})
+
+	coverUploadsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "book_cover_uploads_total",
+		Help:      "Number of cover images uploaded.",
+	})
+
+	pricesAppliedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "book_prices_applied_total",
+		Help:      "Number of book prices changed by scheduled price ranges starting or ending.",
+	})
+)
@@ -1,23 +1,40 @@
 package internal
 
 import (
+	"book-service/internal/api/dto"
 	"time"
 
+	"github.com/fahrizalvianaz/shared-contracts/money"
 	"gorm.io/gorm"
 )
 
 type Book struct {
-	ID          uint           `gorm:"primaryKey"`
-	Title       string         `gorm:"column:title;not null"`
-	Author      string         `gorm:"column:author;uniqueIndex;not null"`
-	Description string         `gorm:"column:description;uniqueIndex;not null"`
-	Price       int            `gorm:"column:price;not null"`
-	Stock       int            `gorm:"column:stock;not null"`
-	CreatedAt   time.Time      `gorm:"column:created_at;autoCreateTime"`
-	ModifiedAt  time.Time      `gorm:"column:modified_at;autoUpdateTime"`
-	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	ID          uint               `gorm:"primaryKey"`
+	Title       string             `gorm:"column:title;not null"`
+	Author      string             `gorm:"column:author;not null"`
+	Description string             `gorm:"column:description;not null"`
+	ISBN10      *string            `gorm:"column:isbn10"`
+	ISBN13      *string            `gorm:"column:isbn13;uniqueIndex"`
+	CategoryID  *uint              `gorm:"column:category_id;index"`
+	Tags        []Tag              `gorm:"many2many:book_tags"`
+	CoverKey    *string            `gorm:"column:cover_key"`
+	Cover       *dto.CoverResponse `gorm:"-" json:",omitempty"`
+	// Price is in minor units of Currency.
+	Price    int64  `gorm:"column:price;not null"`
+	Currency string `gorm:"column:currency;not null;default:IDR"`
+	// Display is Price converted to the currency the client asked for.
+	Display    *money.Money   `gorm:"-" json:",omitempty"`
+	Stock      int            `gorm:"column:stock;not null"`
+	Version    int            `gorm:"column:version;not null;default:1"`
+	CreatedAt  time.Time      `gorm:"column:created_at;autoCreateTime"`
+	ModifiedAt time.Time      `gorm:"column:modified_at;autoUpdateTime"`
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
 }
 
 func (Book) TableName() string {
 	return "books"
 }
+
+func (b *Book) Money() money.Money {
+	return money.New(b.Price, b.Currency)
+}
@@ -1,14 +1,53 @@
 package internal
 
 import (
+	"book-service/internal/api/dto"
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
 
+	"github.com/fahrizalvianaz/shared-contracts/money"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var (
+	ErrInsufficientStock = errors.New("insufficient stock")
+	ErrVersionConflict   = errors.New("book version conflict")
+)
+
+// BookFilter narrows book listings. CategoryPath selects a category and
+// all of its subcategories; Tags keeps books carrying every listed tag.
+type BookFilter struct {
+	CategoryPath string
+	Tags         []string
+}
+
+// UpsertResult is the outcome of one book in UpsertByISBN. Status is one of
+// the dto.Import* values; Err is set when the row was rolled back.
+type UpsertResult struct {
+	ID     uint
+	Status string
+	Err    error
+}
+
 type BookRepository interface {
 	Create(ctx context.Context, book *Book) (*Book, error)
 	FindByID(ctx context.Context, id uint) (*Book, error)
+	FindAll(ctx context.Context, filter BookFilter, offset, limit int) ([]Book, int64, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]Book, error)
+	FindByISBN(ctx context.Context, isbn13 string) (*Book, error)
+	Update(ctx context.Context, book *Book, version int) (*Book, error)
+	UpsertByISBN(ctx context.Context, books []Book) ([]UpsertResult, error)
+	Stream(ctx context.Context, filter BookFilter, batchSize int, fn func(books []Book) error) error
+	DecreaseStock(ctx context.Context, id uint, quantity int) (*Book, error)
+	DecreaseStocks(ctx context.Context, quantities map[uint]int) ([]Book, error)
+	IncreaseStock(ctx context.Context, id uint, quantity int) (*Book, error)
+	SetCover(ctx context.Context, id uint, key *string) (previous *string, err error)
+	ApplyScheduledPrices(ctx context.Context, at time.Time) ([]uint, error)
 }
 
 type bookRepository struct {
@@ -21,19 +60,435 @@ func NewBookRepository(db *gorm.DB) BookRepository {
 	}
 }
 
+// Create inserts book and links its tags, creating tags that do not exist
+// yet.
 func (b *bookRepository) Create(ctx context.Context, book *Book) (*Book, error) {
-	result := b.db.WithContext(ctx).Create(&book)
+	err := b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tags, err := findOrCreateTags(tx, book.Tags)
+		if err != nil {
+			return err
+		}
+		book.Tags = tags
+		if err := tx.Omit("Tags.*").Create(book).Error; err != nil {
+			return err
+		}
+		return recordPrice(tx, book.ID, book.Money())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+func (b *bookRepository) FindByID(ctx context.Context, id uint) (*Book, error) {
+	var book Book
+	result := b.db.WithContext(ctx).Preload("Tags").First(&book, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &book, nil
+}
+
+func (b *bookRepository) FindAll(ctx context.Context, filter BookFilter, offset, limit int) ([]Book, int64, error) {
+	var books []Book
+	var total int64
+
+	db := filtered(b.db.WithContext(ctx).Model(&Book{}), filter)
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := db.Preload("Tags").Order("id").Offset(offset).Limit(limit).Find(&books)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return books, total, nil
+}
+
+func (b *bookRepository) FindByIDs(ctx context.Context, ids []uint) ([]Book, error) {
+	var books []Book
+	result := b.db.WithContext(ctx).Preload("Tags").Where("id IN ?", ids).Find(&books)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return books, nil
+}
+
+func (b *bookRepository) FindByISBN(ctx context.Context, isbn13 string) (*Book, error) {
+	var book Book
+	result := b.db.WithContext(ctx).Preload("Tags").Where("isbn13 = ?", isbn13).First(&book)
 	if result.Error != nil {
 		return nil, result.Error
 	}
+	return &book, nil
+}
+
+// Stream walks the filtered books in id order, batchSize at a time, using
+// keyset pagination so memory stays flat however large the catalogue is.
+func (b *bookRepository) Stream(ctx context.Context, filter BookFilter, batchSize int, fn func(books []Book) error) error {
+	var batch []Book
+	result := filtered(b.db.WithContext(ctx).Model(&Book{}), filter).
+		Preload("Tags").
+		FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		})
+	return result.Error
+}
+
+// Update saves the editable fields of book only while the stored row is
+// still at version, and bumps the version. A zero version skips the check.
+func (b *bookRepository) Update(ctx context.Context, book *Book, version int) (*Book, error) {
+	var updated Book
+	err := b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var previous Book
+		if err := tx.Model(&Book{}).Select("price", "currency").Where("id = ?", book.ID).Scan(&previous).Error; err != nil {
+			return err
+		}
+
+		query := tx.Model(&Book{}).Where("id = ?", book.ID)
+		if version > 0 {
+			query = query.Where("version = ?", version)
+		}
+		result := query.Updates(map[string]interface{}{
+			"title":       book.Title,
+			"author":      book.Author,
+			"description": book.Description,
+			"isbn10":      book.ISBN10,
+			"isbn13":      book.ISBN13,
+			"category_id": book.CategoryID,
+			"price":       book.Price,
+			"currency":    book.Currency,
+			"stock":       book.Stock,
+			"version":     gorm.Expr("version + 1"),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			if err := tx.First(&updated, book.ID).Error; err != nil {
+				return err
+			}
+			return ErrVersionConflict
+		}
+		if previous.Money() != book.Money() {
+			if err := recordPrice(tx, book.ID, book.Money()); err != nil {
+				return err
+			}
+		}
+
+		tags, err := findOrCreateTags(tx, book.Tags)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&Book{ID: book.ID}).Association("Tags").Replace(tags); err != nil {
+			return err
+		}
+
+		return tx.Preload("Tags").First(&updated, book.ID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// UpsertByISBN creates or updates books keyed by their ISBN-13 in one
+// transaction. Each book runs under its own savepoint, so a failing row is
+// reported in its result without aborting the rest of the batch. Books
+// whose stored data already matches are skipped.
+func (b *bookRepository) UpsertByISBN(ctx context.Context, books []Book) ([]UpsertResult, error) {
+	results := make([]UpsertResult, len(books))
+	err := b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		isbns := make([]string, 0, len(books))
+		for _, book := range books {
+			isbns = append(isbns, *book.ISBN13)
+		}
+
+		var existing []Book
+		if err := tx.Preload("Tags").Where("isbn13 IN ?", isbns).Find(&existing).Error; err != nil {
+			return err
+		}
+		byISBN := make(map[string]*Book, len(existing))
+		for i := range existing {
+			byISBN[*existing[i].ISBN13] = &existing[i]
+		}
+
+		for i := range books {
+			savepoint := fmt.Sprintf("upsert_%d", i)
+			if err := tx.SavePoint(savepoint).Error; err != nil {
+				return err
+			}
+			result, err := upsertBook(tx, &books[i], byISBN[*books[i].ISBN13])
+			if err != nil {
+				if err := tx.RollbackTo(savepoint).Error; err != nil {
+					return err
+				}
+				results[i] = UpsertResult{Status: dto.ImportError, Err: err}
+				continue
+			}
+			results[i] = result
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func upsertBook(tx *gorm.DB, book *Book, current *Book) (UpsertResult, error) {
+	tags, err := findOrCreateTags(tx, book.Tags)
+	if err != nil {
+		return UpsertResult{}, err
+	}
+	book.Tags = tags
+
+	if current == nil {
+		if err := tx.Omit("Tags.*").Create(book).Error; err != nil {
+			return UpsertResult{}, err
+		}
+		if err := recordPrice(tx, book.ID, book.Money()); err != nil {
+			return UpsertResult{}, err
+		}
+		return UpsertResult{ID: book.ID, Status: dto.ImportCreated}, nil
+	}
+
+	if sameBook(current, book) {
+		return UpsertResult{ID: current.ID, Status: dto.ImportSkipped}, nil
+	}
+
+	err = tx.Model(&Book{}).Where("id = ?", current.ID).Updates(map[string]interface{}{
+		"title":       book.Title,
+		"author":      book.Author,
+		"description": book.Description,
+		"isbn10":      book.ISBN10,
+		"category_id": book.CategoryID,
+		"price":       book.Price,
+		"currency":    book.Currency,
+		"stock":       book.Stock,
+		"version":     gorm.Expr("version + 1"),
+	}).Error
+	if err != nil {
+		return UpsertResult{}, err
+	}
+	if current.Money() != book.Money() {
+		if err := recordPrice(tx, current.ID, book.Money()); err != nil {
+			return UpsertResult{}, err
+		}
+	}
+	if err := tx.Model(&Book{ID: current.ID}).Association("Tags").Replace(tags); err != nil {
+		return UpsertResult{}, err
+	}
+	return UpsertResult{ID: current.ID, Status: dto.ImportUpdated}, nil
+}
+
+func sameBook(current, book *Book) bool {
+	if current.Title != book.Title || current.Author != book.Author || current.Description != book.Description ||
+		current.Money() != book.Money() || current.Stock != book.Stock {
+		return false
+	}
+	if (current.CategoryID == nil) != (book.CategoryID == nil) ||
+		(current.CategoryID != nil && *current.CategoryID != *book.CategoryID) {
+		return false
+	}
+	if len(current.Tags) != len(book.Tags) {
+		return false
+	}
+	names := make(map[string]bool, len(current.Tags))
+	for _, tag := range current.Tags {
+		names[tag.Name] = true
+	}
+	for _, tag := range book.Tags {
+		if !names[tag.Name] {
+			return false
+		}
+	}
+	return true
+}
+
+// DecreaseStock takes quantity out of the book stock in a single conditional
+// update so concurrent purchases can never push the stock below zero.
+//
+// The price is brought up to date in the same statement, so the returned
+// book carries the price in effect at the moment of the reservation even if
+// ApplyScheduledPrices has not caught up yet.
+func (b *bookRepository) DecreaseStock(ctx context.Context, id uint, quantity int) (*Book, error) {
+	var book *Book
+	err := b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		book, err = decreaseStock(tx, id, quantity, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 	return book, nil
 }
 
-func (b *bookRepository) FindByID(ctx context.Context, id uint) (*Book, error) {
+// DecreaseStocks is DecreaseStock for several books in one transaction:
+// either every quantity is taken or none is. Rows are updated in id order
+// so two overlapping batches cannot deadlock.
+func (b *bookRepository) DecreaseStocks(ctx context.Context, quantities map[uint]int) ([]Book, error) {
+	ids := make([]uint, 0, len(quantities))
+	for id := range quantities {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	books := make([]Book, 0, len(ids))
+	now := time.Now()
+	err := b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, id := range ids {
+			book, err := decreaseStock(tx, id, quantities[id], now)
+			if err != nil {
+				return err
+			}
+			books = append(books, *book)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+// IncreaseStock puts quantity back into the book stock.
+func (b *bookRepository) IncreaseStock(ctx context.Context, id uint, quantity int) (*Book, error) {
 	var book Book
-	result := b.db.WithContext(ctx).First(&book, id)
+	err := b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Book{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"stock":   gorm.Expr("stock + ?", quantity),
+			"version": gorm.Expr("version + 1"),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.First(&book, id).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+func decreaseStock(tx *gorm.DB, id uint, quantity int, now time.Time) (*Book, error) {
+	result := tx.Model(&Book{}).
+		Where("id = ? AND stock >= ?", id, quantity).
+		Updates(map[string]interface{}{
+			"stock":    gorm.Expr("stock - ?", quantity),
+			"price":    effectivePrice("price", now),
+			"currency": effectivePrice("currency", now),
+			"version":  gorm.Expr("version + 1"),
+		})
 	if result.Error != nil {
 		return nil, result.Error
 	}
+
+	var book Book
+	if err := tx.First(&book, id).Error; err != nil {
+		return nil, err
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("book %d: %w", id, ErrInsufficientStock)
+	}
 	return &book, nil
 }
+
+// SetCover points the book at a new set of cover images, or none when key
+// is nil, and returns the key it replaced so the caller can delete the old
+// images.
+func (b *bookRepository) SetCover(ctx context.Context, id uint, key *string) (*string, error) {
+	var book Book
+	err := b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "cover_key").First(&book, id).Error; err != nil {
+			return err
+		}
+		return tx.Model(&Book{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"cover_key": key,
+			"version":   gorm.Expr("version + 1"),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return book.CoverKey, nil
+}
+
+// ApplyScheduledPrices copies the price in effect at into every book whose
+// price differs from it and returns the ids of the books that changed. It
+// is a single idempotent statement, so any number of instances can run it.
+func (b *bookRepository) ApplyScheduledPrices(ctx context.Context, at time.Time) ([]uint, error) {
+	var ids []uint
+	result := b.db.WithContext(ctx).Raw(`UPDATE books
+		SET price = effective.price, currency = effective.currency, version = books.version + 1, modified_at = @at
+		FROM (
+			SELECT DISTINCT ON (book_id) book_id, price, currency FROM book_prices
+			WHERE effective_from <= @at AND (effective_to IS NULL OR effective_to > @at)
+			ORDER BY book_id, effective_from DESC, id DESC
+		) AS effective
+		WHERE books.id = effective.book_id AND books.deleted_at IS NULL
+			AND (books.price <> effective.price OR books.currency <> effective.currency)
+		RETURNING books.id`, sql.Named("at", at)).Scan(&ids)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return ids, nil
+}
+
+// recordPrice starts an open-ended price from now. Direct price edits go
+// through it so they show up in the history and win over earlier schedules.
+func recordPrice(tx *gorm.DB, bookID uint, price money.Money) error {
+	return tx.Create(&BookPrice{BookID: bookID, Price: price.Amount, Currency: price.Currency, EffectiveFrom: time.Now()}).Error
+}
+
+// effectivePrice keeps column as it is unless a price range covers at.
+func effectivePrice(column string, at time.Time) clause.Expression {
+	return clause.NamedExpr{
+		SQL:  fmt.Sprintf("COALESCE(("+effectivePriceSQL+"), %s)", column, column),
+		Vars: []interface{}{sql.Named("at", at)},
+	}
+}
+
+func filtered(db *gorm.DB, filter BookFilter) *gorm.DB {
+	if filter.CategoryPath != "" {
+		db = db.Where("category_id IN (?)",
+			db.Session(&gorm.Session{NewDB: true}).Model(&Category{}).Select("id").Where("path LIKE ?", filter.CategoryPath+"%"))
+	}
+	if len(filter.Tags) > 0 {
+		db = db.Where("books.id IN (?)",
+			db.Session(&gorm.Session{NewDB: true}).Table("book_tags").
+				Select("book_tags.book_id").
+				Joins("JOIN tags ON tags.id = book_tags.tag_id").
+				Where("tags.name IN ?", filter.Tags).
+				Group("book_tags.book_id").
+				Having("COUNT(DISTINCT tags.name) = ?", len(filter.Tags)))
+	}
+	return db
+}
+
+// findOrCreateTags resolves tags by name, inserting the missing ones.
+func findOrCreateTags(tx *gorm.DB, tags []Tag) ([]Tag, error) {
+	if len(tags) == 0 {
+		return []Tag{}, nil
+	}
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+
+	err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var resolved []Tag
+	if err := tx.Where("name IN ?", names).Order("name").Find(&resolved).Error; err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
@@ -2,46 +2,96 @@ package internal
 
 import (
 	"book-service/internal/api/dto"
+	"book-service/pkg/blob"
 	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
 	"time"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-errors/validation"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPageLimit = 10
+	maxPageLimit     = 100
+	exportBatchSize  = 500
 )
 
 type BookService interface {
 	Create(ctx context.Context, request dto.CreateRequest) (*dto.CreateResponse, error)
 	FindByID(ctx context.Context, id uint) (*Book, error)
+	FindAll(ctx context.Context, request dto.ListRequest) (*dto.ListResponse, error)
+	FindByCategory(ctx context.Context, categoryID uint, request dto.ListRequest) (*dto.ListResponse, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]dto.BookResponse, error)
+	FindByISBN(ctx context.Context, isbn string) (*dto.BookResponse, error)
+	Update(ctx context.Context, id uint, version int, request dto.UpdateRequest) (*dto.BookResponse, error)
+	ReserveStock(ctx context.Context, id uint, request dto.ReserveRequest) (*dto.BookResponse, error)
+	ReserveStocks(ctx context.Context, quantities map[uint]int) ([]dto.BookResponse, error)
+	ReleaseStock(ctx context.Context, id uint, request dto.ReserveRequest) (*dto.BookResponse, error)
+	Export(ctx context.Context, request dto.ExportRequest, w io.Writer) error
 }
 
 type bookService struct {
-	bookRepository BookRepository
+	bookRepository     BookRepository
+	categoryRepository CategoryRepository
+	covers             blob.Store
+	rates              money.Rates
 }
 
-func NewBookService(bookRepository BookRepository) BookService {
+func NewBookService(bookRepository BookRepository, categoryRepository CategoryRepository, covers blob.Store, rates money.Rates) BookService {
 	return &bookService{
-		bookRepository: bookRepository,
+		bookRepository:     bookRepository,
+		categoryRepository: categoryRepository,
+		covers:             covers,
+		rates:              rates,
 	}
 }
 
 func (b *bookService) Create(ctx context.Context, request dto.CreateRequest) (*dto.CreateResponse, error) {
+	price, err := requestPrice(request.Price)
+	if err != nil {
+		return nil, err
+	}
 	book := &Book{
 		Title:       request.Title,
 		Author:      request.Author,
 		Description: request.Description,
-		Price:       request.Price,
+		Price:       price.Amount,
+		Currency:    price.Currency,
 		Stock:       request.Stock,
+		CategoryID:  request.CategoryID,
+		Tags:        toTags(request.Tags),
+	}
+	if err := b.assignISBN(ctx, book, request.ISBN); err != nil {
+		return nil, err
+	}
+	if err := b.checkCategory(ctx, book.CategoryID); err != nil {
+		return nil, err
 	}
 
 	result, err := b.bookRepository.Create(ctx, book)
 
 	if err != nil {
-		return nil, err
+		return nil, bookError(err)
 	}
+	booksCreatedTotal.Inc()
 
+	displayPrice, basePrice := b.display(ctx, result.Money())
 	response := &dto.CreateResponse{
 		ID:          result.ID,
 		Title:       result.Title,
 		Author:      result.Author,
 		Description: result.Description,
-		Price:       result.Price,
+		ISBN10:      stringValue(result.ISBN10),
+		ISBN13:      stringValue(result.ISBN13),
+		CategoryID:  result.CategoryID,
+		Tags:        tagNames(result.Tags),
+		Price:       displayPrice,
+		BasePrice:   basePrice,
 		Stock:       result.Stock,
 		CreatedAt:   time.Now(),
 	}
@@ -52,15 +102,319 @@ func (b *bookService) Create(ctx context.Context, request dto.CreateRequest) (*d
 func (b *bookService) FindByID(ctx context.Context, id uint) (*Book, error) {
 	result, err := b.bookRepository.FindByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, bookError(err)
 	}
 	book := &Book{
 		ID:          result.ID,
 		Title:       result.Title,
 		Author:      result.Author,
 		Description: result.Description,
+		ISBN10:      result.ISBN10,
+		ISBN13:      result.ISBN13,
+		CategoryID:  result.CategoryID,
+		Tags:        result.Tags,
 		Price:       result.Price,
+		Currency:    result.Currency,
 		Stock:       result.Stock,
+		Version:     result.Version,
+		Cover:       coverURLs(b.covers, result.CoverKey),
+	}
+	if display, base := b.display(ctx, result.Money()); base != nil {
+		book.Display = &display
 	}
 	return book, nil
 }
+
+func (b *bookService) FindAll(ctx context.Context, request dto.ListRequest) (*dto.ListResponse, error) {
+	return b.list(ctx, BookFilter{}, request)
+}
+
+// FindByCategory lists the books in a category and all of its
+// subcategories.
+func (b *bookService) FindByCategory(ctx context.Context, categoryID uint, request dto.ListRequest) (*dto.ListResponse, error) {
+	category, err := b.categoryRepository.FindByID(ctx, categoryID)
+	if err != nil {
+		return nil, categoryError(err)
+	}
+	return b.list(ctx, BookFilter{CategoryPath: category.Path}, request)
+}
+
+func (b *bookService) list(ctx context.Context, filter BookFilter, request dto.ListRequest) (*dto.ListResponse, error) {
+	page, limit := request.Page, request.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+
+	filter.Tags = tagNames(toTags(strings.Split(request.Tags, ",")))
+	books, total, err := b.bookRepository.FindAll(ctx, filter, (page-1)*limit, limit)
+	if err != nil {
+		return nil, bookError(err)
+	}
+
+	response := &dto.ListResponse{
+		Books: make([]dto.BookResponse, 0, len(books)),
+		Page:  page,
+		Limit: limit,
+		Total: total,
+	}
+	for _, book := range books {
+		response.Books = append(response.Books, b.toBookResponse(ctx, &book))
+	}
+
+	return response, nil
+}
+
+func (b *bookService) FindByIDs(ctx context.Context, ids []uint) ([]dto.BookResponse, error) {
+	books, err := b.bookRepository.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, bookError(err)
+	}
+
+	response := make([]dto.BookResponse, 0, len(books))
+	for _, book := range books {
+		response = append(response, b.toBookResponse(ctx, &book))
+	}
+	return response, nil
+}
+
+// FindByISBN accepts either ISBN form, with or without hyphens.
+func (b *bookService) FindByISBN(ctx context.Context, isbn string) (*dto.BookResponse, error) {
+	isbn13, ok := validation.ISBN13(isbn)
+	if !ok {
+		return nil, ErrInvalidISBN
+	}
+
+	book, err := b.bookRepository.FindByISBN(ctx, isbn13)
+	if err != nil {
+		return nil, bookError(err)
+	}
+
+	response := b.toBookResponse(ctx, book)
+	return &response, nil
+}
+
+// Update overwrites the book if it is still at version, as taken from the
+// client's If-Match header.
+func (b *bookService) Update(ctx context.Context, id uint, version int, request dto.UpdateRequest) (*dto.BookResponse, error) {
+	price, err := requestPrice(request.Price)
+	if err != nil {
+		return nil, err
+	}
+	book := &Book{
+		ID:          id,
+		Title:       request.Title,
+		Author:      request.Author,
+		Description: request.Description,
+		Price:       price.Amount,
+		Currency:    price.Currency,
+		Stock:       request.Stock,
+		CategoryID:  request.CategoryID,
+		Tags:        toTags(request.Tags),
+	}
+	if err := b.assignISBN(ctx, book, request.ISBN); err != nil {
+		return nil, err
+	}
+	if err := b.checkCategory(ctx, book.CategoryID); err != nil {
+		return nil, err
+	}
+
+	result, err := b.bookRepository.Update(ctx, book, version)
+	if err != nil {
+		return nil, bookError(err)
+	}
+
+	response := b.toBookResponse(ctx, result)
+	return &response, nil
+}
+
+// Export streams the books matching the list filters to w in the requested
+// format.
+func (b *bookService) Export(ctx context.Context, request dto.ExportRequest, w io.Writer) error {
+	// Exports carry stored prices, so they import back unchanged.
+	stored := money.WithDisplay(ctx, "")
+	writer := newExportWriter(request.Format, w, func(book *Book) dto.BookResponse {
+		return b.toBookResponse(stored, book)
+	})
+	if err := writer.Begin(); err != nil {
+		return err
+	}
+
+	filter := BookFilter{Tags: tagNames(toTags(strings.Split(request.Tags, ",")))}
+	err := b.bookRepository.Stream(ctx, filter, exportBatchSize, func(books []Book) error {
+		for i := range books {
+			if err := writer.Write(&books[i]); err != nil {
+				return err
+			}
+		}
+		exportedBooksTotal.WithLabelValues(request.Format).Add(float64(len(books)))
+		return nil
+	})
+	if err != nil {
+		return bookError(err)
+	}
+	return writer.End()
+}
+
+func (b *bookService) ReserveStock(ctx context.Context, id uint, request dto.ReserveRequest) (*dto.BookResponse, error) {
+	book, err := b.bookRepository.DecreaseStock(ctx, id, request.Quantity)
+	if err != nil {
+		return nil, bookError(err)
+	}
+	if book.Stock == 0 {
+		stockOutsTotal.Inc()
+	}
+
+	response := b.toBookResponse(ctx, book)
+	return &response, nil
+}
+
+// ReserveStocks reserves quantities, keyed by book id, all or nothing.
+func (b *bookService) ReserveStocks(ctx context.Context, quantities map[uint]int) ([]dto.BookResponse, error) {
+	books, err := b.bookRepository.DecreaseStocks(ctx, quantities)
+	if err != nil {
+		return nil, bookError(err)
+	}
+
+	responses := make([]dto.BookResponse, 0, len(books))
+	for i := range books {
+		if books[i].Stock == 0 {
+			stockOutsTotal.Inc()
+		}
+		responses = append(responses, b.toBookResponse(ctx, &books[i]))
+	}
+	return responses, nil
+}
+
+// ReleaseStock gives back stock taken out by a reservation, for purchases
+// that could not be completed and for returned loans.
+func (b *bookService) ReleaseStock(ctx context.Context, id uint, request dto.ReserveRequest) (*dto.BookResponse, error) {
+	book, err := b.bookRepository.IncreaseStock(ctx, id, request.Quantity)
+	if err != nil {
+		return nil, bookError(err)
+	}
+
+	response := b.toBookResponse(ctx, book)
+	return &response, nil
+}
+
+func (b *bookService) toBookResponse(ctx context.Context, book *Book) dto.BookResponse {
+	price, basePrice := b.display(ctx, book.Money())
+	return dto.BookResponse{
+		ID:          book.ID,
+		Title:       book.Title,
+		Author:      book.Author,
+		Description: book.Description,
+		ISBN10:      stringValue(book.ISBN10),
+		ISBN13:      stringValue(book.ISBN13),
+		CategoryID:  book.CategoryID,
+		Tags:        tagNames(book.Tags),
+		Price:       price,
+		BasePrice:   basePrice,
+		Stock:       book.Stock,
+		Cover:       coverURLs(b.covers, book.CoverKey),
+		Version:     book.Version,
+		CreatedAt:   book.CreatedAt,
+	}
+}
+
+// assignISBN stores isbn on book in both forms, ISBN-13 being the canonical
+// one. A book other than book itself already holding the ISBN is a
+// conflict; the unique index still guards against races.
+func (b *bookService) assignISBN(ctx context.Context, book *Book, isbn string) error {
+	if isbn == "" {
+		return nil
+	}
+	isbn13, ok := validation.ISBN13(isbn)
+	if !ok {
+		return ErrInvalidISBN
+	}
+
+	existing, err := b.bookRepository.FindByISBN(ctx, isbn13)
+	switch {
+	case err == nil && existing.ID != book.ID:
+		return ErrISBNAlreadyExists
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		return bookError(err)
+	}
+
+	book.ISBN13 = &isbn13
+	if isbn10, ok := validation.ISBN10(isbn13); ok {
+		book.ISBN10 = &isbn10
+	}
+	return nil
+}
+
+// display converts price into the currency the client asked for. Without
+// a request, or when no rate is known, the price is returned as stored and
+// base is nil.
+func (b *bookService) display(ctx context.Context, price money.Money) (converted money.Money, base *money.Money) {
+	currency := money.DisplayFrom(ctx)
+	if currency == "" || currency == price.Currency || b.rates == nil {
+		return price, nil
+	}
+	converted, err := money.Convert(price, currency, b.rates)
+	if err != nil {
+		slog.WarnContext(ctx, "price conversion failed", "from", price.Currency, "to", currency, "error", err)
+		return price, nil
+	}
+	return converted, &price
+}
+
+// requestPrice defaults the currency of a requested price and checks that
+// it is supported.
+func requestPrice(request dto.MoneyRequest) (money.Money, error) {
+	currency := request.Currency
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	if !money.Known(currency) {
+		return money.Money{}, ErrInvalidCurrency
+	}
+	return money.New(request.Amount, currency), nil
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func (b *bookService) checkCategory(ctx context.Context, categoryID *uint) error {
+	if categoryID == nil {
+		return nil
+	}
+	if _, err := b.categoryRepository.FindByID(ctx, *categoryID); err != nil {
+		return categoryError(err)
+	}
+	return nil
+}
+
+// toTags trims, lower-cases and de-duplicates tag names.
+func toTags(names []string) []Tag {
+	seen := make(map[string]bool, len(names))
+	tags := make([]Tag, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		tags = append(tags, Tag{Name: name})
+	}
+	return tags
+}
+
+func tagNames(tags []Tag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
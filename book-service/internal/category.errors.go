@@ -0,0 +1,27 @@
+package internal
+
+import (
+	"errors"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCategoryNotFound      = apperror.NotFound("CATEGORY_NOT_FOUND", "Category not found")
+	ErrCategoryAlreadyExists = apperror.Conflict("CATEGORY_ALREADY_EXISTS", "A category with this name already exists under the same parent")
+	ErrCategoryNotEmpty      = apperror.Conflict("CATEGORY_NOT_EMPTY", "Category still has subcategories or books")
+	ErrCategoryCycle         = apperror.Validation("CATEGORY_CYCLE", "A category cannot be moved under itself or one of its subcategories")
+)
+
+// categoryError translates repository errors into domain errors.
+func categoryError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrCategoryNotFound.Wrap(err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return ErrCategoryAlreadyExists.Wrap(err)
+	default:
+		return apperror.Internal(err)
+	}
+}
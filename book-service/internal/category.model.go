@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Category is a node in the catalogue tree. Path is the materialized path
+// of ids from the root, e.g. "/1/4/", so a subtree is a prefix match.
+type Category struct {
+	ID         uint      `gorm:"primaryKey"`
+	Name       string    `gorm:"column:name;not null"`
+	ParentID   *uint     `gorm:"column:parent_id"`
+	Path       string    `gorm:"column:path;not null"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
+	ModifiedAt time.Time `gorm:"column:modified_at;autoUpdateTime"`
+}
+
+func (Category) TableName() string {
+	return "categories"
+}
+
+// Depth is zero for root categories.
+func (c *Category) Depth() int {
+	return strings.Count(c.Path, "/") - 2
+}
+
+// IsAncestorOf reports whether other sits in the subtree below c.
+func (c *Category) IsAncestorOf(other *Category) bool {
+	return other.ID != c.ID && strings.HasPrefix(other.Path, c.Path)
+}
+
+func categoryPath(parent *Category, id uint) string {
+	prefix := "/"
+	if parent != nil {
+		prefix = parent.Path
+	}
+	return prefix + strconv.FormatUint(uint64(id), 10) + "/"
+}
+
+// Tag is a free-form label attached to books. Names are stored lower case.
+type Tag struct {
+	ID   uint   `gorm:"primaryKey"`
+	Name string `gorm:"column:name;uniqueIndex;not null"`
+}
+
+func (Tag) TableName() string {
+	return "tags"
+}
@@ -0,0 +1,138 @@
+package internal
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type CategoryRepository interface {
+	Create(ctx context.Context, category *Category, parent *Category) (*Category, error)
+	FindByID(ctx context.Context, id uint) (*Category, error)
+	FindAll(ctx context.Context) ([]Category, error)
+	FindChildren(ctx context.Context, parent *Category) ([]Category, error)
+	Update(ctx context.Context, category *Category, parent *Category) (*Category, error)
+	Delete(ctx context.Context, id uint) error
+	CountChildren(ctx context.Context, id uint) (int64, error)
+	CountBooks(ctx context.Context, id uint) (int64, error)
+}
+
+type categoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) CategoryRepository {
+	return &categoryRepository{
+		db: db,
+	}
+}
+
+// Create inserts category under parent. The path needs the new id, so it is
+// filled in within the same transaction.
+func (c *categoryRepository) Create(ctx context.Context, category *Category, parent *Category) (*Category, error) {
+	err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		category.Path = "/"
+		if err := tx.Create(category).Error; err != nil {
+			return err
+		}
+		category.Path = categoryPath(parent, category.ID)
+		return tx.Model(category).Update("path", category.Path).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (c *categoryRepository) FindByID(ctx context.Context, id uint) (*Category, error) {
+	var category Category
+	result := c.db.WithContext(ctx).First(&category, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &category, nil
+}
+
+// FindAll returns every category ordered by path, so parents come before
+// their children.
+func (c *categoryRepository) FindAll(ctx context.Context) ([]Category, error) {
+	var categories []Category
+	result := c.db.WithContext(ctx).Order("path").Find(&categories)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return categories, nil
+}
+
+// FindChildren returns the direct children of parent ordered by path. The
+// path prefix narrows the scan to parent's subtree through the path index.
+func (c *categoryRepository) FindChildren(ctx context.Context, parent *Category) ([]Category, error) {
+	var categories []Category
+	result := c.db.WithContext(ctx).
+		Where("path LIKE ? AND parent_id = ?", parent.Path+"%", parent.ID).
+		Order("path").
+		Find(&categories)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return categories, nil
+}
+
+// Update renames category and moves it under parent, rewriting the path of
+// the whole subtree when the parent changes.
+func (c *categoryRepository) Update(ctx context.Context, category *Category, parent *Category) (*Category, error) {
+	var updated Category
+	err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current Category
+		if err := tx.First(&current, category.ID).Error; err != nil {
+			return err
+		}
+
+		path := categoryPath(parent, category.ID)
+		result := tx.Model(&Category{}).Where("id = ?", category.ID).Updates(map[string]interface{}{
+			"name":      category.Name,
+			"parent_id": category.ParentID,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if path != current.Path {
+			err := tx.Model(&Category{}).
+				Where("path LIKE ?", current.Path+"%").
+				Update("path", gorm.Expr("CAST(? AS TEXT) || substr(path, ?)", path, len(current.Path)+1)).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.First(&updated, category.ID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (c *categoryRepository) Delete(ctx context.Context, id uint) error {
+	result := c.db.WithContext(ctx).Delete(&Category{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (c *categoryRepository) CountChildren(ctx context.Context, id uint) (int64, error) {
+	var count int64
+	err := c.db.WithContext(ctx).Model(&Category{}).Where("parent_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+func (c *categoryRepository) CountBooks(ctx context.Context, id uint) (int64, error) {
+	var count int64
+	err := c.db.WithContext(ctx).Model(&Book{}).Where("category_id = ?", id).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"book-service/internal/api/dto"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type CategoryService interface {
+	Create(ctx context.Context, request dto.CategoryRequest) (*dto.CategoryResponse, error)
+	FindByID(ctx context.Context, id uint) (*dto.CategoryResponse, error)
+	FindTree(ctx context.Context) ([]dto.CategoryResponse, error)
+	Update(ctx context.Context, id uint, request dto.CategoryRequest) (*dto.CategoryResponse, error)
+	Delete(ctx context.Context, id uint) error
+}
+
+type categoryService struct {
+	categoryRepository CategoryRepository
+}
+
+func NewCategoryService(categoryRepository CategoryRepository) CategoryService {
+	return &categoryService{
+		categoryRepository: categoryRepository,
+	}
+}
+
+func (c *categoryService) Create(ctx context.Context, request dto.CategoryRequest) (*dto.CategoryResponse, error) {
+	parent, err := c.parent(ctx, request.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := c.categoryRepository.Create(ctx, &Category{Name: request.Name, ParentID: request.ParentID}, parent)
+	if err != nil {
+		return nil, categoryError(err)
+	}
+
+	response := toCategoryResponse(category)
+	return &response, nil
+}
+
+// FindByID returns the category with its direct children.
+func (c *categoryService) FindByID(ctx context.Context, id uint) (*dto.CategoryResponse, error) {
+	category, err := c.categoryRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, categoryError(err)
+	}
+	children, err := c.categoryRepository.FindChildren(ctx, category)
+	if err != nil {
+		return nil, categoryError(err)
+	}
+
+	response := toCategoryResponse(category)
+	for _, child := range children {
+		response.Children = append(response.Children, toCategoryResponse(&child))
+	}
+	return &response, nil
+}
+
+// FindTree returns the root categories with their descendants nested.
+func (c *categoryService) FindTree(ctx context.Context) ([]dto.CategoryResponse, error) {
+	categories, err := c.categoryRepository.FindAll(ctx)
+	if err != nil {
+		return nil, categoryError(err)
+	}
+
+	children := make(map[uint][]Category)
+	var roots []Category
+	for _, category := range categories {
+		if category.ParentID == nil {
+			roots = append(roots, category)
+			continue
+		}
+		children[*category.ParentID] = append(children[*category.ParentID], category)
+	}
+
+	var build func(category Category) dto.CategoryResponse
+	build = func(category Category) dto.CategoryResponse {
+		response := toCategoryResponse(&category)
+		for _, child := range children[category.ID] {
+			response.Children = append(response.Children, build(child))
+		}
+		return response
+	}
+
+	tree := make([]dto.CategoryResponse, 0, len(roots))
+	for _, root := range roots {
+		tree = append(tree, build(root))
+	}
+	return tree, nil
+}
+
+// Update renames the category and moves it, along with its subtree, under
+// the requested parent.
+func (c *categoryService) Update(ctx context.Context, id uint, request dto.CategoryRequest) (*dto.CategoryResponse, error) {
+	category, err := c.categoryRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, categoryError(err)
+	}
+
+	parent, err := c.parent(ctx, request.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent != nil && (parent.ID == category.ID || category.IsAncestorOf(parent)) {
+		return nil, ErrCategoryCycle
+	}
+
+	category.Name = request.Name
+	category.ParentID = request.ParentID
+	updated, err := c.categoryRepository.Update(ctx, category, parent)
+	if err != nil {
+		return nil, categoryError(err)
+	}
+
+	response := toCategoryResponse(updated)
+	return &response, nil
+}
+
+// Delete only removes empty categories so books are never left pointing at
+// a missing one.
+func (c *categoryService) Delete(ctx context.Context, id uint) error {
+	children, err := c.categoryRepository.CountChildren(ctx, id)
+	if err != nil {
+		return categoryError(err)
+	}
+	books, err := c.categoryRepository.CountBooks(ctx, id)
+	if err != nil {
+		return categoryError(err)
+	}
+	if children > 0 || books > 0 {
+		return ErrCategoryNotEmpty
+	}
+
+	// A subcategory or book added since the counts were taken still
+	// holds a foreign key to the category.
+	err = c.categoryRepository.Delete(ctx, id)
+	switch {
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return ErrCategoryNotEmpty.Wrap(err)
+	case err != nil:
+		return categoryError(err)
+	}
+	return nil
+}
+
+func (c *categoryService) parent(ctx context.Context, parentID *uint) (*Category, error) {
+	if parentID == nil {
+		return nil, nil
+	}
+	parent, err := c.categoryRepository.FindByID(ctx, *parentID)
+	if err != nil {
+		return nil, categoryError(err)
+	}
+	return parent, nil
+}
+
+func toCategoryResponse(category *Category) dto.CategoryResponse {
+	return dto.CategoryResponse{
+		ID:       category.ID,
+		Name:     category.Name,
+		ParentID: category.ParentID,
+		Depth:    category.Depth(),
+	}
+}
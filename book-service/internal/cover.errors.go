@@ -0,0 +1,11 @@
+package internal
+
+import "github.com/fahrizalvianaz/shared-errors/apperror"
+
+var (
+	ErrCoverTooLarge   = apperror.Validation("COVER_TOO_LARGE", "Cover image exceeds the maximum upload size")
+	ErrCoverType       = apperror.Validation("COVER_TYPE_UNSUPPORTED", "Cover must be a JPEG, PNG or WebP image")
+	ErrCoverDimensions = apperror.Validation("COVER_DIMENSIONS_TOO_LARGE", "Cover image has too many pixels")
+	ErrCoverNotFound   = apperror.NotFound("COVER_NOT_FOUND", "Book has no cover")
+	ErrCoverRequired   = apperror.Validation("COVER_FILE_REQUIRED", "Multipart upload needs a file field")
+)
@@ -0,0 +1,221 @@
+package internal
+
+import (
+	"book-service/internal/api/dto"
+	"book-service/pkg/blob"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	DefaultCoverMaxBytes = 5 << 20
+	// maxCoverPixels bounds the decoded size, so a small file cannot expand
+	// into gigabytes of pixels.
+	maxCoverPixels = 25_000_000
+	coverQuality   = 85
+)
+
+// CoverMaxBytesFromEnv reads COVER_MAX_BYTES, falling back to
+// DefaultCoverMaxBytes.
+func CoverMaxBytesFromEnv() int64 {
+	size, err := strconv.ParseInt(os.Getenv("COVER_MAX_BYTES"), 10, 64)
+	if err != nil || size <= 0 {
+		return DefaultCoverMaxBytes
+	}
+	return size
+}
+
+var coverTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+// coverVariant is a resized copy that fits inside width x height.
+type coverVariant struct {
+	name          string
+	width, height int
+}
+
+var coverVariants = []coverVariant{
+	{name: "medium.jpg", width: 600, height: 900},
+	{name: "thumbnail.jpg", width: 160, height: 240},
+}
+
+type CoverService interface {
+	Upload(ctx context.Context, id uint, body io.Reader) (*dto.CoverResponse, error)
+	Delete(ctx context.Context, id uint) error
+}
+
+type coverService struct {
+	bookRepository BookRepository
+	store          blob.Store
+	maxBytes       int64
+}
+
+func NewCoverService(bookRepository BookRepository, store blob.Store, maxBytes int64) CoverService {
+	return &coverService{
+		bookRepository: bookRepository,
+		store:          store,
+		maxBytes:       maxBytes,
+	}
+}
+
+// Upload stores the image as sent plus its resized variants under a fresh
+// key, so cached URLs of the previous cover never serve the new one, and
+// then removes the previous cover.
+func (c *coverService) Upload(ctx context.Context, id uint, body io.Reader) (*dto.CoverResponse, error) {
+	if _, err := c.bookRepository.FindByID(ctx, id); err != nil {
+		return nil, bookError(err)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, c.maxBytes+1))
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		return nil, ErrCoverTooLarge.Wrap(err)
+	case err != nil:
+		return nil, apperror.Internal(err)
+	case int64(len(data)) > c.maxBytes:
+		return nil, ErrCoverTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if !coverTypes[contentType] {
+		return nil, ErrCoverType
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrCoverType.Wrap(err)
+	}
+	if config.Width*config.Height > maxCoverPixels {
+		return nil, ErrCoverDimensions
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrCoverType.Wrap(err)
+	}
+
+	key, err := newCoverKey(id)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	keys := coverKeys(key)
+	if err := c.store.Put(ctx, keys[0], contentType, data); err != nil {
+		return nil, apperror.Internal(err)
+	}
+	for i, variant := range coverVariants {
+		resized, err := resize(img, variant.width, variant.height)
+		if err == nil {
+			err = c.store.Put(ctx, keys[i+1], "image/jpeg", resized)
+		}
+		if err != nil {
+			c.remove(ctx, keys)
+			return nil, apperror.Internal(err)
+		}
+	}
+
+	previous, err := c.bookRepository.SetCover(ctx, id, &key)
+	if err != nil {
+		c.remove(ctx, keys)
+		return nil, bookError(err)
+	}
+	if previous != nil {
+		c.remove(ctx, coverKeys(*previous))
+	}
+	coverUploadsTotal.Inc()
+
+	return coverURLs(c.store, &key), nil
+}
+
+func (c *coverService) Delete(ctx context.Context, id uint) error {
+	previous, err := c.bookRepository.SetCover(ctx, id, nil)
+	if err != nil {
+		return bookError(err)
+	}
+	if previous == nil {
+		return ErrCoverNotFound
+	}
+	c.remove(ctx, coverKeys(*previous))
+	return nil
+}
+
+// remove deletes images that are no longer referenced. Failures only leave
+// orphans behind, so they are logged rather than returned.
+func (c *coverService) remove(ctx context.Context, keys []string) {
+	if err := c.store.Delete(context.WithoutCancel(ctx), keys...); err != nil {
+		slog.WarnContext(ctx, "cover cleanup failed", "keys", keys, "error", err)
+	}
+}
+
+func newCoverKey(id uint) (string, error) {
+	token := make([]byte, 8)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("covers/%d/%s", id, hex.EncodeToString(token)), nil
+}
+
+// coverKeys lists the original followed by each variant.
+func coverKeys(key string) []string {
+	keys := []string{key + "/original"}
+	for _, variant := range coverVariants {
+		keys = append(keys, key+"/"+variant.name)
+	}
+	return keys
+}
+
+// coverURLs is nil for books without a cover.
+func coverURLs(store blob.Store, key *string) *dto.CoverResponse {
+	if store == nil || key == nil {
+		return nil
+	}
+	keys := coverKeys(*key)
+	return &dto.CoverResponse{
+		Original:  store.URL(keys[0]),
+		Medium:    store.URL(keys[1]),
+		Thumbnail: store.URL(keys[2]),
+	}
+}
+
+// resize scales img to fit inside width x height, keeping its aspect ratio
+// and never enlarging it, and encodes the result as JPEG on a white
+// background.
+func resize(img image.Image, width, height int) ([]byte, error) {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w > width || h > height {
+		if w*height > h*width {
+			w, h = width, max(1, h*width/w)
+		} else {
+			w, h = max(1, w*height/h), height
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, dst, &jpeg.Options{Quality: coverQuality}); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,234 @@
+package internal
+
+import (
+	"book-service/internal/api/dto"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const FormatXML = "xml"
+
+// exportWriter encodes books one at a time so an export never holds more
+// than one batch in memory.
+type exportWriter interface {
+	Begin() error
+	Write(book *Book) error
+	End() error
+}
+
+// ExportContentType is the media type served for format.
+func ExportContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/xml; charset=utf-8"
+	}
+}
+
+func newExportWriter(format string, w io.Writer, response func(*Book) dto.BookResponse) exportWriter {
+	switch format {
+	case FormatCSV:
+		return &csvExportWriter{writer: csv.NewWriter(w)}
+	case FormatNDJSON:
+		return &ndjsonExportWriter{encoder: json.NewEncoder(w), response: response}
+	default:
+		return &onixExportWriter{w: w, encoder: xml.NewEncoder(w)}
+	}
+}
+
+// csvExportColumns match the import columns, so an export can be edited
+// and uploaded again.
+var csvExportColumns = []string{"id", "title", "author", "description", "isbn", "isbn10", "price", "currency", "stock", "categoryId", "tags"}
+
+type csvExportWriter struct {
+	writer *csv.Writer
+}
+
+func (c *csvExportWriter) Begin() error {
+	return c.writer.Write(csvExportColumns)
+}
+
+func (c *csvExportWriter) Write(book *Book) error {
+	categoryID := ""
+	if book.CategoryID != nil {
+		categoryID = strconv.FormatUint(uint64(*book.CategoryID), 10)
+	}
+	return c.writer.Write([]string{
+		strconv.FormatUint(uint64(book.ID), 10),
+		book.Title,
+		book.Author,
+		book.Description,
+		stringValue(book.ISBN13),
+		stringValue(book.ISBN10),
+		book.Money().Decimal(),
+		book.Currency,
+		strconv.Itoa(book.Stock),
+		categoryID,
+		strings.Join(tagNames(book.Tags), ";"),
+	})
+}
+
+func (c *csvExportWriter) End() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+type ndjsonExportWriter struct {
+	encoder  *json.Encoder
+	response func(*Book) dto.BookResponse
+}
+
+func (n *ndjsonExportWriter) Begin() error {
+	return nil
+}
+
+func (n *ndjsonExportWriter) Write(book *Book) error {
+	return n.encoder.Encode(n.response(book))
+}
+
+func (n *ndjsonExportWriter) End() error {
+	return nil
+}
+
+// onixExportWriter writes a simplified ONIX for Books 3.0 product feed:
+// identifiers, title, author, description, keywords, availability and
+// price, without the full schema's optional blocks.
+type onixExportWriter struct {
+	w       io.Writer
+	encoder *xml.Encoder
+}
+
+var onixMessage = xml.StartElement{
+	Name: xml.Name{Local: "ONIXMessage"},
+	Attr: []xml.Attr{
+		{Name: xml.Name{Local: "xmlns"}, Value: "http://ns.editeur.org/onix/3.0/reference"},
+		{Name: xml.Name{Local: "release"}, Value: "3.0"},
+	},
+}
+
+type onixHeader struct {
+	XMLName      xml.Name `xml:"Header"`
+	SenderName   string   `xml:"Sender>SenderName"`
+	SentDateTime string   `xml:"SentDateTime"`
+}
+
+type onixIdentifier struct {
+	ProductIDType string `xml:"ProductIDType"`
+	IDValue       string `xml:"IDValue"`
+}
+
+type onixSubject struct {
+	SubjectSchemeIdentifier string `xml:"SubjectSchemeIdentifier"`
+	SubjectHeadingText      string `xml:"SubjectHeadingText"`
+}
+
+type onixProduct struct {
+	XMLName            xml.Name         `xml:"Product"`
+	RecordReference    string           `xml:"RecordReference"`
+	NotificationType   string           `xml:"NotificationType"`
+	ProductIdentifiers []onixIdentifier `xml:"ProductIdentifier"`
+	Descriptive        struct {
+		ProductComposition string `xml:"ProductComposition"`
+		ProductForm        string `xml:"ProductForm"`
+		Title              struct {
+			TitleType string `xml:"TitleType"`
+			Element   struct {
+				Level string `xml:"TitleElementLevel"`
+				Text  string `xml:"TitleText"`
+			} `xml:"TitleElement"`
+		} `xml:"TitleDetail"`
+		Contributor struct {
+			SequenceNumber int    `xml:"SequenceNumber"`
+			Role           string `xml:"ContributorRole"`
+			PersonName     string `xml:"PersonName"`
+		} `xml:"Contributor"`
+		Subjects []onixSubject `xml:"Subject"`
+	} `xml:"DescriptiveDetail"`
+	Text struct {
+		TextType        string `xml:"TextType"`
+		ContentAudience string `xml:"ContentAudience"`
+		Text            string `xml:"Text"`
+	} `xml:"CollateralDetail>TextContent"`
+	Supply struct {
+		Availability string `xml:"ProductAvailability"`
+		OnHand       int    `xml:"Stock>OnHand"`
+		Price        struct {
+			PriceType    string `xml:"PriceType"`
+			PriceAmount  string `xml:"PriceAmount"`
+			CurrencyCode string `xml:"CurrencyCode"`
+		} `xml:"Price"`
+	} `xml:"ProductSupply>SupplyDetail"`
+}
+
+func (o *onixExportWriter) Begin() error {
+	if _, err := io.WriteString(o.w, xml.Header); err != nil {
+		return err
+	}
+	if err := o.encoder.EncodeToken(onixMessage); err != nil {
+		return err
+	}
+	return o.encoder.Encode(onixHeader{
+		SenderName:   "Bookstore",
+		SentDateTime: time.Now().UTC().Format("20060102T1504Z"),
+	})
+}
+
+func (o *onixExportWriter) Write(book *Book) error {
+	product := onixProduct{
+		RecordReference:  "bookstore-book-" + strconv.FormatUint(uint64(book.ID), 10),
+		NotificationType: "03",
+	}
+	// Code list 5: 15 is ISBN-13, 02 is ISBN-10, 01 a proprietary id.
+	product.ProductIdentifiers = append(product.ProductIdentifiers, onixIdentifier{"01", strconv.FormatUint(uint64(book.ID), 10)})
+	if book.ISBN13 != nil {
+		product.ProductIdentifiers = append(product.ProductIdentifiers, onixIdentifier{"15", *book.ISBN13})
+	}
+	if book.ISBN10 != nil {
+		product.ProductIdentifiers = append(product.ProductIdentifiers, onixIdentifier{"02", *book.ISBN10})
+	}
+
+	descriptive := &product.Descriptive
+	descriptive.ProductComposition = "00"
+	descriptive.ProductForm = "BA"
+	descriptive.Title.TitleType = "01"
+	descriptive.Title.Element.Level = "01"
+	descriptive.Title.Element.Text = book.Title
+	descriptive.Contributor.SequenceNumber = 1
+	descriptive.Contributor.Role = "A01"
+	descriptive.Contributor.PersonName = book.Author
+	for _, tag := range book.Tags {
+		// Scheme 20 is free keywords.
+		descriptive.Subjects = append(descriptive.Subjects, onixSubject{"20", tag.Name})
+	}
+
+	product.Text.TextType = "03"
+	product.Text.ContentAudience = "00"
+	product.Text.Text = book.Description
+
+	// Code list 65: 21 is in stock, 31 is out of stock.
+	product.Supply.Availability = "21"
+	if book.Stock == 0 {
+		product.Supply.Availability = "31"
+	}
+	product.Supply.OnHand = book.Stock
+	product.Supply.Price.PriceType = "02"
+	product.Supply.Price.PriceAmount = book.Money().Decimal()
+	product.Supply.Price.CurrencyCode = book.Currency
+
+	return o.encoder.Encode(product)
+}
+
+func (o *onixExportWriter) End() error {
+	if err := o.encoder.EncodeToken(onixMessage.End()); err != nil {
+		return err
+	}
+	return o.encoder.Flush()
+}
@@ -0,0 +1,25 @@
+package internal
+
+import (
+	"errors"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrImportFormat      = apperror.Validation("IMPORT_FORMAT_UNSUPPORTED", "Upload must be CSV or NDJSON")
+	ErrImportHeader      = apperror.Validation("IMPORT_INVALID_HEADER", "CSV header must include title, author, description, isbn and price")
+	ErrImportTooLarge    = apperror.Validation("IMPORT_TOO_LARGE", "Upload exceeds the maximum import size")
+	ErrImportJobNotFound = apperror.NotFound("IMPORT_JOB_NOT_FOUND", "Import job not found")
+)
+
+// importJobError translates repository errors into domain errors.
+func importJobError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrImportJobNotFound.Wrap(err)
+	default:
+		return apperror.Internal(err)
+	}
+}
@@ -0,0 +1,29 @@
+package internal
+
+import "time"
+
+const (
+	ImportPending   = "pending"
+	ImportRunning   = "running"
+	ImportCompleted = "completed"
+	ImportFailed    = "failed"
+)
+
+// ImportJob is an asynchronous catalogue import. The upload waits in
+// FilePath until a worker claims the job; Report holds the JSON encoded
+// dto.ImportReport once it completes.
+type ImportJob struct {
+	ID         uint       `gorm:"primaryKey"`
+	Status     string     `gorm:"column:status;not null"`
+	Format     string     `gorm:"column:format;not null"`
+	FilePath   string     `gorm:"column:file_path;not null"`
+	Report     string     `gorm:"column:report"`
+	Error      string     `gorm:"column:error"`
+	CreatedAt  time.Time  `gorm:"column:created_at;autoCreateTime"`
+	StartedAt  *time.Time `gorm:"column:started_at"`
+	FinishedAt *time.Time `gorm:"column:finished_at"`
+}
+
+func (ImportJob) TableName() string {
+	return "import_jobs"
+}
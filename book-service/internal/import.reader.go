@@ -0,0 +1,171 @@
+package internal
+
+import (
+	"book-service/internal/api/dto"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+
+	// maxImportLine bounds a single NDJSON line.
+	maxImportLine = 1 << 20
+)
+
+// rowError is a problem with one row; the import carries on after it.
+type rowError struct {
+	reason string
+}
+
+func (e *rowError) Error() string {
+	return e.reason
+}
+
+// rowReader yields one row at a time so uploads are never held in memory.
+// Next returns the row's line number and io.EOF once the input is done.
+type rowReader interface {
+	Next() (dto.ImportRow, int, error)
+}
+
+func newRowReader(format string, r io.Reader) (rowReader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(r)
+	case FormatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxImportLine)
+		return &ndjsonReader{scanner: scanner}, nil
+	default:
+		return nil, ErrImportFormat
+	}
+}
+
+var csvRequiredColumns = []string{"title", "author", "description", "isbn", "price"}
+
+type csvReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, ErrImportHeader.Wrap(err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+	for _, name := range csvRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, ErrImportHeader.Wrap(fmt.Errorf("missing column %q", name))
+		}
+	}
+	return &csvReader{reader: reader, columns: columns}, nil
+}
+
+func (c *csvReader) Next() (dto.ImportRow, int, error) {
+	record, err := c.reader.Read()
+	var parseErr *csv.ParseError
+	switch {
+	case errors.Is(err, io.EOF):
+		return dto.ImportRow{}, 0, io.EOF
+	case errors.As(err, &parseErr):
+		return dto.ImportRow{}, parseErr.StartLine, &rowError{reason: parseErr.Err.Error()}
+	case err != nil:
+		return dto.ImportRow{}, 0, err
+	}
+	line, _ := c.reader.FieldPos(0)
+
+	field := func(name string) string {
+		if i, ok := c.columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	row := dto.ImportRow{
+		Title:       field("title"),
+		Author:      field("author"),
+		Description: field("description"),
+		ISBN:        field("isbn"),
+	}
+	currency := field("currency")
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	price, err := money.Parse(field("price"), currency)
+	switch {
+	case errors.Is(err, money.ErrUnknownCurrency):
+		return row, line, &rowError{reason: "currency: " + ErrInvalidCurrency.Message}
+	case err != nil:
+		return row, line, &rowError{reason: "price: must be a decimal amount in " + currency}
+	}
+	row.Price = dto.MoneyRequest{Amount: price.Amount, Currency: price.Currency}
+	if row.Stock, err = csvInt(field("stock")); err != nil {
+		return row, line, &rowError{reason: "stock: must be a whole number"}
+	}
+	if value := field("categoryId"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return row, line, &rowError{reason: "categoryId: must be a whole number"}
+		}
+		categoryID := uint(id)
+		row.CategoryID = &categoryID
+	}
+	if value := field("tags"); value != "" {
+		row.Tags = strings.Split(value, ";")
+	}
+	return row, line, nil
+}
+
+func csvInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (n *ndjsonReader) Next() (dto.ImportRow, int, error) {
+	for n.scanner.Scan() {
+		n.line++
+		text := strings.TrimSpace(n.scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var row dto.ImportRow
+		if err := json.Unmarshal([]byte(text), &row); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				return row, n.line, &rowError{reason: importReason(apperror.BindError(err))}
+			}
+			return row, n.line, &rowError{reason: "invalid JSON: " + err.Error()}
+		}
+		return row, n.line, nil
+	}
+	if err := n.scanner.Err(); err != nil {
+		return dto.ImportRow{}, n.line + 1, err
+	}
+	return dto.ImportRow{}, 0, io.EOF
+}
@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *ImportJob) (*ImportJob, error)
+	FindByID(ctx context.Context, id uint) (*ImportJob, error)
+	Claim(ctx context.Context, staleBefore time.Time) (*ImportJob, error)
+	Finish(ctx context.Context, job *ImportJob) error
+}
+
+type importJobRepository struct {
+	db *gorm.DB
+}
+
+func NewImportJobRepository(db *gorm.DB) ImportJobRepository {
+	return &importJobRepository{
+		db: db,
+	}
+}
+
+func (i *importJobRepository) Create(ctx context.Context, job *ImportJob) (*ImportJob, error) {
+	result := i.db.WithContext(ctx).Create(job)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return job, nil
+}
+
+func (i *importJobRepository) FindByID(ctx context.Context, id uint) (*ImportJob, error) {
+	var job ImportJob
+	result := i.db.WithContext(ctx).First(&job, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &job, nil
+}
+
+// Claim marks the oldest pending job as running and returns it. Jobs left
+// running since before staleBefore, by a worker that died, are claimed
+// again. SKIP LOCKED lets several instances poll the same table. It returns
+// gorm.ErrRecordNotFound when there is nothing to do.
+func (i *importJobRepository) Claim(ctx context.Context, staleBefore time.Time) (*ImportJob, error) {
+	var jobs []ImportJob
+	result := i.db.WithContext(ctx).Raw(`
+		UPDATE import_jobs SET status = ?, started_at = ?
+		WHERE id = (
+			SELECT id FROM import_jobs
+			WHERE status = ? OR (status = ? AND started_at < ?)
+			ORDER BY id
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING *`,
+		ImportRunning, time.Now(), ImportPending, ImportRunning, staleBefore,
+	).Scan(&jobs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(jobs) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &jobs[0], nil
+}
+
+func (i *importJobRepository) Finish(ctx context.Context, job *ImportJob) error {
+	return i.db.WithContext(ctx).Model(&ImportJob{}).Where("id = ?", job.ID).Updates(map[string]interface{}{
+		"status":      job.Status,
+		"report":      job.Report,
+		"error":       job.Error,
+		"finished_at": job.FinishedAt,
+	}).Error
+}
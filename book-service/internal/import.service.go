@@ -0,0 +1,313 @@
+package internal
+
+import (
+	"book-service/internal/api/dto"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"github.com/fahrizalvianaz/shared-errors/validation"
+	"github.com/gin-gonic/gin/binding"
+	"gorm.io/gorm"
+)
+
+const (
+	importBatchSize = 200
+	// importStaleAfter is how long a running job may go unfinished before
+	// another worker takes it over.
+	importStaleAfter = 30 * time.Minute
+	// DefaultImportPollInterval is how often idle workers look for jobs.
+	DefaultImportPollInterval = 2 * time.Second
+)
+
+type ImportService interface {
+	// Import processes an upload inline and returns its report.
+	Import(ctx context.Context, format string, r io.Reader) (*dto.ImportReport, error)
+	// Enqueue spools an upload to disk and queues it for a worker.
+	Enqueue(ctx context.Context, format string, r io.Reader) (*dto.ImportJobResponse, error)
+	FindJob(ctx context.Context, id uint) (*dto.ImportJobResponse, error)
+	// Work runs queued jobs until ctx is cancelled.
+	Work(ctx context.Context) error
+}
+
+type importService struct {
+	bookRepository BookRepository
+	jobRepository  ImportJobRepository
+	dir            string
+	pollInterval   time.Duration
+}
+
+// NewImportService stores queued uploads under dir, which must be shared by
+// every instance that runs Work.
+func NewImportService(bookRepository BookRepository, jobRepository ImportJobRepository, dir string) ImportService {
+	return &importService{
+		bookRepository: bookRepository,
+		jobRepository:  jobRepository,
+		dir:            dir,
+		pollInterval:   DefaultImportPollInterval,
+	}
+}
+
+// ImportDirFromEnv reads IMPORT_DIR, defaulting to a book-imports folder in
+// the system temp directory.
+func ImportDirFromEnv() string {
+	if dir := os.Getenv("IMPORT_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.TempDir(), "book-imports")
+}
+
+func (i *importService) Import(ctx context.Context, format string, r io.Reader) (*dto.ImportReport, error) {
+	rows, err := newRowReader(format, r)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.ImportReport{Rows: []dto.ImportRowResult{}}
+	seen := make(map[string]int)
+	batch := make([]Book, 0, importBatchSize)
+	lines := make([]int, 0, importBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		results, err := i.bookRepository.UpsertByISBN(ctx, batch)
+		if err != nil {
+			return err
+		}
+		for n, result := range results {
+			row := dto.ImportRowResult{Row: lines[n], ISBN: *batch[n].ISBN13, BookID: result.ID, Status: result.Status}
+			if result.Err != nil {
+				row.Reason = importReason(bookError(result.Err))
+			}
+			report.Add(row)
+			importRowsTotal.WithLabelValues(row.Status).Inc()
+		}
+		batch, lines = batch[:0], lines[:0]
+		return nil
+	}
+	fail := func(line int, isbn, reason string) {
+		report.Add(dto.ImportRowResult{Row: line, ISBN: isbn, Status: dto.ImportError, Reason: reason})
+		importRowsTotal.WithLabelValues(dto.ImportError).Inc()
+	}
+
+	for {
+		row, line, err := rows.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var invalid *rowError
+		if errors.As(err, &invalid) {
+			fail(line, row.ISBN, invalid.reason)
+			continue
+		}
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return nil, ErrImportTooLarge.Wrap(err)
+			}
+			return nil, apperror.Validation("IMPORT_UNREADABLE", "Upload could not be read").Wrap(err)
+		}
+
+		if err := binding.Validator.ValidateStruct(&row); err != nil {
+			fail(line, row.ISBN, importReason(apperror.BindError(err)))
+			continue
+		}
+		book, err := importBook(row)
+		if err != nil {
+			fail(line, row.ISBN, importReason(err))
+			continue
+		}
+		if first, ok := seen[*book.ISBN13]; ok {
+			fail(line, row.ISBN, fmt.Sprintf("duplicate ISBN, first seen on row %d", first))
+			continue
+		}
+		seen[*book.ISBN13] = line
+
+		batch = append(batch, book)
+		lines = append(lines, line)
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return nil, bookError(err)
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, bookError(err)
+	}
+	// Rejected rows are reported straight away and valid ones per batch.
+	sort.SliceStable(report.Rows, func(a, b int) bool { return report.Rows[a].Row < report.Rows[b].Row })
+	return report, nil
+}
+
+func (i *importService) Enqueue(ctx context.Context, format string, r io.Reader) (*dto.ImportJobResponse, error) {
+	if format != FormatCSV && format != FormatNDJSON {
+		return nil, ErrImportFormat
+	}
+	if err := os.MkdirAll(i.dir, 0o750); err != nil {
+		return nil, apperror.Internal(err)
+	}
+	file, err := os.CreateTemp(i.dir, "import-*."+format)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	_, err = io.Copy(file, r)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, ErrImportTooLarge.Wrap(err)
+		}
+		return nil, apperror.Internal(err)
+	}
+
+	job, err := i.jobRepository.Create(ctx, &ImportJob{Status: ImportPending, Format: format, FilePath: file.Name()})
+	if err != nil {
+		os.Remove(file.Name())
+		return nil, importJobError(err)
+	}
+	return toImportJobResponse(job), nil
+}
+
+func (i *importService) FindJob(ctx context.Context, id uint) (*dto.ImportJobResponse, error) {
+	job, err := i.jobRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, importJobError(err)
+	}
+	return toImportJobResponse(job), nil
+}
+
+func (i *importService) Work(ctx context.Context) error {
+	ticker := time.NewTicker(i.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain the queue before waiting for the next tick.
+		for ctx.Err() == nil && i.runNext(ctx) {
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// runNext runs one queued job and reports whether there was one.
+func (i *importService) runNext(ctx context.Context) bool {
+	job, err := i.jobRepository.Claim(ctx, time.Now().Add(-importStaleAfter))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "import job claim failed", "error", err)
+		return false
+	}
+
+	slog.InfoContext(ctx, "import job started", "job_id", job.ID, "format", job.Format)
+	report, err := i.importFile(ctx, job)
+	if ctx.Err() != nil {
+		// Shutting down; the job goes stale and is picked up again.
+		return false
+	}
+
+	now := time.Now()
+	job.FinishedAt = &now
+	if err != nil {
+		job.Status = ImportFailed
+		job.Error = apperror.From(err).Message
+		slog.WarnContext(ctx, "import job failed", "job_id", job.ID, "error", err)
+	} else {
+		job.Status = ImportCompleted
+		payload, _ := json.Marshal(report)
+		job.Report = string(payload)
+		slog.InfoContext(ctx, "import job completed", "job_id", job.ID, "rows", report.Total, "failed", report.Failed)
+	}
+	if err := i.jobRepository.Finish(context.WithoutCancel(ctx), job); err != nil {
+		slog.ErrorContext(ctx, "import job not saved", "job_id", job.ID, "error", err)
+		return true
+	}
+	os.Remove(job.FilePath)
+	return true
+}
+
+func (i *importService) importFile(ctx context.Context, job *ImportJob) (*dto.ImportReport, error) {
+	file, err := os.Open(job.FilePath)
+	if err != nil {
+		return nil, apperror.Validation("IMPORT_FILE_MISSING", "Uploaded file is no longer available").Wrap(err)
+	}
+	defer file.Close()
+	return i.Import(ctx, job.Format, file)
+}
+
+func importBook(row dto.ImportRow) (Book, error) {
+	isbn13, ok := validation.ISBN13(row.ISBN)
+	if !ok {
+		return Book{}, ErrInvalidISBN
+	}
+	price, err := requestPrice(row.Price)
+	if err != nil {
+		return Book{}, err
+	}
+	book := Book{
+		Title:       row.Title,
+		Author:      row.Author,
+		Description: row.Description,
+		ISBN13:      &isbn13,
+		CategoryID:  row.CategoryID,
+		Tags:        toTags(row.Tags),
+		Price:       price.Amount,
+		Currency:    price.Currency,
+		Stock:       row.Stock,
+	}
+	if isbn10, ok := validation.ISBN10(isbn13); ok {
+		book.ISBN10 = &isbn10
+	}
+	return book, nil
+}
+
+// importReason flattens an error into the one line shown in the report.
+func importReason(err error) string {
+	appErr := apperror.From(err)
+	if len(appErr.Fields) == 0 {
+		return appErr.Message
+	}
+	reasons := make([]string, len(appErr.Fields))
+	for n, field := range appErr.Fields {
+		reasons[n] = field.Field + ": " + field.Message
+	}
+	return strings.Join(reasons, "; ")
+}
+
+func toImportJobResponse(job *ImportJob) *dto.ImportJobResponse {
+	response := &dto.ImportJobResponse{
+		ID:         job.ID,
+		Status:     job.Status,
+		Format:     job.Format,
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+	}
+	if job.Report != "" {
+		var report dto.ImportReport
+		if err := json.Unmarshal([]byte(job.Report), &report); err == nil {
+			response.Report = &report
+		}
+	}
+	return response
+}
@@ -0,0 +1,29 @@
+package internal
+
+import (
+	"errors"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPriceNotFound       = apperror.NotFound("PRICE_NOT_FOUND", "Price not found")
+	ErrPriceInPast         = apperror.Validation("PRICE_START_IN_PAST", "Scheduled prices must start in the future")
+	ErrPriceAlreadyStarted = apperror.Conflict("PRICE_ALREADY_STARTED", "Only prices that have not started yet can be cancelled")
+	ErrPriceCurrency       = apperror.Validation("PRICE_CURRENCY_MISMATCH", "Scheduled prices must use the book's currency")
+)
+
+// priceError translates repository errors into domain errors.
+func priceError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrPriceNotFound.Wrap(err)
+	case errors.Is(err, ErrPriceStarted):
+		return ErrPriceAlreadyStarted.Wrap(err)
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return ErrBookNotFound.Wrap(err)
+	default:
+		return apperror.Internal(err)
+	}
+}
@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+)
+
+// BookPrice is the price of a book over [EffectiveFrom, EffectiveTo). A nil
+// EffectiveTo is open-ended. Ranges may overlap: at any instant the range
+// that started last wins, so a temporary price layers over the regular one
+// and the regular one returns when it ends.
+type BookPrice struct {
+	ID            uint       `gorm:"primaryKey"`
+	BookID        uint       `gorm:"column:book_id;not null;index"`
+	Price         int64      `gorm:"column:price;not null"`
+	Currency      string     `gorm:"column:currency;not null;default:IDR"`
+	EffectiveFrom time.Time  `gorm:"column:effective_from;not null"`
+	EffectiveTo   *time.Time `gorm:"column:effective_to"`
+	CreatedAt     time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (BookPrice) TableName() string {
+	return "book_prices"
+}
+
+func (p *BookPrice) Money() money.Money {
+	return money.New(p.Price, p.Currency)
+}
+
+// effectivePriceSQL selects a column of the price in effect for books.id at
+// the time bound to @at.
+const effectivePriceSQL = `SELECT book_prices.%s FROM book_prices
+	WHERE book_prices.book_id = books.id
+	AND book_prices.effective_from <= @at
+	AND (book_prices.effective_to IS NULL OR book_prices.effective_to > @at)
+	ORDER BY book_prices.effective_from DESC, book_prices.id DESC
+	LIMIT 1`
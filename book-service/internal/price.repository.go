@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrPriceStarted is returned when cancelling a price that is already in
+// effect or over.
+var ErrPriceStarted = errors.New("price already started")
+
+type PriceRepository interface {
+	Create(ctx context.Context, price *BookPrice) (*BookPrice, error)
+	FindByBookID(ctx context.Context, bookID uint, offset, limit int) ([]BookPrice, int64, error)
+	FindEffective(ctx context.Context, bookID uint, at time.Time) (*BookPrice, error)
+	DeleteScheduled(ctx context.Context, bookID, id uint, now time.Time) error
+}
+
+type priceRepository struct {
+	db *gorm.DB
+}
+
+func NewPriceRepository(db *gorm.DB) PriceRepository {
+	return &priceRepository{
+		db: db,
+	}
+}
+
+func (p *priceRepository) Create(ctx context.Context, price *BookPrice) (*BookPrice, error) {
+	if err := p.db.WithContext(ctx).Create(price).Error; err != nil {
+		return nil, err
+	}
+	return price, nil
+}
+
+// FindByBookID lists the prices of a book, latest start first.
+func (p *priceRepository) FindByBookID(ctx context.Context, bookID uint, offset, limit int) ([]BookPrice, int64, error) {
+	var prices []BookPrice
+	var total int64
+
+	db := p.db.WithContext(ctx).Model(&BookPrice{}).Where("book_id = ?", bookID)
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := db.Order("effective_from DESC, id DESC").Offset(offset).Limit(limit).Find(&prices).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return prices, total, nil
+}
+
+// FindEffective returns the price in effect at, following the rule in
+// BookPrice.
+func (p *priceRepository) FindEffective(ctx context.Context, bookID uint, at time.Time) (*BookPrice, error) {
+	var price BookPrice
+	result := p.db.WithContext(ctx).
+		Where("book_id = ? AND effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)", bookID, at, at).
+		Order("effective_from DESC, id DESC").
+		First(&price)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &price, nil
+}
+
+// DeleteScheduled removes a price that has not started by now.
+func (p *priceRepository) DeleteScheduled(ctx context.Context, bookID, id uint, now time.Time) error {
+	return p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var price BookPrice
+		if err := tx.Where("book_id = ?", bookID).First(&price, id).Error; err != nil {
+			return err
+		}
+		result := tx.Where("effective_from > ?", now).Delete(&BookPrice{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPriceStarted
+		}
+		return nil
+	})
+}
@@ -0,0 +1,190 @@
+package internal
+
+import (
+	"book-service/internal/api/dto"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultPriceApplyInterval = time.Minute
+	// priceScheduleSlack tolerates clocks and request latency when a client
+	// schedules a price to start right away.
+	priceScheduleSlack = time.Minute
+)
+
+// PriceApplyIntervalFromEnv reads PRICE_APPLY_INTERVAL, falling back to
+// DefaultPriceApplyInterval.
+func PriceApplyIntervalFromEnv() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("PRICE_APPLY_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return DefaultPriceApplyInterval
+	}
+	return interval
+}
+
+type PriceService interface {
+	Schedule(ctx context.Context, bookID uint, request dto.SchedulePriceRequest) (*dto.PriceResponse, error)
+	History(ctx context.Context, bookID uint, request dto.PriceHistoryRequest) (*dto.PriceHistoryResponse, error)
+	Cancel(ctx context.Context, bookID, priceID uint) error
+	// Work applies prices as their ranges start and end until ctx is
+	// cancelled.
+	Work(ctx context.Context) error
+}
+
+type priceService struct {
+	bookRepository  BookRepository
+	priceRepository PriceRepository
+	interval        time.Duration
+}
+
+func NewPriceService(bookRepository BookRepository, priceRepository PriceRepository, interval time.Duration) PriceService {
+	return &priceService{
+		bookRepository:  bookRepository,
+		priceRepository: priceRepository,
+		interval:        interval,
+	}
+}
+
+func (p *priceService) Schedule(ctx context.Context, bookID uint, request dto.SchedulePriceRequest) (*dto.PriceResponse, error) {
+	now := time.Now()
+	if request.EffectiveFrom.Before(now.Add(-priceScheduleSlack)) {
+		return nil, ErrPriceInPast
+	}
+	book, err := p.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		return nil, bookError(err)
+	}
+	if request.Price.Currency == "" {
+		request.Price.Currency = book.Currency
+	}
+	if request.Price.Currency != book.Currency {
+		return nil, ErrPriceCurrency
+	}
+
+	price, err := p.priceRepository.Create(ctx, &BookPrice{
+		BookID:        bookID,
+		Price:         request.Price.Amount,
+		Currency:      request.Price.Currency,
+		EffectiveFrom: request.EffectiveFrom,
+		EffectiveTo:   request.EffectiveTo,
+	})
+	if err != nil {
+		return nil, priceError(err)
+	}
+
+	var activeID uint
+	if effective, err := p.priceRepository.FindEffective(ctx, bookID, now); err == nil {
+		activeID = effective.ID
+	}
+	response := toPriceResponse(price, now, activeID)
+	return &response, nil
+}
+
+// History lists the prices of a book, latest start first, and marks the one
+// in effect now.
+func (p *priceService) History(ctx context.Context, bookID uint, request dto.PriceHistoryRequest) (*dto.PriceHistoryResponse, error) {
+	page, limit := request.Page, request.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+
+	book, err := p.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		return nil, bookError(err)
+	}
+
+	now := time.Now()
+	response := &dto.PriceHistoryResponse{
+		BookID:       bookID,
+		CurrentPrice: book.Money(),
+		Page:         page,
+		Limit:        limit,
+	}
+	var activeID uint
+	effective, err := p.priceRepository.FindEffective(ctx, bookID, now)
+	switch {
+	case err == nil:
+		activeID = effective.ID
+		response.CurrentPrice = effective.Money()
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, priceError(err)
+	}
+
+	prices, total, err := p.priceRepository.FindByBookID(ctx, bookID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, priceError(err)
+	}
+	response.Total = total
+	response.Prices = make([]dto.PriceResponse, 0, len(prices))
+	for _, price := range prices {
+		response.Prices = append(response.Prices, toPriceResponse(&price, now, activeID))
+	}
+
+	return response, nil
+}
+
+func (p *priceService) Cancel(ctx context.Context, bookID, priceID uint) error {
+	if err := p.priceRepository.DeleteScheduled(ctx, bookID, priceID, time.Now()); err != nil {
+		return priceError(err)
+	}
+	return nil
+}
+
+func (p *priceService) Work(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.apply(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *priceService) apply(ctx context.Context) {
+	ids, err := p.bookRepository.ApplyScheduledPrices(ctx, time.Now())
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.ErrorContext(ctx, "applying scheduled prices failed", "error", err)
+		}
+		return
+	}
+	if len(ids) > 0 {
+		pricesAppliedTotal.Add(float64(len(ids)))
+		slog.InfoContext(ctx, "scheduled prices applied", "books", len(ids))
+	}
+}
+
+func toPriceResponse(price *BookPrice, now time.Time, activeID uint) dto.PriceResponse {
+	status := dto.PriceInactive
+	switch {
+	case price.EffectiveFrom.After(now):
+		status = dto.PriceScheduled
+	case price.ID == activeID:
+		status = dto.PriceActive
+	}
+	return dto.PriceResponse{
+		ID:            price.ID,
+		BookID:        price.BookID,
+		Price:         price.Money(),
+		EffectiveFrom: price.EffectiveFrom,
+		EffectiveTo:   price.EffectiveTo,
+		Status:        status,
+		CreatedAt:     price.CreatedAt,
+	}
+}
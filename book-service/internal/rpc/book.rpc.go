@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"book-service/internal"
+	"book-service/pkg/blob"
+	"time"
+
+	"github.com/fahrizalvianaz/shared-contracts/bookpb"
+	"github.com/fahrizalvianaz/shared-contracts/interceptor"
+	"github.com/fahrizalvianaz/shared-observability/tracing"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+const maxCallDuration = 10 * time.Second
+
+// NewServer builds the internal gRPC server that runs next to the gin router
+// and shares its service layer. The stock changing methods are only open to
+// services presenting serviceToken.
+func NewServer(db *gorm.DB, bookRepository internal.BookRepository, covers blob.Store, secretKey, serviceToken string) *grpc.Server {
+	bookService := internal.NewBookService(bookRepository, internal.NewCategoryRepository(db), covers, nil)
+
+	server := grpc.NewServer(
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(
+			interceptor.UnaryServerDeadline(maxCallDuration),
+			interceptor.UnaryServerServiceToken(serviceToken,
+				bookpb.BookService_ReserveStock_FullMethodName,
+				bookpb.BookService_ReserveStocks_FullMethodName,
+				bookpb.BookService_ReleaseStock_FullMethodName,
+			),
+			// The catalogue is public over REST too, so reads need no token.
+			interceptor.UnaryServerAuth(secretKey,
+				bookpb.BookService_GetBook_FullMethodName,
+				bookpb.BookService_GetBooks_FullMethodName,
+			),
+		),
+	)
+	bookpb.RegisterBookServiceServer(server, NewBookServer(bookService))
+
+	return server
+}
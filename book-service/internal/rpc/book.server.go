@@ -0,0 +1,114 @@
+package rpc
+
+import (
+	"book-service/internal"
+	"book-service/internal/api/dto"
+	"context"
+
+	"github.com/fahrizalvianaz/shared-contracts/bookpb"
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type BookServer struct {
+	bookpb.UnimplementedBookServiceServer
+	bookService internal.BookService
+}
+
+func NewBookServer(bookService internal.BookService) *BookServer {
+	return &BookServer{
+		bookService: bookService,
+	}
+}
+
+func (b *BookServer) GetBook(ctx context.Context, req *bookpb.GetBookRequest) (*bookpb.Book, error) {
+	books, err := b.bookService.FindByIDs(ctx, []uint{uint(req.GetId())})
+	if err != nil {
+		return nil, apperror.ToStatus(err)
+	}
+	if len(books) == 0 {
+		return nil, apperror.ToStatus(internal.ErrBookNotFound)
+	}
+	return toBook(&books[0]), nil
+}
+
+func (b *BookServer) GetBooks(ctx context.Context, req *bookpb.GetBooksRequest) (*bookpb.GetBooksResponse, error) {
+	ids := make([]uint, len(req.GetIds()))
+	for i, id := range req.GetIds() {
+		ids[i] = uint(id)
+	}
+
+	books, err := b.bookService.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, apperror.ToStatus(err)
+	}
+
+	response := &bookpb.GetBooksResponse{Books: make([]*bookpb.Book, 0, len(books))}
+	for i := range books {
+		response.Books = append(response.Books, toBook(&books[i]))
+	}
+	return response, nil
+}
+
+func (b *BookServer) ReserveStock(ctx context.Context, req *bookpb.ReserveStockRequest) (*bookpb.Book, error) {
+	if req.GetQuantity() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "quantity must be at least 1")
+	}
+
+	book, err := b.bookService.ReserveStock(ctx, uint(req.GetId()), dto.ReserveRequest{Quantity: int(req.GetQuantity())})
+	if err != nil {
+		return nil, apperror.ToStatus(err)
+	}
+	return toBook(book), nil
+}
+
+func (b *BookServer) ReserveStocks(ctx context.Context, req *bookpb.ReserveStocksRequest) (*bookpb.GetBooksResponse, error) {
+	if len(req.GetItems()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "items must not be empty")
+	}
+	quantities := make(map[uint]int, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		if item.GetQuantity() < 1 {
+			return nil, status.Error(codes.InvalidArgument, "quantity must be at least 1")
+		}
+		quantities[uint(item.GetId())] += int(item.GetQuantity())
+	}
+
+	books, err := b.bookService.ReserveStocks(ctx, quantities)
+	if err != nil {
+		return nil, apperror.ToStatus(err)
+	}
+
+	response := &bookpb.GetBooksResponse{Books: make([]*bookpb.Book, 0, len(books))}
+	for i := range books {
+		response.Books = append(response.Books, toBook(&books[i]))
+	}
+	return response, nil
+}
+
+func (b *BookServer) ReleaseStock(ctx context.Context, req *bookpb.ReserveStockRequest) (*bookpb.Book, error) {
+	if req.GetQuantity() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "quantity must be at least 1")
+	}
+
+	book, err := b.bookService.ReleaseStock(ctx, uint(req.GetId()), dto.ReserveRequest{Quantity: int(req.GetQuantity())})
+	if err != nil {
+		return nil, apperror.ToStatus(err)
+	}
+	return toBook(book), nil
+}
+
+func toBook(book *dto.BookResponse) *bookpb.Book {
+	return &bookpb.Book{
+		Id:          uint64(book.ID),
+		Title:       book.Title,
+		Author:      book.Author,
+		Description: book.Description,
+		Price:       book.Price.Amount,
+		Currency:    book.Price.Currency,
+		Stock:       int64(book.Stock),
+		CreatedAt:   timestamppb.New(book.CreatedAt),
+	}
+}
@@ -1,31 +1,126 @@
 package main
 
 import (
+	"book-service/internal"
+	"book-service/internal/rpc"
 	"book-service/migrations"
 	"book-service/pkg"
+	"book-service/pkg/blob"
+	"book-service/pkg/cache"
 	"book-service/routes"
-	"log"
+	"book-service/seeds"
+	"context"
+	"net"
+	"os"
 
 	configs "github.com/fahrizalvianaz/shared-configuration/configs"
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-observability/logging"
+	"github.com/fahrizalvianaz/shared-observability/tracing"
+	"github.com/fahrizalvianaz/shared-server/health"
+	"github.com/fahrizalvianaz/shared-server/idempotency"
+	"github.com/fahrizalvianaz/shared-server/roles"
+	"github.com/fahrizalvianaz/shared-server/server"
+	"gorm.io/gorm"
 )
 
 func main() {
+	logging.Setup("book-service")
+
 	cfg, err := configs.LoadConfig()
 	if err != nil {
-		log.Fatalf("Failed to load config : %v", err)
+		logging.Fatal("Failed to load config", err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		connect := func() (*gorm.DB, error) { return pkg.ConnectDB(cfg) }
+		if err := migrations.Command(os.Args[2:], connect); err != nil {
+			logging.Fatal("Migration command failed", err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		db, err := pkg.ConnectDB(cfg)
+		if err != nil {
+			logging.Fatal("Failed to connect to database", err)
+		}
+		if err := seeds.Command(os.Args[2:], db); err != nil {
+			logging.Fatal("Seed command failed", err)
+		}
+		return
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.LoadConfig("book-service"))
+	if err != nil {
+		logging.Fatal("Failed to set up tracing", err)
 	}
 
 	db, err := pkg.ConnectDB(cfg)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		logging.Fatal("Failed to connect to database", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		logging.Fatal("Failed to get database handle", err)
 	}
 
 	if err := migrations.Migrate(db); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
+		logging.Fatal("Failed to run migrations", err)
 	}
 
-	router := routes.Router(db)
+	grpcAddr := os.Getenv("GRPC_ADDR")
+	if grpcAddr == "" {
+		grpcAddr = ":9090"
+	}
+	listener, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		logging.Fatal("Failed to listen for grpc", err)
+	}
+	bookCache, err := cache.FromEnv("book-service:")
+	if err != nil {
+		logging.Fatal("Failed to set up cache", err)
+	}
+	covers, err := blob.FromEnv()
+	if err != nil {
+		logging.Fatal("Failed to set up blob store", err)
+	}
+	rates, err := money.RatesFromEnv()
+	if err != nil {
+		logging.Fatal("Failed to load exchange rates", err)
+	}
+	admins, err := roles.AdminsFromEnv()
+	if err != nil {
+		logging.Fatal("Failed to load admin users", err)
+	}
+	// One cached repository serves every entry point, so their writes evict
+	// through the same generation guard.
+	bookRepository := internal.NewCachedBookRepository(internal.NewBookRepository(db), bookCache, internal.BookCacheTTLFromEnv())
+	grpcServer := rpc.NewServer(db, bookRepository, covers, cfg.SecretKey, os.Getenv("SERVICE_TOKEN"))
+
+	checker := health.New(health.DefaultTimeout)
+	checker.Add("database", health.Ping(sqlDB))
+	checker.Add("migrations", func(ctx context.Context) error { return migrations.Status(ctx, db) })
+	// Reads fall back to the database, so a cache outage only degrades.
+	checker.AddOptional("cache", bookCache.Ping)
+	checker.Add("blob", covers.Ping)
+
+	router := routes.Router(db, bookRepository, covers, rates, admins, checker)
 	router.SetTrustedProxies(nil)
 
-	router.Run(":8080")
+	runner := server.New(server.LoadConfig(), router)
+	runner.OnDrain(checker.Drain)
+	runner.OnShutdown("tracing", shutdownTracing)
+	runner.OnShutdown("database", func(context.Context) error { return sqlDB.Close() })
+	runner.OnShutdown("cache", func(context.Context) error { return bookCache.Close() })
+	runner.AddWorker("grpc", server.ServeWorker(grpcServer, listener))
+	importService := internal.NewImportService(bookRepository, internal.NewImportJobRepository(db), internal.ImportDirFromEnv())
+	runner.AddWorker("book-import", importService.Work)
+	priceService := internal.NewPriceService(bookRepository, internal.NewPriceRepository(db), internal.PriceApplyIntervalFromEnv())
+	runner.AddWorker("book-prices", priceService.Work)
+	runner.AddWorker("idempotency-cleanup", idempotency.CleanupWorker(idempotency.NewGormStore(db), idempotency.DefaultCleanupInterval))
+
+	if err := runner.Run(context.Background()); err != nil {
+		logging.Fatal("Server stopped with error", err)
+	}
 }
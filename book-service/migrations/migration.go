@@ -1,22 +1,74 @@
 package migrations
 
 import (
-	model "book-service/internal"
+	"context"
+	"embed"
 	"fmt"
-	"log"
+	"io/fs"
+	"log/slog"
+	"os"
 
+	"github.com/fahrizalvianaz/shared-migrations/migrate"
 	"gorm.io/gorm"
 )
 
+//go:embed sql/*.sql
+var files embed.FS
+
+// Dir is where `migrate create` writes new files, relative to the service
+// root.
+const Dir = "migrations/sql"
+
+func NewMigrator(db *gorm.DB) (*migrate.Migrator, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	source, err := fs.Sub(files, "sql")
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(sqlDB, source, migrate.DefaultTable)
+}
+
 func Migrate(db *gorm.DB) error {
-	log.Println("Running database migrations...")
-	err := db.AutoMigrate(
-		&model.Book{},
-	)
+	slog.Info("Running database migrations...")
+	migrator, err := NewMigrator(db)
 	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
-	log.Println("Database migrations completed successfully")
+	slog.Info("Database migrations completed successfully")
 	return nil
 }
+
+// Status reports an error while migrations are pending, so readiness fails
+// until Migrate has run against the database.
+func Status(ctx context.Context, db *gorm.DB) error {
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	return migrator.Pending(ctx)
+}
+
+// Command runs the `migrate` subcommand. connect is only called for the
+// subcommands that need the database.
+func Command(args []string, connect func() (*gorm.DB, error)) error {
+	if len(args) > 0 && args[0] == "create" {
+		return migrate.Run(context.Background(), nil, Dir, args, os.Stdout)
+	}
+
+	db, err := connect()
+	if err != nil {
+		return err
+	}
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	return migrate.Run(context.Background(), migrator, Dir, args, os.Stdout)
+}
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS books (
+    id BIGSERIAL PRIMARY KEY,
+    title TEXT NOT NULL,
+    author TEXT NOT NULL,
+    description TEXT NOT NULL,
+    price BIGINT NOT NULL,
+    stock BIGINT NOT NULL,
+    created_at TIMESTAMPTZ,
+    modified_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_books_author ON books (author);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_books_description ON books (description);
+CREATE INDEX IF NOT EXISTS idx_books_deleted_at ON books (deleted_at);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_books_isbn13;
+
+ALTER TABLE books DROP COLUMN IF EXISTS isbn13;
+ALTER TABLE books DROP COLUMN IF EXISTS isbn10;
@@ -0,0 +1,4 @@
+ALTER TABLE books ADD COLUMN IF NOT EXISTS isbn10 TEXT;
+ALTER TABLE books ADD COLUMN IF NOT EXISTS isbn13 TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_books_isbn13 ON books (isbn13);
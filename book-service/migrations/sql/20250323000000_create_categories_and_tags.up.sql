@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    parent_id BIGINT REFERENCES categories (id),
+    path TEXT NOT NULL,
+    created_at TIMESTAMPTZ,
+    modified_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_parent_name ON categories (COALESCE(parent_id, 0), lower(name));
+CREATE INDEX IF NOT EXISTS idx_categories_path ON categories (path text_pattern_ops);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_name ON tags (name);
+
+CREATE TABLE IF NOT EXISTS book_tags (
+    book_id BIGINT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    tag_id BIGINT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (book_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_tags_tag_id ON book_tags (tag_id);
+
+ALTER TABLE books ADD COLUMN IF NOT EXISTS category_id BIGINT REFERENCES categories (id);
+
+CREATE INDEX IF NOT EXISTS idx_books_category_id ON books (category_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    status TEXT NOT NULL,
+    format TEXT NOT NULL,
+    file_path TEXT NOT NULL,
+    report TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ,
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_status ON import_jobs (status);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS book_prices (
+    id BIGSERIAL PRIMARY KEY,
+    book_id BIGINT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    price BIGINT NOT NULL CHECK (price >= 0),
+    effective_from TIMESTAMPTZ NOT NULL,
+    effective_to TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    CHECK (effective_to IS NULL OR effective_to > effective_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_prices_book_from ON book_prices (book_id, effective_from DESC, id DESC);
+
+-- Every existing book starts its history with the price it has now.
+INSERT INTO book_prices (book_id, price, effective_from, created_at)
+SELECT id, price, COALESCE(created_at, now()), now()
+FROM books
+WHERE NOT EXISTS (SELECT 1 FROM book_prices WHERE book_prices.book_id = books.id);
@@ -0,0 +1,5 @@
+UPDATE book_prices SET price = price / 100;
+UPDATE books SET price = price / 100;
+
+ALTER TABLE book_prices DROP COLUMN IF EXISTS currency;
+ALTER TABLE books DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,7 @@
+-- Prices move from whole rupiah to minor units (sen) with an explicit
+-- ISO 4217 currency.
+ALTER TABLE books ADD COLUMN currency TEXT NOT NULL DEFAULT 'IDR';
+ALTER TABLE book_prices ADD COLUMN currency TEXT NOT NULL DEFAULT 'IDR';
+
+UPDATE books SET price = price * 100;
+UPDATE book_prices SET price = price * 100;
@@ -0,0 +1,2 @@
+CREATE UNIQUE INDEX IF NOT EXISTS idx_books_description ON books (description);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_books_author ON books (author);
@@ -0,0 +1,4 @@
+-- ISBN-13 identifies a book; authors write more than one and descriptions
+-- may repeat.
+DROP INDEX IF EXISTS idx_books_author;
+DROP INDEX IF EXISTS idx_books_description;
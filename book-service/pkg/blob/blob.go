@@ -0,0 +1,22 @@
+// Package blob stores binary objects such as cover images. Backends are
+// interchangeable; keys are slash separated paths chosen by the caller.
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned by Get when the key does not exist.
+var ErrNotFound = errors.New("blob not found")
+
+type Store interface {
+	Put(ctx context.Context, key, contentType string, body []byte) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes keys, ignoring ones that do not exist.
+	Delete(ctx context.Context, keys ...string) error
+	// URL is where clients fetch key from.
+	URL(key string) string
+	Ping(ctx context.Context) error
+}
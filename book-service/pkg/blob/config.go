@@ -0,0 +1,47 @@
+package blob
+
+import (
+	"fmt"
+	"os"
+)
+
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+
+	DefaultDir     = "./data/blobs"
+	DefaultBaseURL = "/api/v1/books/covers"
+)
+
+// FromEnv builds the backend named by BLOB_BACKEND (local by default). The
+// local backend writes under BLOB_DIR and links to BLOB_BASE_URL; the s3
+// backend uses S3_ENDPOINT, S3_REGION, S3_BUCKET, S3_ACCESS_KEY,
+// S3_SECRET_KEY and, optionally, S3_PUBLIC_URL.
+func FromEnv() (Store, error) {
+	switch backend := os.Getenv("BLOB_BACKEND"); backend {
+	case "", BackendLocal:
+		return NewLocal(envOr("BLOB_DIR", DefaultDir), envOr("BLOB_BASE_URL", DefaultBaseURL)), nil
+	case BackendS3:
+		config := S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Region:    envOr("S3_REGION", "us-east-1"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			PublicURL: os.Getenv("S3_PUBLIC_URL"),
+		}
+		if config.Endpoint == "" || config.Bucket == "" {
+			return nil, fmt.Errorf("S3_ENDPOINT and S3_BUCKET are required for the s3 blob backend")
+		}
+		return NewS3(config, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown blob backend %q", backend)
+	}
+}
+
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
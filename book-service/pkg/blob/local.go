@@ -0,0 +1,90 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local keeps objects as files under a directory. It also serves them, so
+// the service can mount it at baseURL when no CDN sits in front.
+type Local struct {
+	dir     string
+	baseURL string
+	files   http.Handler
+}
+
+func NewLocal(dir, baseURL string) *Local {
+	return &Local{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		files:   http.FileServer(http.Dir(dir)),
+	}
+}
+
+func (l *Local) path(key string) string {
+	return filepath.Join(l.dir, filepath.FromSlash(key))
+}
+
+// Put writes to a temporary file and renames it, so readers never see a
+// partial object.
+func (l *Local) Put(ctx context.Context, key, contentType string, body []byte) error {
+	path := l.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(body); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+func (l *Local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	file, err := os.Open(l.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+func (l *Local) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		if err := os.Remove(l.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Local) URL(key string) string {
+	return l.baseURL + "/" + key
+}
+
+func (l *Local) Ping(ctx context.Context) error {
+	return os.MkdirAll(l.dir, 0o755)
+}
+
+// ServeHTTP serves objects by key relative to the request path. Directory
+// listings are refused so keys cannot be enumerated.
+func (l *Local) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/") {
+		http.NotFound(w, r)
+		return
+	}
+	l.files.ServeHTTP(w, r)
+}
@@ -0,0 +1,47 @@
+// Package cache provides the byte-oriented key/value backends used for
+// cache-aside reads. Backends are interchangeable; callers own encoding.
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrMiss is returned by Get when the key is absent or expired.
+var ErrMiss = errors.New("cache miss")
+
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+type noop struct{}
+
+// NewNoop disables caching: every Get misses and writes are discarded.
+func NewNoop() Cache {
+	return noop{}
+}
+
+func (noop) Get(context.Context, string) ([]byte, error) {
+	return nil, ErrMiss
+}
+
+func (noop) Set(context.Context, string, []byte, time.Duration) error {
+	return nil
+}
+
+func (noop) Delete(context.Context, ...string) error {
+	return nil
+}
+
+func (noop) Ping(context.Context) error {
+	return nil
+}
+
+func (noop) Close() error {
+	return nil
+}
@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+	BackendNone   = "none"
+
+	DefaultCapacity = 1000
+)
+
+// FromEnv builds the backend named by CACHE_BACKEND (memory by default).
+// The memory backend holds CACHE_CAPACITY entries; the redis backend
+// connects to REDIS_ADDR with REDIS_PASSWORD and REDIS_DB.
+func FromEnv(prefix string) (Cache, error) {
+	switch backend := os.Getenv("CACHE_BACKEND"); backend {
+	case "", BackendMemory:
+		capacity, err := strconv.Atoi(os.Getenv("CACHE_CAPACITY"))
+		if err != nil || capacity < 1 {
+			capacity = DefaultCapacity
+		}
+		return NewLRU(capacity), nil
+	case BackendRedis:
+		addr := os.Getenv("REDIS_ADDR")
+		if addr == "" {
+			addr = "localhost:6379"
+		}
+		db, _ := strconv.Atoi(os.Getenv("REDIS_DB"))
+		client := redis.NewClient(&redis.Options{
+			Addr:     addr,
+			Password: os.Getenv("REDIS_PASSWORD"),
+			DB:       db,
+		})
+		return NewRedis(client, prefix), nil
+	case BackendNone:
+		return NewNoop(), nil
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q", backend)
+	}
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisClient is the subset of redis commands the cache uses. It is
+// satisfied by *redis.Client, *redis.ClusterClient and redis.Ring, so any
+// Redis-compatible server (Valkey, KeyDB, Dragonfly) works.
+type RedisClient interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+	Ping(ctx context.Context) *redis.StatusCmd
+	Close() error
+}
+
+type redisCache struct {
+	client RedisClient
+	prefix string
+}
+
+// NewRedis stores entries in redis under prefix so several services can
+// share one instance.
+func NewRedis(client RedisClient, prefix string) Cache {
+	return &redisCache{client: client, prefix: prefix}
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrMiss
+	}
+	return value, err
+}
+
+func (c *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, c.prefix+key, value, ttl).Err()
+}
+
+func (c *redisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = c.prefix + key
+	}
+	return c.client.Del(ctx, prefixed...).Err()
+}
+
+func (c *redisCache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
+func (c *redisCache) Close() error {
+	return c.client.Close()
+}
@@ -2,13 +2,16 @@ package pkg
 
 import (
 	"fmt"
+	"log/slog"
 	"time"
 
 	configs "github.com/fahrizalvianaz/shared-configuration/configs"
+	"github.com/fahrizalvianaz/shared-observability/logging"
+	"github.com/fahrizalvianaz/shared-observability/metrics"
+	"github.com/fahrizalvianaz/shared-observability/tracing"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 func ConnectDB(cfg *configs.Config) (*gorm.DB, error) {
@@ -18,13 +21,18 @@ func ConnectDB(cfg *configs.Config) (*gorm.DB, error) {
 	dialector := postgres.Open(dsn)
 
 	db, err := gorm.Open(dialector, &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger:         logging.NewGormLogger(slog.Default(), logging.SlowThresholdFromEnv()),
+		TranslateError: true,
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
+	if err := db.Use(tracing.GormPlugin()); err != nil {
+		return nil, err
+	}
+
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, err
@@ -34,5 +42,9 @@ func ConnectDB(cfg *configs.Config) (*gorm.DB, error) {
 	sqlDB.SetMaxOpenConns(100)
 	sqlDB.SetConnMaxLifetime(time.Hour)
 
+	if err := metrics.RegisterDBStats(sqlDB, cfg.DBName); err != nil {
+		return nil, err
+	}
+
 	return db, nil
 }
@@ -1,17 +1,39 @@
 package routes
 
 import (
+	"book-service/internal"
 	"book-service/internal/api"
+	"book-service/pkg/blob"
 
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"github.com/fahrizalvianaz/shared-errors/validation"
+	"github.com/fahrizalvianaz/shared-observability/logging"
+	"github.com/fahrizalvianaz/shared-observability/metrics"
+	"github.com/fahrizalvianaz/shared-observability/tracing"
+	"github.com/fahrizalvianaz/shared-server/currency"
+	"github.com/fahrizalvianaz/shared-server/health"
+	"github.com/fahrizalvianaz/shared-server/roles"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func Router(db *gorm.DB) *gin.Engine {
-	router := gin.Default()
-	group := router.Group("api/v1")
+func Router(db *gorm.DB, bookRepository internal.BookRepository, covers blob.Store, rates money.Rates, admins roles.Admins, checker *health.Checker) *gin.Engine {
+	validation.Register()
 
-	api.BookRoutes(group.Group("/books"), db)
+	router := gin.New()
+	router.Use(logging.RequestID())
+	router.Use(tracing.Middleware("book-service")...)
+	router.Use(metrics.Middleware("book-service"))
+	router.Use(logging.Middleware(), logging.Recovery())
+	router.NoRoute(apperror.NoRoute)
+	router.GET("/metrics", metrics.Handler())
+	router.GET("/healthz", gin.WrapF(checker.Liveness))
+	router.GET("/readyz", gin.WrapF(checker.Readiness))
+	group := router.Group("api/v1", currency.Middleware(rates))
+
+	api.BookRoutes(group.Group("/books"), db, bookRepository, covers, rates, admins)
+	api.CategoryRoutes(group.Group("/categories"), db, bookRepository, covers, rates, admins)
 
 	return router
 
@@ -0,0 +1,159 @@
+package seeds
+
+import (
+	model "book-service/internal"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-errors/validation"
+	"github.com/fahrizalvianaz/shared-migrations/seed"
+	"gorm.io/gorm"
+)
+
+//go:embed fixtures.yaml
+var defaultFixtures []byte
+
+// BookFixture gives the price in major units, in Currency or IDR when it
+// is empty.
+type BookFixture struct {
+	Title       string      `yaml:"title" json:"title"`
+	Author      string      `yaml:"author" json:"author"`
+	Description string      `yaml:"description" json:"description"`
+	ISBN        string      `yaml:"isbn,omitempty" json:"isbn,omitempty"`
+	Price       json.Number `yaml:"price" json:"price"`
+	Currency    string      `yaml:"currency,omitempty" json:"currency,omitempty"`
+	Stock       int         `yaml:"stock" json:"stock"`
+}
+
+type Fixtures struct {
+	Books []BookFixture `yaml:"books" json:"books"`
+}
+
+// Command runs the `seed` subcommand.
+func Command(args []string, db *gorm.DB) error {
+	opts, err := seed.ParseArgs(args)
+	if err != nil {
+		return err
+	}
+
+	var fixtures Fixtures
+	switch {
+	case opts.Generate > 0:
+		fixtures = Generate(opts.Generate)
+	case opts.File != "":
+		err = seed.ReadFile(opts.File, &fixtures)
+	default:
+		err = seed.Decode(defaultFixtures, false, &fixtures)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	count, err := Load(context.Background(), db, fixtures)
+	if err != nil {
+		return err
+	}
+	slog.Info("Seeded books", "count", count)
+	return nil
+}
+
+// Load upserts every book keyed by title and author, so running it again
+// updates the existing rows instead of duplicating them.
+func Load(ctx context.Context, db *gorm.DB, fixtures Fixtures) (int, error) {
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, fixture := range fixtures.Books {
+			currency := fixture.Currency
+			if currency == "" {
+				currency = money.DefaultCurrency
+			}
+			price, err := money.Parse(fixture.Price.String(), currency)
+			if err != nil {
+				return fmt.Errorf("invalid price for book %q: %w", fixture.Title, err)
+			}
+			book := model.Book{}
+			// A map, unlike a struct, also assigns zero values, so a fixture
+			// with no stock resets the stock of an existing book.
+			attrs := map[string]any{
+				"description": fixture.Description,
+				"price":       price.Amount,
+				"currency":    price.Currency,
+				"stock":       fixture.Stock,
+			}
+			if fixture.ISBN != "" {
+				isbn13, ok := validation.ISBN13(fixture.ISBN)
+				if !ok {
+					return fmt.Errorf("invalid ISBN %q for book %q", fixture.ISBN, fixture.Title)
+				}
+				attrs["isbn13"] = &isbn13
+				if isbn10, ok := validation.ISBN10(isbn13); ok {
+					attrs["isbn10"] = &isbn10
+				}
+			}
+			err = tx.Where(model.Book{Title: fixture.Title, Author: fixture.Author}).
+				Assign(attrs).
+				FirstOrCreate(&book).Error
+			if err != nil {
+				return fmt.Errorf("failed to seed book %q: %w", fixture.Title, err)
+			}
+			if err := seedPrice(tx, book.ID, price); err != nil {
+				return fmt.Errorf("failed to seed price of %q: %w", fixture.Title, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(fixtures.Books), nil
+}
+
+// seedPrice starts a price history entry when the seeded price is not the
+// one in effect, so the scheduled price job does not revert it.
+func seedPrice(tx *gorm.DB, bookID uint, price money.Money) error {
+	now := time.Now()
+	var current model.BookPrice
+	err := tx.Where("book_id = ? AND effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)", bookID, now, now).
+		Order("effective_from DESC, id DESC").
+		First(&current).Error
+	switch {
+	case err == nil && current.Money() == price:
+		return nil
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		return err
+	}
+	return tx.Create(&model.BookPrice{BookID: bookID, Price: price.Amount, Currency: price.Currency, EffectiveFrom: now}).Error
+}
+
+// booksPerAuthor is how many generated books share an author.
+const booksPerAuthor = 3
+
+// Generate builds n fake books. Book i is the same on every run. An author
+// writes a series of booksPerAuthor books, numbered so that title and
+// author, which Load upserts by, stay unique.
+func Generate(n int) Fixtures {
+	fixtures := Fixtures{Books: make([]BookFixture, 0, n)}
+	for i := 0; i < n; i++ {
+		r := seed.Rand(i)
+		first, volume := i-i%booksPerAuthor, i%booksPerAuthor+1
+		title := seed.Title(first)
+		if volume > 1 {
+			title = fmt.Sprintf("%s %d", title, volume)
+		}
+		author := seed.PersonName(i / booksPerAuthor)
+		fixtures.Books = append(fixtures.Books, BookFixture{
+			Title:       title,
+			Author:      author,
+			Description: fmt.Sprintf("%s, a novel by %s.", title, author),
+			Price:       json.Number(strconv.Itoa(seed.Between(r, 40, 250) * 1000)),
+			Stock:       seed.Between(r, 0, 50),
+		})
+	}
+	return fixtures
+}
@@ -0,0 +1,108 @@
+package blob_test
+
+import (
+	"book-service/pkg/blob"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func roundTrip(t *testing.T, store blob.Store) {
+	ctx := context.Background()
+	require.NoError(t, store.Ping(ctx))
+	require.NoError(t, store.Put(ctx, "covers/1/abc/original", "image/png", []byte("png bytes")))
+
+	body, err := store.Get(ctx, "covers/1/abc/original")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, "png bytes", string(data))
+
+	require.NoError(t, store.Delete(ctx, "covers/1/abc/original", "covers/1/missing"))
+	_, err = store.Get(ctx, "covers/1/abc/original")
+	assert.ErrorIs(t, err, blob.ErrNotFound)
+}
+
+func TestLocal_RoundTripAndServe(t *testing.T) {
+	store := blob.NewLocal(t.TempDir(), "/api/v1/books/covers/")
+	roundTrip(t, store)
+
+	require.NoError(t, store.Put(context.Background(), "covers/2/def/medium.jpg", "image/jpeg", []byte("jpeg bytes")))
+	assert.Equal(t, "/api/v1/books/covers/covers/2/def/medium.jpg", store.URL("covers/2/def/medium.jpg"))
+
+	w := httptest.NewRecorder()
+	store.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/covers/2/def/medium.jpg", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "jpeg bytes", w.Body.String())
+
+	w = httptest.NewRecorder()
+	store.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/covers/2/", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// fakeS3 is a path-style bucket that keeps objects in memory and insists on
+// signed requests.
+type fakeS3 struct {
+	mu      sync.Mutex
+	bucket  string
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=access/") ||
+		!strings.Contains(auth, "SignedHeaders=") || r.Header.Get("X-Amz-Content-Sha256") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+f.bucket)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	key = strings.TrimPrefix(key, "/")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodHead:
+	case http.MethodPut:
+		f.objects[key], _ = io.ReadAll(r.Body)
+	case http.MethodGet:
+		object, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(object)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3_RoundTrip(t *testing.T) {
+	server := httptest.NewServer(&fakeS3{bucket: "covers", objects: map[string][]byte{}})
+	defer server.Close()
+
+	store := blob.NewS3(blob.S3Config{
+		Endpoint:  server.URL,
+		Region:    "us-east-1",
+		Bucket:    "covers",
+		AccessKey: "access",
+		SecretKey: "secret",
+	}, server.Client())
+	roundTrip(t, store)
+
+	assert.Equal(t, server.URL+"/covers/covers/1/a%20b.jpg", store.URL("covers/1/a b.jpg"))
+
+	denied := blob.NewS3(blob.S3Config{Endpoint: server.URL, Bucket: "covers", AccessKey: "other"}, server.Client())
+	assert.Error(t, denied.Ping(context.Background()))
+}
@@ -0,0 +1,50 @@
+package cache_test
+
+import (
+	"book-service/pkg/cache"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRU(2)
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), 0))
+	require.NoError(t, c.Set(ctx, "b", []byte("2"), 0))
+	_, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	require.NoError(t, c.Set(ctx, "c", []byte("3"), 0))
+
+	_, err = c.Get(ctx, "b")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+	value, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+}
+
+func TestLRU_ExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRU(10)
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), 10*time.Millisecond))
+	time.Sleep(20 * time.Millisecond)
+
+	_, err := c.Get(ctx, "a")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+}
+
+func TestLRU_Delete(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRU(10)
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, c.Delete(ctx, "a", "missing"))
+
+	_, err := c.Get(ctx, "a")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+}
@@ -0,0 +1,175 @@
+package handler_test
+
+import (
+	"book-service/internal"
+	"book-service/internal/api"
+	"book-service/internal/api/dto"
+	mocks "book-service/test/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-errors/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const updateBody = `{"title":"Dune","author":"Frank Herbert","description":"Desert planet","price":{"amount":10000000},"stock":1}`
+
+func newRouter(t *testing.T) (*gin.Engine, *mocks.MockBookService) {
+	gin.SetMode(gin.TestMode)
+	validation.Register()
+	service := mocks.NewMockBookService(gomock.NewController(t))
+	handler := api.NewBookHandler(service, time.Minute)
+
+	router := gin.New()
+	router.GET("/books/isbn/:isbn", handler.FindByISBN)
+	router.GET("/books/:id", handler.FindByID)
+	router.PUT("/books/:id", handler.Update)
+	return router, service
+}
+
+func request(router http.Handler, method, header, value, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/books/1", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if value != "" {
+		req.Header.Set(header, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestBookHandler_FindByIDIfNoneMatch(t *testing.T) {
+	usd := money.New(625, "USD")
+	tests := []struct {
+		name    string
+		display *money.Money
+		header  string
+		status  int
+	}{
+		{"no header", nil, "", http.StatusOK},
+		{"matching tag", nil, `"3"`, http.StatusNotModified},
+		{"weak tag", nil, `W/"3"`, http.StatusNotModified},
+		{"any", nil, "*", http.StatusNotModified},
+		{"list", nil, `"1", W/"3"`, http.StatusNotModified},
+		{"stale tag", nil, `"2"`, http.StatusOK},
+		{"other currency", nil, `"3-USD"`, http.StatusOK},
+		{"display currency", &usd, `"3-USD"`, http.StatusNotModified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, service := newRouter(t)
+			service.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&internal.Book{ID: 1, Title: "Dune", Version: 3, Display: tt.display}, nil)
+
+			w := request(router, http.MethodGet, "If-None-Match", tt.header, "")
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.NotEmpty(t, w.Header().Get("ETag"))
+			if tt.status == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestBookHandler_FindByISBNIfNoneMatch(t *testing.T) {
+	idr := money.New(10000000, "IDR")
+	tests := []struct {
+		name      string
+		converted bool
+		header    string
+		status    int
+	}{
+		{"no header", false, "", http.StatusOK},
+		{"matching tag", false, `"3"`, http.StatusNotModified},
+		{"stale tag", false, `"2"`, http.StatusOK},
+		{"display currency", true, `"3-USD"`, http.StatusNotModified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, service := newRouter(t)
+			book := &dto.BookResponse{ID: 1, Title: "Dune", Version: 3, Price: idr}
+			if tt.converted {
+				book.Price, book.BasePrice = money.New(625, "USD"), &idr
+			}
+			service.EXPECT().FindByISBN(gomock.Any(), "9780441172719").Return(book, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/books/isbn/9780441172719", nil)
+			if tt.header != "" {
+				req.Header.Set("If-None-Match", tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.NotEmpty(t, w.Header().Get("ETag"))
+			if tt.status == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestBookHandler_UpdateIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		version int
+	}{
+		{"strong tag", `"3"`, 3},
+		{"display currency", `"3-USD"`, 3},
+		{"any", "*", 0},
+		{"one version listed twice", `"3", "3-USD"`, 3},
+		{"weak tags skipped", `W/"2", "3"`, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, service := newRouter(t)
+			service.EXPECT().Update(gomock.Any(), uint(1), tt.version, gomock.Any()).Return(&dto.BookResponse{ID: 1, Version: 4}, nil)
+
+			w := request(router, http.MethodPut, "If-Match", tt.header, updateBody)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestBookHandler_UpdatePreconditions(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		status int
+		code   string
+	}{
+		{"missing", "", http.StatusPreconditionRequired, "IF_MATCH_REQUIRED"},
+		{"weak tag", `W/"3"`, http.StatusPreconditionFailed, "BOOK_VERSION_MISMATCH"},
+		{"malformed", "3", http.StatusPreconditionFailed, "BOOK_VERSION_MISMATCH"},
+		{"several versions", `"2", "3"`, http.StatusPreconditionFailed, "BOOK_VERSION_MISMATCH"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, _ := newRouter(t)
+
+			w := request(router, http.MethodPut, "If-Match", tt.header, updateBody)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Contains(t, w.Body.String(), tt.code)
+		})
+	}
+
+	t.Run("stale version", func(t *testing.T) {
+		router, service := newRouter(t)
+		service.EXPECT().Update(gomock.Any(), uint(1), 2, gomock.Any()).Return(nil, internal.ErrBookModified)
+
+		w := request(router, http.MethodPut, "If-Match", `"2"`, updateBody)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		assert.Contains(t, w.Body.String(), "BOOK_VERSION_MISMATCH")
+	})
+}
@@ -0,0 +1,103 @@
+package handler_test
+
+import (
+	"book-service/internal/api"
+	mocks "book-service/test/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fahrizalvianaz/shared-errors/validation"
+	shared_middleware "github.com/fahrizalvianaz/shared-middleware"
+	"github.com/fahrizalvianaz/shared-server/roles"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSecret = "test-secret"
+	adminID    = 1
+	customerID = 2
+)
+
+// newCatalogueRouter mounts the real book and category routes. Requests
+// stopped by authentication never reach the database, so there is none.
+func newCatalogueRouter(t *testing.T) *gin.Engine {
+	t.Setenv("SECRET_KEY", testSecret)
+	gin.SetMode(gin.TestMode)
+	validation.Register()
+	repository := mocks.NewMockBookRepository(gomock.NewController(t))
+	admins := roles.Admins{adminID: true}
+
+	router := gin.New()
+	api.BookRoutes(router.Group("/books"), nil, repository, nil, nil, admins)
+	api.CategoryRoutes(router.Group("/categories"), nil, repository, nil, nil, admins)
+	return router
+}
+
+func bearer(t *testing.T, userID uint) string {
+	claims := shared_middleware.Claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return "Bearer " + token
+}
+
+func TestCatalogueRoutes_RequireAdmin(t *testing.T) {
+	router := newCatalogueRouter(t)
+	routes := []struct{ method, path string }{
+		{http.MethodPost, "/books/add"},
+		{http.MethodPost, "/books/import"},
+		{http.MethodGet, "/books/import/1"},
+		{http.MethodPut, "/books/1"},
+		{http.MethodPost, "/books/1/prices"},
+		{http.MethodDelete, "/books/1/prices/1"},
+		{http.MethodPost, "/books/1/cover"},
+		{http.MethodDelete, "/books/1/cover"},
+		{http.MethodPost, "/categories"},
+		{http.MethodPut, "/categories/1"},
+		{http.MethodDelete, "/categories/1"},
+	}
+	for _, route := range routes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			send := func(authorization string) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(route.method, route.path, strings.NewReader(`{}`))
+				req.Header.Set("Content-Type", "application/json")
+				if authorization != "" {
+					req.Header.Set("Authorization", authorization)
+				}
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				return w
+			}
+
+			assert.Equal(t, http.StatusUnauthorized, send("").Code)
+
+			w := send(bearer(t, customerID))
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			assert.Contains(t, w.Body.String(), "ADMIN_ONLY")
+		})
+	}
+}
+
+func TestCatalogueRoutes_AdminPassesThrough(t *testing.T) {
+	router := newCatalogueRouter(t)
+
+	req := httptest.NewRequest(http.MethodPut, "/books/1", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", bearer(t, adminID))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// The update handler ran and asked for If-Match.
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+}
@@ -0,0 +1,539 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: book-service/internal (interfaces: BookRepository,CategoryRepository,PriceRepository,ImportJobRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	internal "book-service/internal"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBookRepository is a mock of BookRepository interface.
+type MockBookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookRepositoryMockRecorder
+}
+
+// MockBookRepositoryMockRecorder is the mock recorder for MockBookRepository.
+type MockBookRepositoryMockRecorder struct {
+	mock *MockBookRepository
+}
+
+// NewMockBookRepository creates a new mock instance.
+func NewMockBookRepository(ctrl *gomock.Controller) *MockBookRepository {
+	mock := &MockBookRepository{ctrl: ctrl}
+	mock.recorder = &MockBookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookRepository) EXPECT() *MockBookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ApplyScheduledPrices mocks base method.
+func (m *MockBookRepository) ApplyScheduledPrices(arg0 context.Context, arg1 time.Time) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyScheduledPrices", arg0, arg1)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyScheduledPrices indicates an expected call of ApplyScheduledPrices.
+func (mr *MockBookRepositoryMockRecorder) ApplyScheduledPrices(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyScheduledPrices", reflect.TypeOf((*MockBookRepository)(nil).ApplyScheduledPrices), arg0, arg1)
+}
+
+// Create mocks base method.
+func (m *MockBookRepository) Create(arg0 context.Context, arg1 *internal.Book) (*internal.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*internal.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBookRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookRepository)(nil).Create), arg0, arg1)
+}
+
+// DecreaseStock mocks base method.
+func (m *MockBookRepository) DecreaseStock(arg0 context.Context, arg1 uint, arg2 int) (*internal.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecreaseStock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*internal.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecreaseStock indicates an expected call of DecreaseStock.
+func (mr *MockBookRepositoryMockRecorder) DecreaseStock(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecreaseStock", reflect.TypeOf((*MockBookRepository)(nil).DecreaseStock), arg0, arg1, arg2)
+}
+
+// DecreaseStocks mocks base method.
+func (m *MockBookRepository) DecreaseStocks(arg0 context.Context, arg1 map[uint]int) ([]internal.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecreaseStocks", arg0, arg1)
+	ret0, _ := ret[0].([]internal.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecreaseStocks indicates an expected call of DecreaseStocks.
+func (mr *MockBookRepositoryMockRecorder) DecreaseStocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecreaseStocks", reflect.TypeOf((*MockBookRepository)(nil).DecreaseStocks), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockBookRepository) FindAll(arg0 context.Context, arg1 internal.BookFilter, arg2, arg3 int) ([]internal.Book, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]internal.Book)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockBookRepositoryMockRecorder) FindAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBookRepository)(nil).FindAll), arg0, arg1, arg2, arg3)
+}
+
+// FindByID mocks base method.
+func (m *MockBookRepository) FindByID(arg0 context.Context, arg1 uint) (*internal.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*internal.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockBookRepositoryMockRecorder) FindByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockBookRepository)(nil).FindByID), arg0, arg1)
+}
+
+// FindByIDs mocks base method.
+func (m *MockBookRepository) FindByIDs(arg0 context.Context, arg1 []uint) ([]internal.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]internal.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockBookRepositoryMockRecorder) FindByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockBookRepository)(nil).FindByIDs), arg0, arg1)
+}
+
+// FindByISBN mocks base method.
+func (m *MockBookRepository) FindByISBN(arg0 context.Context, arg1 string) (*internal.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByISBN", arg0, arg1)
+	ret0, _ := ret[0].(*internal.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByISBN indicates an expected call of FindByISBN.
+func (mr *MockBookRepositoryMockRecorder) FindByISBN(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByISBN", reflect.TypeOf((*MockBookRepository)(nil).FindByISBN), arg0, arg1)
+}
+
+// IncreaseStock mocks base method.
+func (m *MockBookRepository) IncreaseStock(arg0 context.Context, arg1 uint, arg2 int) (*internal.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreaseStock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*internal.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncreaseStock indicates an expected call of IncreaseStock.
+func (mr *MockBookRepositoryMockRecorder) IncreaseStock(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseStock", reflect.TypeOf((*MockBookRepository)(nil).IncreaseStock), arg0, arg1, arg2)
+}
+
+// SetCover mocks base method.
+func (m *MockBookRepository) SetCover(arg0 context.Context, arg1 uint, arg2 *string) (*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCover", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCover indicates an expected call of SetCover.
+func (mr *MockBookRepositoryMockRecorder) SetCover(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCover", reflect.TypeOf((*MockBookRepository)(nil).SetCover), arg0, arg1, arg2)
+}
+
+// Stream mocks base method.
+func (m *MockBookRepository) Stream(arg0 context.Context, arg1 internal.BookFilter, arg2 int, arg3 func([]internal.Book) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockBookRepositoryMockRecorder) Stream(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockBookRepository)(nil).Stream), arg0, arg1, arg2, arg3)
+}
+
+// Update mocks base method.
+func (m *MockBookRepository) Update(arg0 context.Context, arg1 *internal.Book, arg2 int) (*internal.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*internal.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBookRepositoryMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookRepository)(nil).Update), arg0, arg1, arg2)
+}
+
+// UpsertByISBN mocks base method.
+func (m *MockBookRepository) UpsertByISBN(arg0 context.Context, arg1 []internal.Book) ([]internal.UpsertResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertByISBN", arg0, arg1)
+	ret0, _ := ret[0].([]internal.UpsertResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertByISBN indicates an expected call of UpsertByISBN.
+func (mr *MockBookRepositoryMockRecorder) UpsertByISBN(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertByISBN", reflect.TypeOf((*MockBookRepository)(nil).UpsertByISBN), arg0, arg1)
+}
+
+// MockCategoryRepository is a mock of CategoryRepository interface.
+type MockCategoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryRepositoryMockRecorder
+}
+
+// MockCategoryRepositoryMockRecorder is the mock recorder for MockCategoryRepository.
+type MockCategoryRepositoryMockRecorder struct {
+	mock *MockCategoryRepository
+}
+
+// NewMockCategoryRepository creates a new mock instance.
+func NewMockCategoryRepository(ctrl *gomock.Controller) *MockCategoryRepository {
+	mock := &MockCategoryRepository{ctrl: ctrl}
+	mock.recorder = &MockCategoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryRepository) EXPECT() *MockCategoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountBooks mocks base method.
+func (m *MockCategoryRepository) CountBooks(arg0 context.Context, arg1 uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBooks", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBooks indicates an expected call of CountBooks.
+func (mr *MockCategoryRepositoryMockRecorder) CountBooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBooks", reflect.TypeOf((*MockCategoryRepository)(nil).CountBooks), arg0, arg1)
+}
+
+// CountChildren mocks base method.
+func (m *MockCategoryRepository) CountChildren(arg0 context.Context, arg1 uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountChildren", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountChildren indicates an expected call of CountChildren.
+func (mr *MockCategoryRepositoryMockRecorder) CountChildren(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountChildren", reflect.TypeOf((*MockCategoryRepository)(nil).CountChildren), arg0, arg1)
+}
+
+// Create mocks base method.
+func (m *MockCategoryRepository) Create(arg0 context.Context, arg1, arg2 *internal.Category) (*internal.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*internal.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCategoryRepositoryMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCategoryRepository)(nil).Create), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockCategoryRepository) Delete(arg0 context.Context, arg1 uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCategoryRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockCategoryRepository) FindAll(arg0 context.Context) ([]internal.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]internal.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockCategoryRepositoryMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCategoryRepository)(nil).FindAll), arg0)
+}
+
+// FindByID mocks base method.
+func (m *MockCategoryRepository) FindByID(arg0 context.Context, arg1 uint) (*internal.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*internal.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockCategoryRepositoryMockRecorder) FindByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockCategoryRepository)(nil).FindByID), arg0, arg1)
+}
+
+// FindChildren mocks base method.
+func (m *MockCategoryRepository) FindChildren(arg0 context.Context, arg1 *internal.Category) ([]internal.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindChildren", arg0, arg1)
+	ret0, _ := ret[0].([]internal.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindChildren indicates an expected call of FindChildren.
+func (mr *MockCategoryRepositoryMockRecorder) FindChildren(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindChildren", reflect.TypeOf((*MockCategoryRepository)(nil).FindChildren), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockCategoryRepository) Update(arg0 context.Context, arg1, arg2 *internal.Category) (*internal.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*internal.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCategoryRepositoryMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCategoryRepository)(nil).Update), arg0, arg1, arg2)
+}
+
+// MockPriceRepository is a mock of PriceRepository interface.
+type MockPriceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceRepositoryMockRecorder
+}
+
+// MockPriceRepositoryMockRecorder is the mock recorder for MockPriceRepository.
+type MockPriceRepositoryMockRecorder struct {
+	mock *MockPriceRepository
+}
+
+// NewMockPriceRepository creates a new mock instance.
+func NewMockPriceRepository(ctrl *gomock.Controller) *MockPriceRepository {
+	mock := &MockPriceRepository{ctrl: ctrl}
+	mock.recorder = &MockPriceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceRepository) EXPECT() *MockPriceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPriceRepository) Create(arg0 context.Context, arg1 *internal.BookPrice) (*internal.BookPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*internal.BookPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPriceRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPriceRepository)(nil).Create), arg0, arg1)
+}
+
+// DeleteScheduled mocks base method.
+func (m *MockPriceRepository) DeleteScheduled(arg0 context.Context, arg1, arg2 uint, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduled", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScheduled indicates an expected call of DeleteScheduled.
+func (mr *MockPriceRepositoryMockRecorder) DeleteScheduled(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduled", reflect.TypeOf((*MockPriceRepository)(nil).DeleteScheduled), arg0, arg1, arg2, arg3)
+}
+
+// FindByBookID mocks base method.
+func (m *MockPriceRepository) FindByBookID(arg0 context.Context, arg1 uint, arg2, arg3 int) ([]internal.BookPrice, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByBookID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]internal.BookPrice)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindByBookID indicates an expected call of FindByBookID.
+func (mr *MockPriceRepositoryMockRecorder) FindByBookID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBookID", reflect.TypeOf((*MockPriceRepository)(nil).FindByBookID), arg0, arg1, arg2, arg3)
+}
+
+// FindEffective mocks base method.
+func (m *MockPriceRepository) FindEffective(arg0 context.Context, arg1 uint, arg2 time.Time) (*internal.BookPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEffective", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*internal.BookPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEffective indicates an expected call of FindEffective.
+func (mr *MockPriceRepositoryMockRecorder) FindEffective(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEffective", reflect.TypeOf((*MockPriceRepository)(nil).FindEffective), arg0, arg1, arg2)
+}
+
+// MockImportJobRepository is a mock of ImportJobRepository interface.
+type MockImportJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportJobRepositoryMockRecorder
+}
+
+// MockImportJobRepositoryMockRecorder is the mock recorder for MockImportJobRepository.
+type MockImportJobRepositoryMockRecorder struct {
+	mock *MockImportJobRepository
+}
+
+// NewMockImportJobRepository creates a new mock instance.
+func NewMockImportJobRepository(ctrl *gomock.Controller) *MockImportJobRepository {
+	mock := &MockImportJobRepository{ctrl: ctrl}
+	mock.recorder = &MockImportJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportJobRepository) EXPECT() *MockImportJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockImportJobRepository) Claim(arg0 context.Context, arg1 time.Time) (*internal.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", arg0, arg1)
+	ret0, _ := ret[0].(*internal.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockImportJobRepositoryMockRecorder) Claim(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockImportJobRepository)(nil).Claim), arg0, arg1)
+}
+
+// Create mocks base method.
+func (m *MockImportJobRepository) Create(arg0 context.Context, arg1 *internal.ImportJob) (*internal.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*internal.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockImportJobRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockImportJobRepository)(nil).Create), arg0, arg1)
+}
+
+// FindByID mocks base method.
+func (m *MockImportJobRepository) FindByID(arg0 context.Context, arg1 uint) (*internal.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*internal.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockImportJobRepositoryMockRecorder) FindByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockImportJobRepository)(nil).FindByID), arg0, arg1)
+}
+
+// Finish mocks base method.
+func (m *MockImportJobRepository) Finish(arg0 context.Context, arg1 *internal.ImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockImportJobRepositoryMockRecorder) Finish(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockImportJobRepository)(nil).Finish), arg0, arg1)
+}
@@ -0,0 +1,202 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: book-service/internal (interfaces: BookService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	internal "book-service/internal"
+	dto "book-service/internal/api/dto"
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBookService is a mock of BookService interface.
+type MockBookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookServiceMockRecorder
+}
+
+// MockBookServiceMockRecorder is the mock recorder for MockBookService.
+type MockBookServiceMockRecorder struct {
+	mock *MockBookService
+}
+
+// NewMockBookService creates a new mock instance.
+func NewMockBookService(ctrl *gomock.Controller) *MockBookService {
+	mock := &MockBookService{ctrl: ctrl}
+	mock.recorder = &MockBookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookService) EXPECT() *MockBookServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBookService) Create(arg0 context.Context, arg1 dto.CreateRequest) (*dto.CreateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*dto.CreateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBookServiceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookService)(nil).Create), arg0, arg1)
+}
+
+// Export mocks base method.
+func (m *MockBookService) Export(arg0 context.Context, arg1 dto.ExportRequest, arg2 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockBookServiceMockRecorder) Export(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockBookService)(nil).Export), arg0, arg1, arg2)
+}
+
+// FindAll mocks base method.
+func (m *MockBookService) FindAll(arg0 context.Context, arg1 dto.ListRequest) (*dto.ListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
+	ret0, _ := ret[0].(*dto.ListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockBookServiceMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBookService)(nil).FindAll), arg0, arg1)
+}
+
+// FindByCategory mocks base method.
+func (m *MockBookService) FindByCategory(arg0 context.Context, arg1 uint, arg2 dto.ListRequest) (*dto.ListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCategory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.ListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCategory indicates an expected call of FindByCategory.
+func (mr *MockBookServiceMockRecorder) FindByCategory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCategory", reflect.TypeOf((*MockBookService)(nil).FindByCategory), arg0, arg1, arg2)
+}
+
+// FindByID mocks base method.
+func (m *MockBookService) FindByID(arg0 context.Context, arg1 uint) (*internal.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*internal.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockBookServiceMockRecorder) FindByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockBookService)(nil).FindByID), arg0, arg1)
+}
+
+// FindByIDs mocks base method.
+func (m *MockBookService) FindByIDs(arg0 context.Context, arg1 []uint) ([]dto.BookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]dto.BookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockBookServiceMockRecorder) FindByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockBookService)(nil).FindByIDs), arg0, arg1)
+}
+
+// FindByISBN mocks base method.
+func (m *MockBookService) FindByISBN(arg0 context.Context, arg1 string) (*dto.BookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByISBN", arg0, arg1)
+	ret0, _ := ret[0].(*dto.BookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByISBN indicates an expected call of FindByISBN.
+func (mr *MockBookServiceMockRecorder) FindByISBN(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByISBN", reflect.TypeOf((*MockBookService)(nil).FindByISBN), arg0, arg1)
+}
+
+// ReleaseStock mocks base method.
+func (m *MockBookService) ReleaseStock(arg0 context.Context, arg1 uint, arg2 dto.ReserveRequest) (*dto.BookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseStock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.BookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseStock indicates an expected call of ReleaseStock.
+func (mr *MockBookServiceMockRecorder) ReleaseStock(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseStock", reflect.TypeOf((*MockBookService)(nil).ReleaseStock), arg0, arg1, arg2)
+}
+
+// ReserveStock mocks base method.
+func (m *MockBookService) ReserveStock(arg0 context.Context, arg1 uint, arg2 dto.ReserveRequest) (*dto.BookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveStock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.BookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveStock indicates an expected call of ReserveStock.
+func (mr *MockBookServiceMockRecorder) ReserveStock(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveStock", reflect.TypeOf((*MockBookService)(nil).ReserveStock), arg0, arg1, arg2)
+}
+
+// ReserveStocks mocks base method.
+func (m *MockBookService) ReserveStocks(arg0 context.Context, arg1 map[uint]int) ([]dto.BookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveStocks", arg0, arg1)
+	ret0, _ := ret[0].([]dto.BookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveStocks indicates an expected call of ReserveStocks.
+func (mr *MockBookServiceMockRecorder) ReserveStocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveStocks", reflect.TypeOf((*MockBookService)(nil).ReserveStocks), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockBookService) Update(arg0 context.Context, arg1 uint, arg2 int, arg3 dto.UpdateRequest) (*dto.BookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto.BookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBookServiceMockRecorder) Update(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookService)(nil).Update), arg0, arg1, arg2, arg3)
+}
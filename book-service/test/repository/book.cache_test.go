@@ -0,0 +1,146 @@
+package repository_test
+
+import (
+	"book-service/internal"
+	"book-service/pkg/cache"
+	mocks "book-service/test/mock"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setup(t *testing.T, bookCache cache.Cache) (*mocks.MockBookRepository, internal.BookRepository) {
+	inner := mocks.NewMockBookRepository(gomock.NewController(t))
+	return inner, internal.NewCachedBookRepository(inner, bookCache, time.Minute)
+}
+
+func book(title string, stock, version int) *internal.Book {
+	return &internal.Book{ID: 1, Title: title, Stock: stock, Version: version}
+}
+
+func TestCachedBookRepository_ReadsThrough(t *testing.T) {
+	ctx := context.Background()
+	inner, repository := setup(t, cache.NewLRU(10))
+	inner.EXPECT().FindByID(gomock.Any(), uint(1)).Return(book("Dune", 5, 1), nil)
+
+	first, err := repository.FindByID(ctx, 1)
+	require.NoError(t, err)
+	second, err := repository.FindByID(ctx, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Dune", second.Title)
+	assert.Equal(t, first.Version, second.Version)
+}
+
+func TestCachedBookRepository_InvalidatesOnWrite(t *testing.T) {
+	ctx := context.Background()
+	inner, repository := setup(t, cache.NewLRU(10))
+	gomock.InOrder(
+		inner.EXPECT().FindByID(gomock.Any(), uint(1)).Return(book("Dune", 5, 1), nil),
+		inner.EXPECT().Update(gomock.Any(), gomock.Any(), 1).Return(book("Dune Messiah", 5, 2), nil),
+		inner.EXPECT().FindByID(gomock.Any(), uint(1)).Return(book("Dune Messiah", 5, 2), nil),
+	)
+
+	_, err := repository.FindByID(ctx, 1)
+	require.NoError(t, err)
+	_, err = repository.Update(ctx, &internal.Book{ID: 1, Title: "Dune Messiah"}, 1)
+	require.NoError(t, err)
+
+	updated, err := repository.FindByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Dune Messiah", updated.Title)
+	assert.Equal(t, 2, updated.Version)
+}
+
+func TestCachedBookRepository_InvalidatesReservedBooks(t *testing.T) {
+	ctx := context.Background()
+	inner, repository := setup(t, cache.NewLRU(10))
+	gomock.InOrder(
+		inner.EXPECT().FindByID(gomock.Any(), uint(1)).Return(book("Dune", 5, 1), nil),
+		inner.EXPECT().DecreaseStocks(gomock.Any(), map[uint]int{1: 2}).Return([]internal.Book{*book("Dune", 3, 2)}, nil),
+		inner.EXPECT().FindByID(gomock.Any(), uint(1)).Return(book("Dune", 3, 2), nil),
+	)
+
+	_, err := repository.FindByID(ctx, 1)
+	require.NoError(t, err)
+	_, err = repository.DecreaseStocks(ctx, map[uint]int{1: 2})
+	require.NoError(t, err)
+
+	reserved, err := repository.FindByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, reserved.Stock)
+}
+
+func TestCachedBookRepository_DropsLoadsStartedBeforeWrite(t *testing.T) {
+	ctx := context.Background()
+	inner, repository := setup(t, cache.NewLRU(10))
+	read, release := make(chan struct{}), make(chan struct{})
+	gomock.InOrder(
+		inner.EXPECT().FindByID(gomock.Any(), uint(1)).DoAndReturn(func(ctx context.Context, id uint) (*internal.Book, error) {
+			close(read)
+			<-release
+			return book("Dune", 5, 1), nil
+		}),
+		inner.EXPECT().FindByID(gomock.Any(), uint(1)).Return(book("Dune Messiah", 5, 2), nil),
+	)
+	inner.EXPECT().Update(gomock.Any(), gomock.Any(), 1).Return(book("Dune Messiah", 5, 2), nil)
+
+	stale := make(chan *internal.Book)
+	go func() {
+		loaded, err := repository.FindByID(ctx, 1)
+		assert.NoError(t, err)
+		stale <- loaded
+	}()
+	<-read
+	_, err := repository.Update(ctx, &internal.Book{ID: 1, Title: "Dune Messiah"}, 1)
+	require.NoError(t, err)
+
+	fresh := make(chan *internal.Book)
+	go func() {
+		loaded, err := repository.FindByID(ctx, 1)
+		assert.NoError(t, err)
+		fresh <- loaded
+	}()
+	select {
+	case loaded := <-fresh:
+		assert.Equal(t, "Dune Messiah", loaded.Title)
+	case <-time.After(time.Second):
+		close(release)
+		t.Fatal("a reader after the write joined the older load")
+	}
+
+	close(release)
+	assert.Equal(t, "Dune", (<-stale).Title)
+
+	cached, err := repository.FindByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Dune Messiah", cached.Title, "the older load does not refill the cache")
+}
+
+func TestCachedBookRepository_CollapsesConcurrentMisses(t *testing.T) {
+	inner, repository := setup(t, cache.NewNoop())
+	release := make(chan struct{})
+	inner.EXPECT().FindByID(gomock.Any(), uint(1)).DoAndReturn(func(ctx context.Context, id uint) (*internal.Book, error) {
+		<-release
+		return book("Dune", 5, 1), nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loaded, err := repository.FindByID(context.Background(), 1)
+			assert.NoError(t, err)
+			assert.Equal(t, "Dune", loaded.Title)
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+}
@@ -0,0 +1,87 @@
+package service_test
+
+import (
+	"book-service/internal"
+	"book-service/internal/api/dto"
+	"context"
+	"testing"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func createRequest(isbn string) dto.CreateRequest {
+	return dto.CreateRequest{Title: "Dune", Author: "Frank Herbert", Description: "Desert planet", ISBN: isbn, Price: dto.MoneyRequest{Amount: 10000000}, Stock: 1}
+}
+
+func dune() *internal.Book {
+	isbn13, isbn10 := "9780306406157", "0306406152"
+	return &internal.Book{ID: 1, Title: "Dune", Author: "Frank Herbert", Description: "Desert planet", ISBN13: &isbn13, ISBN10: &isbn10, Price: 10000000, Currency: "IDR", Stock: 1}
+}
+
+func TestBookService_CreateNormalizesISBN(t *testing.T) {
+	d := setup(t)
+	d.books.EXPECT().FindByISBN(gomock.Any(), "9780306406157").Return(nil, gorm.ErrRecordNotFound)
+	d.books.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, book *internal.Book) (*internal.Book, error) {
+		book.ID = 1
+		return book, nil
+	})
+
+	response, err := d.bookService().Create(context.Background(), createRequest("0-306-40615-2"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "9780306406157", response.ISBN13)
+	assert.Equal(t, "0306406152", response.ISBN10)
+	assert.Equal(t, money.New(10000000, "IDR"), response.Price)
+}
+
+func TestBookService_CreateRejectsDuplicateISBN(t *testing.T) {
+	d := setup(t)
+	d.books.EXPECT().FindByISBN(gomock.Any(), "9780306406157").Return(dune(), nil)
+
+	_, err := d.bookService().Create(context.Background(), createRequest("0306406152"))
+
+	assert.ErrorIs(t, err, internal.ErrISBNAlreadyExists)
+	assert.Equal(t, apperror.KindConflict, apperror.KindOf(err))
+}
+
+func TestBookService_FindByISBN(t *testing.T) {
+	d := setup(t)
+	service := d.bookService()
+	d.books.EXPECT().FindByISBN(gomock.Any(), "9780306406157").Return(dune(), nil)
+	d.books.EXPECT().FindByISBN(gomock.Any(), "9791090636071").Return(nil, gorm.ErrRecordNotFound)
+
+	book, err := service.FindByISBN(context.Background(), "0-306-40615-2")
+	require.NoError(t, err)
+	assert.Equal(t, "Dune", book.Title)
+
+	_, err = service.FindByISBN(context.Background(), "978-0-306-40615-6")
+	assert.ErrorIs(t, err, internal.ErrInvalidISBN)
+
+	_, err = service.FindByISBN(context.Background(), "979-10-90636-07-1")
+	assert.ErrorIs(t, err, internal.ErrBookNotFound)
+}
+
+func TestBookService_ConvertsToDisplayCurrency(t *testing.T) {
+	d := setup(t)
+	service := d.bookService()
+	d.books.EXPECT().FindByISBN(gomock.Any(), "9780306406157").Return(dune(), nil).Times(2)
+
+	stored, err := service.FindByISBN(context.Background(), "9780306406157")
+	require.NoError(t, err)
+	assert.Equal(t, money.New(10000000, "IDR"), stored.Price)
+	assert.Nil(t, stored.BasePrice)
+
+	converted, err := service.FindByISBN(money.WithDisplay(context.Background(), "USD"), "9780306406157")
+	require.NoError(t, err)
+	assert.Equal(t, money.New(625, "USD"), converted.Price)
+	require.NotNil(t, converted.BasePrice)
+	assert.Equal(t, money.New(10000000, "IDR"), *converted.BasePrice)
+
+	_, err = service.Create(context.Background(), dto.CreateRequest{Title: "Emma", Author: "Jane Austen", Description: "Matchmaking", Price: dto.MoneyRequest{Amount: 1, Currency: "XXX"}})
+	assert.ErrorIs(t, err, internal.ErrInvalidCurrency)
+}
@@ -0,0 +1,97 @@
+package service_test
+
+import (
+	"book-service/internal"
+	"book-service/internal/api/dto"
+	"context"
+	"testing"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// categories is Fiction > Fantasy > Epic plus History, in path order as
+// the repository returns them.
+func categories() []internal.Category {
+	fiction, fantasy := uint(1), uint(2)
+	return []internal.Category{
+		{ID: 1, Name: "Fiction", Path: "/1/"},
+		{ID: 2, Name: "Fantasy", ParentID: &fiction, Path: "/1/2/"},
+		{ID: 3, Name: "Epic", ParentID: &fantasy, Path: "/1/2/3/"},
+		{ID: 4, Name: "History", Path: "/4/"},
+	}
+}
+
+func TestCategoryService_FindTree(t *testing.T) {
+	d := setup(t)
+	d.categories.EXPECT().FindAll(gomock.Any()).Return(categories(), nil)
+
+	tree, err := d.categoryService().FindTree(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, tree, 2)
+	assert.Equal(t, "Fiction", tree[0].Name)
+	assert.Equal(t, "Fantasy", tree[0].Children[0].Name)
+	assert.Equal(t, "Epic", tree[0].Children[0].Children[0].Name)
+	assert.Equal(t, 2, tree[0].Children[0].Children[0].Depth)
+	assert.Equal(t, "History", tree[1].Name)
+}
+
+func TestCategoryService_UpdateRejectsCycles(t *testing.T) {
+	d := setup(t)
+	all := categories()
+	fiction, fantasy := all[0], all[1]
+	d.categories.EXPECT().FindByID(gomock.Any(), fiction.ID).Return(&fiction, nil).Times(3)
+	d.categories.EXPECT().FindByID(gomock.Any(), fantasy.ID).Return(&fantasy, nil)
+
+	_, err := d.categoryService().Update(context.Background(), fiction.ID, dto.CategoryRequest{Name: "Fiction", ParentID: &fantasy.ID})
+	assert.ErrorIs(t, err, internal.ErrCategoryCycle)
+
+	_, err = d.categoryService().Update(context.Background(), fiction.ID, dto.CategoryRequest{Name: "Fiction", ParentID: &fiction.ID})
+	assert.ErrorIs(t, err, internal.ErrCategoryCycle)
+}
+
+func TestCategoryService_DeleteRequiresEmptyCategory(t *testing.T) {
+	d := setup(t)
+	d.categories.EXPECT().CountChildren(gomock.Any(), uint(1)).Return(int64(1), nil)
+	d.categories.EXPECT().CountBooks(gomock.Any(), uint(1)).Return(int64(0), nil)
+	d.categories.EXPECT().CountChildren(gomock.Any(), uint(4)).Return(int64(0), nil)
+	d.categories.EXPECT().CountBooks(gomock.Any(), uint(4)).Return(int64(1), nil)
+
+	assert.ErrorIs(t, d.categoryService().Delete(context.Background(), 1), internal.ErrCategoryNotEmpty)
+	assert.ErrorIs(t, d.categoryService().Delete(context.Background(), 4), internal.ErrCategoryNotEmpty)
+}
+
+func TestCategoryService_FindByIDListsDirectChildren(t *testing.T) {
+	d := setup(t)
+	all := categories()
+	d.categories.EXPECT().FindByID(gomock.Any(), uint(1)).Return(&all[0], nil)
+	d.categories.EXPECT().FindChildren(gomock.Any(), &all[0]).Return(all[1:2], nil)
+	d.categories.EXPECT().FindByID(gomock.Any(), uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	fiction, err := d.categoryService().FindByID(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Fiction", fiction.Name)
+	require.Len(t, fiction.Children, 1)
+	assert.Equal(t, "Fantasy", fiction.Children[0].Name)
+	assert.Equal(t, 1, fiction.Children[0].Depth)
+
+	_, err = d.categoryService().FindByID(context.Background(), 9)
+	assert.ErrorIs(t, err, internal.ErrCategoryNotFound)
+}
+
+func TestCategoryService_DeleteRacingInsert(t *testing.T) {
+	d := setup(t)
+	d.categories.EXPECT().CountChildren(gomock.Any(), uint(4)).Return(int64(0), nil)
+	d.categories.EXPECT().CountBooks(gomock.Any(), uint(4)).Return(int64(0), nil)
+	d.categories.EXPECT().Delete(gomock.Any(), uint(4)).Return(gorm.ErrForeignKeyViolated)
+
+	err := d.categoryService().Delete(context.Background(), 4)
+
+	assert.ErrorIs(t, err, internal.ErrCategoryNotEmpty)
+	assert.Equal(t, apperror.KindConflict, apperror.KindOf(err))
+}
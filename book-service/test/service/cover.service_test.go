@@ -0,0 +1,93 @@
+package service_test
+
+import (
+	"book-service/internal"
+	"book-service/pkg/blob"
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func pngImage(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, x%height, color.NRGBA{R: 200, A: 255})
+	}
+	var out bytes.Buffer
+	require.NoError(t, png.Encode(&out, img))
+	return out.Bytes()
+}
+
+func imageSize(t *testing.T, store blob.Store, url string) image.Point {
+	body, err := store.Get(context.Background(), strings.TrimPrefix(url, "/covers/"))
+	require.NoError(t, err)
+	defer body.Close()
+	img, err := jpeg.Decode(body)
+	require.NoError(t, err)
+	return img.Bounds().Size()
+}
+
+func TestCoverService_UploadStoresVariants(t *testing.T) {
+	d := setup(t)
+	service := d.coverService(internal.DefaultCoverMaxBytes)
+	book := dune()
+	d.books.EXPECT().FindByID(gomock.Any(), uint(1)).Return(book, nil).AnyTimes()
+	d.books.EXPECT().SetCover(gomock.Any(), uint(1), gomock.Any()).DoAndReturn(func(ctx context.Context, id uint, key *string) (*string, error) {
+		previous := book.CoverKey
+		book.CoverKey = key
+		return previous, nil
+	}).Times(2)
+
+	cover, err := service.Upload(context.Background(), 1, bytes.NewReader(pngImage(t, 1200, 1800)))
+	require.NoError(t, err)
+
+	assert.Equal(t, image.Pt(600, 900), imageSize(t, d.covers, cover.Medium))
+	assert.Equal(t, image.Pt(160, 240), imageSize(t, d.covers, cover.Thumbnail))
+	original, err := d.covers.Get(context.Background(), strings.TrimPrefix(cover.Original, "/covers/"))
+	require.NoError(t, err)
+	data, _ := io.ReadAll(original)
+	original.Close()
+	assert.Equal(t, pngImage(t, 1200, 1800), data)
+
+	first := *book.CoverKey
+	_, err = service.Upload(context.Background(), 1, bytes.NewReader(pngImage(t, 100, 50)))
+	require.NoError(t, err)
+	_, err = d.covers.Get(context.Background(), first+"/original")
+	assert.ErrorIs(t, err, blob.ErrNotFound, "replaced cover is removed")
+
+	found, err := d.bookService().FindByID(context.Background(), 1)
+	require.NoError(t, err)
+	require.NotNil(t, found.Cover)
+	assert.Equal(t, image.Pt(100, 50), imageSize(t, d.covers, found.Cover.Medium), "small covers are not enlarged")
+}
+
+func TestCoverService_UploadRejectsBadInput(t *testing.T) {
+	d := setup(t)
+	service := d.coverService(1024)
+	d.books.EXPECT().FindByID(gomock.Any(), uint(1)).Return(dune(), nil).Times(2)
+	d.books.EXPECT().FindByID(gomock.Any(), uint(2)).Return(nil, gorm.ErrRecordNotFound)
+	d.books.EXPECT().SetCover(gomock.Any(), uint(1), nil).Return(nil, nil)
+
+	_, err := service.Upload(context.Background(), 1, strings.NewReader("GIF89a not allowed"))
+	assert.ErrorIs(t, err, internal.ErrCoverType)
+
+	_, err = service.Upload(context.Background(), 1, bytes.NewReader(pngImage(t, 400, 400)))
+	assert.ErrorIs(t, err, internal.ErrCoverTooLarge)
+
+	_, err = service.Upload(context.Background(), 2, bytes.NewReader(pngImage(t, 10, 10)))
+	assert.ErrorIs(t, err, internal.ErrBookNotFound)
+
+	err = service.Delete(context.Background(), 1)
+	assert.ErrorIs(t, err, internal.ErrCoverNotFound)
+}
@@ -0,0 +1,88 @@
+package service_test
+
+import (
+	"book-service/internal"
+	"book-service/internal/api/dto"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// export hands the writer one book per batch, so it sees more than one.
+func export(t *testing.T, format string) string {
+	d := setup(t)
+	book := dune()
+	book.Tags = []internal.Tag{{Name: "classic"}, {Name: "sci-fi"}}
+	emma := internal.Book{ID: 2, Title: "Emma, A Novel", Author: "Jane Austen", Description: "Matchmaking", Price: 1250, Currency: "USD"}
+	d.books.EXPECT().Stream(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, filter internal.BookFilter, batchSize int, fn func(books []internal.Book) error) error {
+			for _, batch := range [][]internal.Book{{*book}, {emma}} {
+				if err := fn(batch); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	var out bytes.Buffer
+	require.NoError(t, d.bookService().Export(context.Background(), dto.ExportRequest{Format: format}, &out))
+	return out.String()
+}
+
+func TestBookService_ExportCSV(t *testing.T) {
+	records, err := csv.NewReader(strings.NewReader(export(t, internal.FormatCSV))).ReadAll()
+
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"id", "title", "author", "description", "isbn", "isbn10", "price", "currency", "stock", "categoryId", "tags"}, records[0])
+	assert.Equal(t, []string{"1", "Dune", "Frank Herbert", "Desert planet", "9780306406157", "0306406152", "100000.00", "IDR", "1", "", "classic;sci-fi"}, records[1])
+	assert.Equal(t, "Emma, A Novel", records[2][1])
+}
+
+func TestBookService_ExportNDJSON(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(export(t, internal.FormatNDJSON)), "\n")
+
+	require.Len(t, lines, 2)
+	var book dto.BookResponse
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &book))
+	assert.Equal(t, "Dune", book.Title)
+	assert.Equal(t, []string{"classic", "sci-fi"}, book.Tags)
+}
+
+func TestBookService_ExportONIX(t *testing.T) {
+	out := export(t, internal.FormatXML)
+
+	var message struct {
+		XMLName  xml.Name `xml:"ONIXMessage"`
+		Release  string   `xml:"release,attr"`
+		Products []struct {
+			Identifiers []struct {
+				Type  string `xml:"ProductIDType"`
+				Value string `xml:"IDValue"`
+			} `xml:"ProductIdentifier"`
+			Title        string `xml:"DescriptiveDetail>TitleDetail>TitleElement>TitleText"`
+			Availability string `xml:"ProductSupply>SupplyDetail>ProductAvailability"`
+			Amount       string `xml:"ProductSupply>SupplyDetail>Price>PriceAmount"`
+			Currency     string `xml:"ProductSupply>SupplyDetail>Price>CurrencyCode"`
+		} `xml:"Product"`
+	}
+	require.NoError(t, xml.Unmarshal([]byte(out), &message))
+
+	assert.Equal(t, "3.0", message.Release)
+	require.Len(t, message.Products, 2)
+	assert.Equal(t, "Dune", message.Products[0].Title)
+	assert.Equal(t, "15", message.Products[0].Identifiers[1].Type)
+	assert.Equal(t, "9780306406157", message.Products[0].Identifiers[1].Value)
+	assert.Equal(t, "21", message.Products[0].Availability)
+	assert.Equal(t, "31", message.Products[1].Availability)
+	assert.Equal(t, "12.50", message.Products[1].Amount)
+	assert.Equal(t, "USD", message.Products[1].Currency)
+}
@@ -0,0 +1,110 @@
+package service_test
+
+import (
+	"book-service/internal"
+	"book-service/internal/api/dto"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upserted answers UpsertByISBN with one status per book, in order, and
+// hands the books it was given to check.
+func upserted(d *deps, got *[]internal.Book, statuses ...string) {
+	d.books.EXPECT().UpsertByISBN(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, books []internal.Book) ([]internal.UpsertResult, error) {
+		*got = append(*got, books...)
+		results := make([]internal.UpsertResult, len(books))
+		for i := range books {
+			results[i] = internal.UpsertResult{ID: uint(i + 1), Status: statuses[i]}
+		}
+		return results, nil
+	})
+}
+
+func TestImportService_CSVReport(t *testing.T) {
+	d := setup(t)
+	var books []internal.Book
+	upserted(d, &books, dto.ImportCreated)
+	csv := strings.Join([]string{
+		"title,author,description,isbn,price,currency,stock,tags",
+		"Dune,Frank Herbert,Desert planet,978-0-306-40615-7,100000,,5,sci-fi;classic",
+		"Emma,Jane Austen,Matchmaking,0-306-40615-3,90000,IDR,2,",
+		"Dune,Frank Herbert,Desert planet,0306406152,100000,,5,",
+		"Persuasion,Jane Austen,Second chances,978-0-8044-2957-3,12.345,USD,1,",
+		`"Broken,quote`,
+	}, "\n")
+
+	report, err := d.importService().Import(context.Background(), internal.FormatCSV, strings.NewReader(csv))
+
+	require.NoError(t, err)
+	require.Len(t, books, 1)
+	assert.Equal(t, []internal.Tag{{Name: "sci-fi"}, {Name: "classic"}}, books[0].Tags)
+	assert.Equal(t, 5, report.Total)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 4, report.Failed)
+
+	rows := map[int]dto.ImportRowResult{}
+	for _, row := range report.Rows {
+		rows[row.Row] = row
+	}
+	assert.Equal(t, dto.ImportCreated, rows[2].Status)
+	assert.Equal(t, "isbn: must be a valid ISBN-10 or ISBN-13", rows[3].Reason)
+	assert.Equal(t, "duplicate ISBN, first seen on row 2", rows[4].Reason)
+	assert.Equal(t, "price: must be a decimal amount in USD", rows[5].Reason)
+	assert.Equal(t, dto.ImportError, rows[6].Status)
+}
+
+func TestImportService_NDJSONUpserts(t *testing.T) {
+	d := setup(t)
+	var books []internal.Book
+	upserted(d, &books, dto.ImportSkipped, dto.ImportCreated)
+	ndjson := strings.Join([]string{
+		`{"title":"Dune","author":"Frank Herbert","description":"Desert planet","isbn":"9780306406157","price":{"amount":10000000},"stock":5}`,
+		`{"title":"Emma","author":"Jane Austen","description":"Matchmaking","isbn":"978-0-8044-2957-3","price":"cheap"}`,
+		"",
+		`{"title":"Dune (revised)","author":"Frank Herbert","description":"Desert planet","isbn":"0-8044-2957-X","price":{"amount":12000000,"currency":"IDR"}}`,
+	}, "\n")
+
+	report, err := d.importService().Import(context.Background(), internal.FormatNDJSON, strings.NewReader(ndjson))
+
+	require.NoError(t, err)
+	require.Len(t, books, 2)
+	assert.Equal(t, "9780804429573", *books[1].ISBN13)
+	assert.Equal(t, int64(12000000), books[1].Price)
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, dto.ImportSkipped, report.Rows[0].Status)
+	assert.Equal(t, "price: must be a JSON object", report.Rows[1].Reason)
+	assert.Equal(t, 4, report.Rows[2].Row)
+	assert.Equal(t, dto.ImportCreated, report.Rows[2].Status)
+}
+
+func TestImportService_SameAuthor(t *testing.T) {
+	d := setup(t)
+	var books []internal.Book
+	upserted(d, &books, dto.ImportCreated, dto.ImportCreated)
+	csv := strings.Join([]string{
+		"title,author,description,isbn,price",
+		"Emma,Jane Austen,A novel by Jane Austen,978-0-306-40615-7,90000",
+		"Persuasion,Jane Austen,A novel by Jane Austen,978-0-8044-2957-3,95000",
+	}, "\n")
+
+	report, err := d.importService().Import(context.Background(), internal.FormatCSV, strings.NewReader(csv))
+
+	require.NoError(t, err)
+	require.Len(t, books, 2)
+	assert.Equal(t, books[0].Author, books[1].Author)
+	assert.Equal(t, 2, report.Created)
+	assert.Zero(t, report.Failed)
+}
+
+func TestImportService_RejectsBadHeader(t *testing.T) {
+	d := setup(t)
+
+	_, err := d.importService().Import(context.Background(), internal.FormatCSV, strings.NewReader("title,author\nDune,Frank Herbert\n"))
+
+	assert.ErrorIs(t, err, internal.ErrImportHeader)
+}
@@ -0,0 +1,85 @@
+package service_test
+
+import (
+	"book-service/internal"
+	"book-service/internal/api/dto"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// prices is the history of Dune, latest start first: a promotion running
+// now over the regular price, and a promotion that already ended.
+func prices(now time.Time) []internal.BookPrice {
+	promoEnd := now.Add(24 * time.Hour)
+	pastEnd := now.Add(-24 * time.Hour)
+	return []internal.BookPrice{
+		{ID: 3, BookID: 1, Price: 80000, Currency: "IDR", EffectiveFrom: now.Add(-time.Hour), EffectiveTo: &promoEnd},
+		{ID: 2, BookID: 1, Price: 70000, Currency: "IDR", EffectiveFrom: now.Add(-10 * 24 * time.Hour), EffectiveTo: &pastEnd},
+		{ID: 1, BookID: 1, Price: 100000, Currency: "IDR", EffectiveFrom: now.Add(-30 * 24 * time.Hour)},
+	}
+}
+
+func TestPriceService_HistoryMarksPriceInEffect(t *testing.T) {
+	d := setup(t)
+	now := time.Now()
+	history := append([]internal.BookPrice{
+		{ID: 4, BookID: 1, Price: 90000, Currency: "IDR", EffectiveFrom: now.Add(48 * time.Hour)},
+	}, prices(now)...)
+	d.books.EXPECT().FindByID(gomock.Any(), uint(1)).Return(dune(), nil)
+	d.prices.EXPECT().FindEffective(gomock.Any(), uint(1), gomock.Any()).Return(&history[1], nil)
+	d.prices.EXPECT().FindByBookID(gomock.Any(), uint(1), 0, 10).Return(history, int64(len(history)), nil)
+
+	response, err := d.priceService().History(context.Background(), 1, dto.PriceHistoryRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, money.New(80000, "IDR"), response.CurrentPrice, "the promotion layers over the regular price")
+	assert.Equal(t, int64(4), response.Total)
+	statuses := make([]string, len(response.Prices))
+	for i, price := range response.Prices {
+		statuses[i] = price.Status
+	}
+	assert.Equal(t, []string{dto.PriceScheduled, dto.PriceActive, dto.PriceInactive, dto.PriceInactive}, statuses)
+	assert.Equal(t, money.New(90000, "IDR"), response.Prices[0].Price)
+}
+
+func TestPriceService_ScheduleAndCancel(t *testing.T) {
+	d := setup(t)
+	service := d.priceService()
+	d.books.EXPECT().FindByID(gomock.Any(), uint(1)).Return(dune(), nil).Times(2)
+	d.books.EXPECT().FindByID(gomock.Any(), uint(2)).Return(nil, gorm.ErrRecordNotFound)
+	d.prices.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, price *internal.BookPrice) (*internal.BookPrice, error) {
+		price.ID = 4
+		return price, nil
+	})
+	d.prices.EXPECT().FindEffective(gomock.Any(), uint(1), gomock.Any()).Return(&prices(time.Now())[0], nil)
+	gomock.InOrder(
+		d.prices.EXPECT().DeleteScheduled(gomock.Any(), uint(1), uint(3), gomock.Any()).Return(internal.ErrPriceStarted),
+		d.prices.EXPECT().DeleteScheduled(gomock.Any(), uint(1), uint(4), gomock.Any()).Return(nil),
+		d.prices.EXPECT().DeleteScheduled(gomock.Any(), uint(1), uint(4), gomock.Any()).Return(gorm.ErrRecordNotFound),
+	)
+
+	_, err := service.Schedule(context.Background(), 1, dto.SchedulePriceRequest{Price: dto.MoneyRequest{Amount: 1}, EffectiveFrom: time.Now().Add(-time.Hour)})
+	assert.ErrorIs(t, err, internal.ErrPriceInPast)
+
+	_, err = service.Schedule(context.Background(), 2, dto.SchedulePriceRequest{Price: dto.MoneyRequest{Amount: 1}, EffectiveFrom: time.Now().Add(time.Hour)})
+	assert.ErrorIs(t, err, internal.ErrBookNotFound)
+
+	_, err = service.Schedule(context.Background(), 1, dto.SchedulePriceRequest{Price: dto.MoneyRequest{Amount: 1, Currency: "USD"}, EffectiveFrom: time.Now().Add(time.Hour)})
+	assert.ErrorIs(t, err, internal.ErrPriceCurrency)
+
+	scheduled, err := service.Schedule(context.Background(), 1, dto.SchedulePriceRequest{Price: dto.MoneyRequest{Amount: 1}, EffectiveFrom: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, dto.PriceScheduled, scheduled.Status)
+	assert.Equal(t, money.New(1, "IDR"), scheduled.Price)
+
+	assert.ErrorIs(t, service.Cancel(context.Background(), 1, 3), internal.ErrPriceAlreadyStarted)
+	require.NoError(t, service.Cancel(context.Background(), 1, scheduled.ID))
+	assert.ErrorIs(t, service.Cancel(context.Background(), 1, scheduled.ID), internal.ErrPriceNotFound)
+}
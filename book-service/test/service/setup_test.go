@@ -0,0 +1,59 @@
+package service_test
+
+import (
+	"book-service/internal"
+	"book-service/pkg/blob"
+	mocks "book-service/test/mock"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-errors/validation"
+	"github.com/golang/mock/gomock"
+)
+
+// deps holds what the services under test are built from: mocked
+// repositories, covers stored in a temporary directory and a rate table
+// with IDR as its base.
+type deps struct {
+	books      *mocks.MockBookRepository
+	categories *mocks.MockCategoryRepository
+	prices     *mocks.MockPriceRepository
+	jobs       *mocks.MockImportJobRepository
+	covers     *blob.Local
+	rates      *money.Table
+}
+
+func setup(t *testing.T) *deps {
+	validation.Register()
+	ctrl := gomock.NewController(t)
+	return &deps{
+		books:      mocks.NewMockBookRepository(ctrl),
+		categories: mocks.NewMockCategoryRepository(ctrl),
+		prices:     mocks.NewMockPriceRepository(ctrl),
+		jobs:       mocks.NewMockImportJobRepository(ctrl),
+		covers:     blob.NewLocal(t.TempDir(), "/covers"),
+		rates:      money.NewTable("IDR", map[string]*big.Rat{"USD": big.NewRat(1, 16000)}),
+	}
+}
+
+func (d *deps) bookService() internal.BookService {
+	return internal.NewBookService(d.books, d.categories, d.covers, d.rates)
+}
+
+func (d *deps) categoryService() internal.CategoryService {
+	return internal.NewCategoryService(d.categories)
+}
+
+func (d *deps) coverService(maxBytes int64) internal.CoverService {
+	return internal.NewCoverService(d.books, d.covers, maxBytes)
+}
+
+func (d *deps) importService() internal.ImportService {
+	return internal.NewImportService(d.books, d.jobs, "")
+}
+
+func (d *deps) priceService() internal.PriceService {
+	return internal.NewPriceService(d.books, d.prices, time.Minute)
+}
@@ -0,0 +1,5 @@
+// Package contracts holds the protobuf contracts shared between the services
+// together with the generated Go code and the gRPC interceptors.
+package contracts
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative bookpb/book.proto userpb/user.proto
@@ -0,0 +1,23 @@
+module github.com/fahrizalvianaz/shared-contracts
+
+go 1.24.0
+
+require (
+	github.com/fahrizalvianaz/shared-middleware v0.0.0-20250314034642-8c44a6b14a76
+	github.com/golang-jwt/jwt/v5 v5.2.1
+	github.com/stretchr/testify v1.10.0
+	google.golang.org/grpc v1.71.1
+	google.golang.org/protobuf v1.36.5
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/fahrizalvianaz/shared-configuration v0.0.0-20250314064920-6532d9ebf6af // indirect
+	github.com/joho/godotenv v1.5.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.37.0 // indirect
+	golang.org/x/sys v0.31.0 // indirect
+	golang.org/x/text v0.23.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250115164207-1a7da9e5054f // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
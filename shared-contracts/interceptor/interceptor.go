@@ -0,0 +1,146 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	shared_middleware "github.com/fahrizalvianaz/shared-middleware"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationKey = "authorization"
+	serviceTokenKey  = "x-service-token"
+)
+
+type tokenKey struct{}
+type claimsKey struct{}
+
+// WithToken stores the caller's bearer token so the client interceptor can
+// forward it as metadata on outgoing calls.
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+// ClaimsFromContext returns the claims the server interceptor validated.
+func ClaimsFromContext(ctx context.Context) (*shared_middleware.Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*shared_middleware.Claims)
+	return claims, ok
+}
+
+// UnaryClientDeadline applies a default timeout to calls whose context does not
+// carry a deadline yet, so a stuck upstream cannot hang the caller.
+func UnaryClientDeadline(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// UnaryClientAuth forwards the token stored with WithToken as authorization
+// metadata.
+func UnaryClientAuth() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if token, ok := ctx.Value(tokenKey{}).(string); ok && token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, authorizationKey, "Bearer "+token)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// UnaryClientServiceToken sends token, the secret shared by the internal
+// services, as metadata on outgoing calls. It identifies the calling
+// service, unlike a forwarded user token.
+func UnaryClientServiceToken(token string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, serviceTokenKey, token)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// UnaryServerServiceToken reserves the full method names in methods for
+// other internal services: calls to them must carry token as sent by
+// UnaryClientServiceToken, whatever user token they forward. An empty token
+// refuses the methods to everyone.
+func UnaryServerServiceToken(token string, methods ...string) grpc.UnaryServerInterceptor {
+	internal := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		internal[method] = true
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !internal[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(serviceTokenKey)
+		if token == "" || len(values) == 0 || subtle.ConstantTimeCompare([]byte(values[0]), []byte(token)) != 1 {
+			return nil, status.Error(codes.PermissionDenied, "method is reserved for internal services")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// UnaryServerAuth rejects calls without a valid bearer token in their
+// metadata and exposes the claims through ClaimsFromContext. The full
+// method names in public may also be called without a token; a token that
+// is sent is still checked.
+func UnaryServerAuth(secretKey string, public ...string) grpc.UnaryServerInterceptor {
+	anonymous := make(map[string]bool, len(public))
+	for _, method := range public {
+		anonymous[method] = true
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(authorizationKey)
+		if len(values) == 0 && anonymous[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		if len(values) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "missing authorization metadata")
+		}
+
+		parts := strings.SplitN(values[0], " ", 2)
+		if !(len(parts) == 2 && parts[0] == "Bearer") {
+			return nil, status.Error(codes.Unauthenticated, "invalid authorization format")
+		}
+
+		claims := &shared_middleware.Claims{}
+		token, err := jwt.ParseWithClaims(parts[1], claims, func(t *jwt.Token) (interface{}, error) {
+			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, jwt.ErrSignatureInvalid
+			}
+			return []byte(secretKey), nil
+		})
+		if err != nil || !token.Valid {
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+		}
+
+		return handler(context.WithValue(ctx, claimsKey{}, claims), req)
+	}
+}
+
+// UnaryServerDeadline caps how long a single call may run on the server even
+// when the client did not send a deadline.
+func UnaryServerDeadline(max time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > max {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, max)
+			defer cancel()
+		}
+		return handler(ctx, req)
+	}
+}
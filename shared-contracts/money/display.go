@@ -0,0 +1,58 @@
+package money
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type displayKey struct{}
+
+// WithDisplay records the currency the client wants amounts shown in.
+func WithDisplay(ctx context.Context, currency string) context.Context {
+	return context.WithValue(ctx, displayKey{}, currency)
+}
+
+// DisplayFrom returns the currency set by WithDisplay, or "" when amounts
+// are shown as stored.
+func DisplayFrom(ctx context.Context) string {
+	currency, _ := ctx.Value(displayKey{}).(string)
+	return currency
+}
+
+// ParseAcceptCurrency returns the codes of an Accept-Currency header, such
+// as "EUR, USD;q=0.8", from most to least preferred. Codes with q=0 are
+// dropped.
+func ParseAcceptCurrency(header string) []string {
+	type choice struct {
+		currency string
+		q        float64
+	}
+	var choices []choice
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		currency := strings.ToUpper(strings.TrimSpace(fields[0]))
+		if currency == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			choices = append(choices, choice{currency, q})
+		}
+	}
+	sort.SliceStable(choices, func(i, j int) bool { return choices[i].q > choices[j].q })
+
+	currencies := make([]string, len(choices))
+	for i, choice := range choices {
+		currencies[i] = choice.currency
+	}
+	return currencies
+}
@@ -0,0 +1,95 @@
+// Package money represents prices as an integer amount of minor units (cents,
+// sen) together with an ISO 4217 currency code, so amounts are exact and
+// never ambiguous about their currency.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// DefaultCurrency is the currency of prices stored before currencies were
+// recorded.
+const DefaultCurrency = "IDR"
+
+var (
+	ErrUnknownCurrency = errors.New("unknown currency")
+	ErrInvalidAmount   = errors.New("invalid amount")
+)
+
+// exponents lists the number of minor-unit digits of the supported ISO 4217
+// currencies.
+var exponents = map[string]int{
+	"AUD": 2, "CAD": 2, "CHF": 2, "CNY": 2, "EUR": 2, "GBP": 2, "HKD": 2,
+	"IDR": 2, "INR": 2, "JPY": 0, "KRW": 0, "MYR": 2, "NZD": 2, "PHP": 2,
+	"SGD": 2, "THB": 2, "USD": 2, "VND": 0,
+}
+
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Exponent reports the minor-unit digits of currency.
+func Exponent(currency string) (int, bool) {
+	exponent, ok := exponents[currency]
+	return exponent, ok
+}
+
+// Known reports whether currency is a supported ISO 4217 code.
+func Known(currency string) bool {
+	_, ok := exponents[currency]
+	return ok
+}
+
+// Parse reads a decimal amount in major units, such as "85000" or "12.5",
+// and rejects more decimals than the currency has.
+func Parse(value, currency string) (Money, error) {
+	exponent, ok := Exponent(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("%w %q", ErrUnknownCurrency, currency)
+	}
+	amount, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return Money{}, fmt.Errorf("%w %q", ErrInvalidAmount, value)
+	}
+	amount.Mul(amount, new(big.Rat).SetInt(pow10(exponent)))
+	if !amount.IsInt() || !amount.Num().IsInt64() {
+		return Money{}, fmt.Errorf("%w %q for %s", ErrInvalidAmount, value, currency)
+	}
+	return New(amount.Num().Int64(), currency), nil
+}
+
+// Decimal formats the amount in major units with the currency's number of
+// decimals, such as "85000.00".
+func (m Money) Decimal() string {
+	exponent, ok := Exponent(m.Currency)
+	if !ok || exponent == 0 {
+		return fmt.Sprintf("%d", m.Amount)
+	}
+	return new(big.Rat).SetFrac(big.NewInt(m.Amount), pow10(exponent)).FloatString(exponent)
+}
+
+func (m Money) String() string {
+	return m.Currency + " " + m.Decimal()
+}
+
+// Mul scales the amount, for example by a quantity.
+func (m Money) Mul(n int64) Money {
+	return New(m.Amount*n, m.Currency)
+}
+
+// Sub takes o, which must be in the same currency, off the amount.
+func (m Money) Sub(o Money) Money {
+	return New(m.Amount-o.Amount, m.Currency)
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
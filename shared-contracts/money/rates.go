@@ -0,0 +1,147 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+)
+
+// ErrNoRate is returned when no exchange rate between two currencies is
+// known.
+var ErrNoRate = errors.New("no exchange rate")
+
+// Rates provides exchange rates.
+type Rates interface {
+	// Rate is the amount of to that one unit of from buys.
+	Rate(from, to string) (*big.Rat, error)
+	// Supports reports whether amounts can be converted into currency.
+	Supports(currency string) bool
+}
+
+// Table holds rates against a single base currency; cross rates go through
+// the base.
+type Table struct {
+	base  string
+	rates map[string]*big.Rat
+}
+
+func NewTable(base string, rates map[string]*big.Rat) *Table {
+	table := &Table{base: base, rates: map[string]*big.Rat{base: big.NewRat(1, 1)}}
+	for currency, rate := range rates {
+		table.rates[currency] = rate
+	}
+	return table
+}
+
+func (t *Table) Rate(from, to string) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+	fromRate, ok := t.rates[from]
+	if !ok {
+		return nil, fmt.Errorf("%w from %s", ErrNoRate, from)
+	}
+	toRate, ok := t.rates[to]
+	if !ok {
+		return nil, fmt.Errorf("%w to %s", ErrNoRate, to)
+	}
+	return new(big.Rat).Quo(toRate, fromRate), nil
+}
+
+func (t *Table) Supports(currency string) bool {
+	_, ok := t.rates[currency]
+	return ok && Known(currency)
+}
+
+// Currencies lists the currencies in the table in alphabetical order.
+func (t *Table) Currencies() []string {
+	currencies := make([]string, 0, len(t.rates))
+	for currency := range t.rates {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	return currencies
+}
+
+// rateFile is the layout of a rates file:
+//
+//	{"base": "USD", "rates": {"IDR": "16385.5", "EUR": 0.92}}
+//
+// Rates are units of each currency per unit of base, as numbers or
+// decimal strings.
+type rateFile struct {
+	Base  string                 `json:"base"`
+	Rates map[string]json.Number `json:"rates"`
+}
+
+// LoadFile reads a rates table from a JSON file, so conversion works
+// without access to a rates service.
+func LoadFile(path string) (*Table, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file rateFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if !Known(file.Base) {
+		return nil, fmt.Errorf("%s: %w %q", path, ErrUnknownCurrency, file.Base)
+	}
+
+	rates := make(map[string]*big.Rat, len(file.Rates))
+	for currency, value := range file.Rates {
+		rate, ok := new(big.Rat).SetString(value.String())
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("%s: invalid rate %q for %s", path, value, currency)
+		}
+		rates[currency] = rate
+	}
+	return NewTable(file.Base, rates), nil
+}
+
+// RatesFromEnv loads the file named by EXCHANGE_RATES_FILE. Without one
+// only DefaultCurrency is supported and nothing is converted.
+func RatesFromEnv() (*Table, error) {
+	path := os.Getenv("EXCHANGE_RATES_FILE")
+	if path == "" {
+		return NewTable(DefaultCurrency, nil), nil
+	}
+	return LoadFile(path)
+}
+
+// Convert expresses m in currency to, rounding half away from zero to the
+// target's minor unit.
+func Convert(m Money, to string, rates Rates) (Money, error) {
+	if m.Currency == to {
+		return m, nil
+	}
+	fromExponent, ok := Exponent(m.Currency)
+	if !ok {
+		return Money{}, fmt.Errorf("%w %q", ErrUnknownCurrency, m.Currency)
+	}
+	toExponent, ok := Exponent(to)
+	if !ok {
+		return Money{}, fmt.Errorf("%w %q", ErrUnknownCurrency, to)
+	}
+	rate, err := rates.Rate(m.Currency, to)
+	if err != nil {
+		return Money{}, err
+	}
+
+	amount := new(big.Rat).Mul(big.NewRat(m.Amount, 1), rate)
+	amount.Mul(amount, new(big.Rat).SetFrac(pow10(toExponent), pow10(fromExponent)))
+	return New(round(amount), to), nil
+}
+
+// round rounds half away from zero.
+func round(r *big.Rat) int64 {
+	quotient, remainder := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(r.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(r.Sign())))
+	}
+	return quotient.Int64()
+}
@@ -0,0 +1,58 @@
+package interceptor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fahrizalvianaz/shared-contracts/interceptor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const reserveMethod = "/book.BookService/ReserveStock"
+
+func callServiceToken(t *testing.T, token, method string, md metadata.MD) error {
+	t.Helper()
+	server := interceptor.UnaryServerServiceToken(token, reserveMethod)
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	_, err := server(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	return err
+}
+
+func TestUnaryServerServiceToken(t *testing.T) {
+	err := callServiceToken(t, "secret", reserveMethod, metadata.Pairs("x-service-token", "secret"))
+	assert.NoError(t, err)
+
+	err = callServiceToken(t, "secret", "/book.BookService/GetBook", metadata.MD{})
+	assert.NoError(t, err, "other methods are not restricted")
+
+	for name, md := range map[string]metadata.MD{
+		"missing":    {},
+		"wrong":      metadata.Pairs("x-service-token", "guess"),
+		"user token": metadata.Pairs("authorization", "Bearer secret"),
+	} {
+		err := callServiceToken(t, "secret", reserveMethod, md)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), name)
+	}
+
+	err = callServiceToken(t, "", reserveMethod, metadata.Pairs("x-service-token", ""))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "an unset token refuses everyone")
+}
+
+func TestUnaryClientServiceToken(t *testing.T) {
+	var sent metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		sent, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	client := interceptor.UnaryClientServiceToken("secret")
+	require.NoError(t, client(context.Background(), reserveMethod, nil, nil, nil, invoker))
+	assert.Equal(t, []string{"secret"}, sent.Get("x-service-token"))
+}
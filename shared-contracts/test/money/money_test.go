@@ -0,0 +1,90 @@
+package money_test
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAndDecimal(t *testing.T) {
+	m, err := money.Parse("85000", "IDR")
+	require.NoError(t, err)
+	assert.Equal(t, money.New(8500000, "IDR"), m)
+	assert.Equal(t, "85000.00", m.Decimal())
+	assert.Equal(t, "IDR 85000.00", m.String())
+
+	m, err = money.Parse("12.5", "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1250), m.Amount)
+
+	m, err = money.Parse("1500", "JPY")
+	require.NoError(t, err)
+	assert.Equal(t, "1500", m.Decimal())
+
+	_, err = money.Parse("1.005", "USD")
+	assert.ErrorIs(t, err, money.ErrInvalidAmount)
+	_, err = money.Parse("1.5", "JPY")
+	assert.ErrorIs(t, err, money.ErrInvalidAmount)
+	_, err = money.Parse("10", "XYZ")
+	assert.ErrorIs(t, err, money.ErrUnknownCurrency)
+}
+
+func TestConvert(t *testing.T) {
+	rates := money.NewTable("USD", map[string]*big.Rat{
+		"IDR": big.NewRat(16000, 1),
+		"JPY": big.NewRat(150, 1),
+	})
+
+	converted, err := money.Convert(money.New(8000000, "IDR"), "USD", rates)
+	require.NoError(t, err)
+	assert.Equal(t, money.New(500, "USD"), converted)
+
+	converted, err = money.Convert(money.New(8000000, "IDR"), "JPY", rates)
+	require.NoError(t, err)
+	assert.Equal(t, money.New(750, "JPY"), converted)
+
+	// 0.5 JPY rounds away from zero.
+	converted, err = money.Convert(money.New(5, "IDR"), "JPY", money.NewTable("JPY", map[string]*big.Rat{"IDR": big.NewRat(1, 1)}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), converted.Amount)
+	converted, err = money.Convert(money.New(50, "IDR"), "JPY", money.NewTable("JPY", map[string]*big.Rat{"IDR": big.NewRat(1, 1)}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), converted.Amount)
+
+	_, err = money.Convert(money.New(100, "USD"), "EUR", rates)
+	assert.ErrorIs(t, err, money.ErrNoRate)
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"base":"USD","rates":{"IDR":"16000","EUR":0.5}}`), 0o644))
+
+	rates, err := money.LoadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"EUR", "IDR", "USD"}, rates.Currencies())
+	assert.True(t, rates.Supports("EUR"))
+	assert.False(t, rates.Supports("GBP"))
+
+	converted, err := money.Convert(money.New(1600000, "IDR"), "EUR", rates)
+	require.NoError(t, err)
+	assert.Equal(t, money.New(50, "EUR"), converted)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"base":"USD","rates":{"IDR":"-1"}}`), 0o644))
+	_, err = money.LoadFile(path)
+	assert.Error(t, err)
+}
+
+func TestParseAcceptCurrency(t *testing.T) {
+	assert.Equal(t, []string{"EUR", "JPY", "USD"}, money.ParseAcceptCurrency("usd;q=0.5, EUR, JPY;q=0.8, GBP;q=0"))
+	assert.Empty(t, money.ParseAcceptCurrency(""))
+
+	ctx := money.WithDisplay(context.Background(), "EUR")
+	assert.Equal(t, "EUR", money.DisplayFrom(ctx))
+	assert.Equal(t, "", money.DisplayFrom(context.Background()))
+}
@@ -0,0 +1,218 @@
+// Package apperror defines the domain error taxonomy shared by the services.
+// Service layers return *Error values; handlers pass them to Respond, which
+// maps the kind to an HTTP status and renders a stable error code.
+package apperror
+
+import (
+	"errors"
+	"net/http"
+)
+
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindUnauthorized
+	KindForbidden
+	// KindPreconditionFailed reports a stale If-Match or similar
+	// conditional request.
+	KindPreconditionFailed
+	// KindPreconditionRequired reports a missing conditional header on a
+	// route that insists on one.
+	KindPreconditionRequired
+)
+
+// Codes used across services. Service specific codes live next to the
+// service errors.
+const (
+	CodeInternal         = "INTERNAL_ERROR"
+	CodeValidationFailed = "VALIDATION_FAILED"
+	CodeUnauthorized     = "UNAUTHORIZED"
+	CodeForbidden        = "FORBIDDEN"
+)
+
+// ErrInvalidID is returned for path or query ids that are not positive
+// integers.
+var ErrInvalidID = Validation("INVALID_ID", "Invalid ID format")
+
+func (k Kind) String() string {
+	switch k {
+	case KindNotFound:
+		return "not_found"
+	case KindConflict:
+		return "conflict"
+	case KindValidation:
+		return "validation"
+	case KindUnauthorized:
+		return "unauthorized"
+	case KindForbidden:
+		return "forbidden"
+	case KindPreconditionFailed:
+		return "precondition_failed"
+	case KindPreconditionRequired:
+		return "precondition_required"
+	default:
+		return "internal"
+	}
+}
+
+// HTTPStatus is the status code a kind is rendered with.
+func (k Kind) HTTPStatus() int {
+	switch k {
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindValidation:
+		return http.StatusUnprocessableEntity
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindPreconditionFailed:
+		return http.StatusPreconditionFailed
+	case KindPreconditionRequired:
+		return http.StatusPreconditionRequired
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// KindForStatus is the inverse of HTTPStatus, used when relaying an
+// upstream service's error.
+func KindForStatus(status int) Kind {
+	switch status {
+	case http.StatusNotFound:
+		return KindNotFound
+	case http.StatusConflict:
+		return KindConflict
+	case http.StatusPreconditionFailed:
+		return KindPreconditionFailed
+	case http.StatusPreconditionRequired:
+		return KindPreconditionRequired
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return KindValidation
+	case http.StatusUnauthorized:
+		return KindUnauthorized
+	case http.StatusForbidden:
+		return KindForbidden
+	default:
+		return KindInternal
+	}
+}
+
+// Error is a domain error. Code is machine readable and stable, Message is
+// safe to show to clients and Err is the underlying cause, which is only
+// logged.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+// FieldError describes one invalid request field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func NotFound(code, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+func Conflict(code, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+func Validation(code, message string) *Error {
+	return New(KindValidation, code, message)
+}
+
+func Unauthorized(code, message string) *Error {
+	return New(KindUnauthorized, code, message)
+}
+
+func Forbidden(code, message string) *Error {
+	return New(KindForbidden, code, message)
+}
+
+func PreconditionFailed(code, message string) *Error {
+	return New(KindPreconditionFailed, code, message)
+}
+
+func PreconditionRequired(code, message string) *Error {
+	return New(KindPreconditionRequired, code, message)
+}
+
+// Internal wraps an unexpected error, hiding its text from clients.
+func Internal(err error) *Error {
+	return &Error{Kind: KindInternal, Code: CodeInternal, Message: "Internal Server Error", Err: err}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches errors by code, so a sentinel such as ErrBookNotFound still
+// matches after Wrap has attached a cause.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Extensions exposes the code to GraphQL clients.
+func (e *Error) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.Code}
+}
+
+// WithFields returns a copy of e listing the invalid fields.
+func (e *Error) WithFields(fields ...FieldError) *Error {
+	wrapped := *e
+	wrapped.Fields = fields
+	return &wrapped
+}
+
+// Wrap returns a copy of e carrying err as its cause.
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+// WithMessage returns a copy of e with a more specific client message.
+func (e *Error) WithMessage(message string) *Error {
+	wrapped := *e
+	wrapped.Message = message
+	return &wrapped
+}
+
+// From returns err as an *Error, treating anything untyped as internal.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return Internal(err)
+}
+
+// KindOf reports the kind of err, KindInternal when it is untyped.
+func KindOf(err error) Kind {
+	return From(err).Kind
+}
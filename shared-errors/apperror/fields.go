@@ -0,0 +1,96 @@
+package apperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// BindError reports a request that could not be bound or failed binding
+// validation, listing every offending field.
+func BindError(err error) *Error {
+	appErr := Validation(CodeValidationFailed, "Invalid Request format").Wrap(err)
+
+	var validationErrors validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &validationErrors):
+		fields := make([]FieldError, 0, len(validationErrors))
+		for _, fieldErr := range validationErrors {
+			fields = append(fields, FieldError{
+				Field:   fieldErr.Field(),
+				Code:    fieldErr.Tag(),
+				Message: fieldMessage(fieldErr),
+			})
+		}
+		appErr.Fields = fields
+	case errors.As(err, &typeErr):
+		appErr.Fields = []FieldError{{
+			Field:   typeErr.Field,
+			Code:    "type",
+			Message: "must be a " + typeName(typeErr.Type),
+		}}
+	}
+	return appErr
+}
+
+func fieldMessage(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	isString := fieldErr.Kind() == reflect.String
+
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "notblank":
+		return "must not be blank"
+	case "email":
+		return "must be a valid email address"
+	case "isbn":
+		return "must be a valid ISBN-10 or ISBN-13"
+	case "password":
+		return "must be 8 to 72 characters and contain a letter and a digit"
+	case "username":
+		return "must be 3 to 32 letters, digits, dots, dashes or underscores"
+	case "oneof":
+		return "must be one of " + param
+	case "min", "gte":
+		if isString {
+			return fmt.Sprintf("must be at least %s characters", param)
+		}
+		return "must be at least " + param
+	case "max", "lte":
+		if isString {
+			return fmt.Sprintf("must be at most %s characters", param)
+		}
+		return "must be at most " + param
+	case "gt":
+		return "must be greater than " + param
+	case "lt":
+		return "must be less than " + param
+	case "len":
+		return fmt.Sprintf("must have length %s", param)
+	default:
+		return fmt.Sprintf("failed the %s rule", fieldErr.Tag())
+	}
+}
+
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "whole number"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	case reflect.Struct, reflect.Map:
+		return "JSON object"
+	default:
+		return t.String()
+	}
+}
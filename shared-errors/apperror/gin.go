@@ -0,0 +1,51 @@
+package apperror
+
+import (
+	"log/slog"
+
+	genericResponse "github.com/fahrizalvianaz/shared-response/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+// Body is the data part of the error envelope.
+type Body struct {
+	Error  string       `json:"error"`
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// Respond renders err with the status for its kind. Clients that accept
+// application/problem+json get an RFC 7807 document; everyone else gets the
+// standard response envelope. Internal errors are logged and reported
+// without their cause.
+func Respond(ctx *gin.Context, err error) {
+	appErr := From(err)
+	status := appErr.Kind.HTTPStatus()
+
+	if appErr.Kind == KindInternal {
+		slog.ErrorContext(ctx.Request.Context(), "request failed", "error", err)
+	}
+
+	if WantsProblem(ctx) {
+		ctx.Render(status, problemRender{NewProblem(appErr, ctx.Request.URL.RequestURI())})
+		return
+	}
+
+	ctx.JSON(status, genericResponse.Response{
+		Code:    status,
+		Message: appErr.Message,
+		Status:  genericResponse.StatusFailed,
+		Data:    Body{Error: appErr.Code, Fields: appErr.Fields},
+	})
+}
+
+// WantsProblem reports whether the client prefers problem+json over the
+// envelope. Wildcards and plain application/json keep the envelope.
+func WantsProblem(ctx *gin.Context) bool {
+	return ctx.NegotiateFormat(gin.MIMEJSON, ProblemContentType) == ProblemContentType
+}
+
+// NoRoute answers unknown routes through Respond so they honour content
+// negotiation too.
+func NoRoute(ctx *gin.Context) {
+	Respond(ctx, NotFound("ROUTE_NOT_FOUND", "Route not found"))
+}
@@ -0,0 +1,122 @@
+package apperror
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+const (
+	errorInfoDomain = "bookstore"
+	// kindMetadataKey names the ErrorInfo metadata entry holding the kind,
+	// for kinds that share a gRPC code.
+	kindMetadataKey = "kind"
+)
+
+var kindCodes = map[Kind]codes.Code{
+	KindInternal:             codes.Internal,
+	KindNotFound:             codes.NotFound,
+	KindConflict:             codes.FailedPrecondition,
+	KindValidation:           codes.InvalidArgument,
+	KindUnauthorized:         codes.Unauthenticated,
+	KindForbidden:            codes.PermissionDenied,
+	KindPreconditionFailed:   codes.Aborted,
+	KindPreconditionRequired: codes.FailedPrecondition,
+}
+
+// codeKinds is the reverse of kindCodes. FailedPrecondition is shared, so
+// without the kind from ErrorInfo it resolves to the more general conflict
+// kind.
+var codeKinds = map[codes.Code]Kind{
+	codes.NotFound:           KindNotFound,
+	codes.FailedPrecondition: KindConflict,
+	codes.InvalidArgument:    KindValidation,
+	codes.Unauthenticated:    KindUnauthorized,
+	codes.PermissionDenied:   KindForbidden,
+	codes.Aborted:            KindPreconditionFailed,
+}
+
+// ToStatus converts err into a gRPC status. The domain code and kind travel
+// as an ErrorInfo detail and the invalid fields as a BadRequest detail, so
+// FromStatus can restore the error on the client.
+func ToStatus(err error) error {
+	appErr := From(err)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   appErr.Code,
+		Domain:   errorInfoDomain,
+		Metadata: map[string]string{kindMetadataKey: appErr.Kind.String()},
+	}}
+	if len(appErr.Fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(appErr.Fields))
+		for _, field := range appErr.Fields {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Reason:      field.Code,
+				Description: field.Message,
+			})
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+
+	st := status.New(kindCodes[appErr.Kind], appErr.Message)
+	if detailed, detailErr := st.WithDetails(details...); detailErr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// FromStatus converts a gRPC error back into a domain error. Transport
+// failures such as Unavailable become internal errors.
+func FromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return Internal(err)
+	}
+
+	kind, ok := codeKinds[st.Code()]
+	if !ok {
+		return Internal(errors.New(st.Message()))
+	}
+
+	code := CodeInternal
+	var fields []FieldError
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if detail.GetDomain() != errorInfoDomain {
+				continue
+			}
+			code = detail.GetReason()
+			if named, ok := kindNamed(detail.GetMetadata()[kindMetadataKey]); ok && kindCodes[named] == st.Code() {
+				kind = named
+			}
+		case *errdetails.BadRequest:
+			for _, violation := range detail.GetFieldViolations() {
+				fields = append(fields, FieldError{
+					Field:   violation.GetField(),
+					Code:    violation.GetReason(),
+					Message: violation.GetDescription(),
+				})
+			}
+		}
+	}
+
+	appErr := New(kind, code, st.Message())
+	if len(fields) > 0 {
+		appErr = appErr.WithFields(fields...)
+	}
+	return appErr
+}
+
+// kindNamed looks up the kind whose String is name.
+func kindNamed(name string) (Kind, bool) {
+	for kind := range kindCodes {
+		if kind.String() == name {
+			return kind, true
+		}
+	}
+	return KindInternal, false
+}
@@ -0,0 +1,39 @@
+package apperror
+
+import (
+	"net/http"
+	"strings"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypeBase prefixes the problem type URI, which ends with the error
+// code in lower kebab case.
+var ProblemTypeBase = "https://bookstore.local/problems/"
+
+// Problem is an RFC 7807 problem details document. Code and Errors are
+// extension members.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// NewProblem builds the problem document for err raised while serving
+// instance.
+func NewProblem(err *Error, instance string) Problem {
+	status := err.Kind.HTTPStatus()
+	return Problem{
+		Type:     ProblemTypeBase + strings.ReplaceAll(strings.ToLower(err.Code), "_", "-"),
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   err.Message,
+		Instance: instance,
+		Code:     err.Code,
+		Errors:   err.Fields,
+	}
+}
@@ -0,0 +1,21 @@
+package apperror
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// problemRender writes a Problem with the problem+json content type, which
+// gin's JSON renderer would overwrite.
+type problemRender struct {
+	problem Problem
+}
+
+func (r problemRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return json.NewEncoder(w).Encode(r.problem)
+}
+
+func (r problemRender) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", ProblemContentType)
+}
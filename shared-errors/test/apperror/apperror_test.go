@@ -0,0 +1,162 @@
+package apperror_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"github.com/fahrizalvianaz/shared-errors/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errBookNotFound = apperror.NotFound("BOOK_NOT_FOUND", "Book not found")
+
+func respond(t *testing.T, err error) (int, map[string]interface{}) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	apperror.Respond(ctx, err)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return w.Code, body
+}
+
+func TestRespond_MapsKindsToStatus(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{errBookNotFound, http.StatusNotFound, "BOOK_NOT_FOUND"},
+		{apperror.Conflict("USER_ALREADY_EXISTS", "User already exists"), http.StatusConflict, "USER_ALREADY_EXISTS"},
+		{apperror.Validation(apperror.CodeValidationFailed, "Invalid Request format"), http.StatusUnprocessableEntity, apperror.CodeValidationFailed},
+		{apperror.Unauthorized("INVALID_CREDENTIALS", "Invalid username or password"), http.StatusUnauthorized, "INVALID_CREDENTIALS"},
+		{apperror.Forbidden(apperror.CodeForbidden, "Access forbidden"), http.StatusForbidden, apperror.CodeForbidden},
+		{apperror.PreconditionFailed("VERSION_MISMATCH", "Book was modified"), http.StatusPreconditionFailed, "VERSION_MISMATCH"},
+		{apperror.PreconditionRequired("IF_MATCH_REQUIRED", "If-Match header is required"), http.StatusPreconditionRequired, "IF_MATCH_REQUIRED"},
+	}
+
+	for _, c := range cases {
+		status, body := respond(t, c.err)
+		assert.Equal(t, c.status, status)
+		assert.Equal(t, float64(c.status), body["code"])
+		assert.Equal(t, false, body["status"])
+		assert.Equal(t, c.code, body["data"].(map[string]interface{})["error"])
+	}
+}
+
+func TestRespond_HidesInternalErrors(t *testing.T) {
+	status, body := respond(t, errors.New(`pq: relation "books" does not exist`))
+
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.Equal(t, "Internal Server Error", body["message"])
+	assert.Equal(t, apperror.CodeInternal, body["data"].(map[string]interface{})["error"])
+}
+
+func TestError_WrapKeepsIdentity(t *testing.T) {
+	cause := errors.New("record not found")
+	err := errBookNotFound.Wrap(cause)
+
+	assert.ErrorIs(t, err, errBookNotFound)
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(err))
+	assert.Equal(t, apperror.KindInternal, apperror.KindOf(cause))
+	assert.Equal(t, "Book not found", apperror.From(err).Message)
+}
+
+func TestStatus_RoundTrip(t *testing.T) {
+	err := apperror.FromStatus(apperror.ToStatus(errBookNotFound.Wrap(errors.New("record not found"))))
+
+	assert.ErrorIs(t, err, errBookNotFound)
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(err))
+	assert.Equal(t, "Book not found", err.Error())
+
+	err = apperror.FromStatus(apperror.ToStatus(errors.New("connection refused")))
+	assert.Equal(t, apperror.KindInternal, apperror.KindOf(err))
+
+	err = apperror.FromStatus(apperror.ToStatus(apperror.Conflict("INSUFFICIENT_STOCK", "Not enough stock")))
+	assert.Equal(t, apperror.KindConflict, apperror.KindOf(err))
+
+	err = apperror.FromStatus(apperror.ToStatus(apperror.PreconditionFailed("VERSION_MISMATCH", "Book was modified")))
+	assert.Equal(t, apperror.KindPreconditionFailed, apperror.KindOf(err))
+}
+
+func TestStatus_RoundTripKeepsKindAndFields(t *testing.T) {
+	err := apperror.FromStatus(apperror.ToStatus(apperror.PreconditionRequired("IF_MATCH_REQUIRED", "If-Match header is required")))
+	assert.Equal(t, apperror.KindPreconditionRequired, apperror.KindOf(err))
+	assert.Equal(t, "IF_MATCH_REQUIRED", apperror.From(err).Code)
+
+	fields := []apperror.FieldError{
+		{Field: "quantity", Code: "min", Message: "quantity must be at least 1"},
+		{Field: "bookId", Code: "required", Message: "bookId is required"},
+	}
+	sent := apperror.Validation(apperror.CodeValidationFailed, "Invalid Request format").WithFields(fields...)
+	err = apperror.FromStatus(apperror.ToStatus(sent))
+	assert.Equal(t, apperror.KindValidation, apperror.KindOf(err))
+	assert.Equal(t, fields, apperror.From(err).Fields)
+}
+
+func TestRespond_ProblemJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	validation.Register()
+
+	type request struct {
+		Title string `json:"title" binding:"required"`
+		Price int    `json:"price" binding:"min=0"`
+	}
+	router := gin.New()
+	router.POST("/books", func(ctx *gin.Context) {
+		var req request
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			apperror.Respond(ctx, apperror.BindError(err))
+			return
+		}
+	})
+
+	send := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/books?draft=1", strings.NewReader(`{"price":-1}`))
+		req.Header.Set("Content-Type", "application/json")
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("application/problem+json")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, apperror.ProblemContentType, w.Header().Get("Content-Type"))
+
+	var problem apperror.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, "https://bookstore.local/problems/validation-failed", problem.Type)
+	assert.Equal(t, "Unprocessable Entity", problem.Title)
+	assert.Equal(t, http.StatusUnprocessableEntity, problem.Status)
+	assert.Equal(t, "Invalid Request format", problem.Detail)
+	assert.Equal(t, "/books?draft=1", problem.Instance)
+	require.Len(t, problem.Errors, 2)
+	assert.Equal(t, "title", problem.Errors[0].Field)
+	assert.Equal(t, "is required", problem.Errors[0].Message)
+	assert.Equal(t, "required", problem.Errors[0].Code)
+	assert.Equal(t, "min", problem.Errors[1].Code)
+
+	for _, accept := range []string{"", "*/*", "application/json"} {
+		w := send(accept)
+		assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"), accept)
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, false, body["status"])
+		assert.Len(t, body["data"].(map[string]interface{})["fields"], 2)
+	}
+}
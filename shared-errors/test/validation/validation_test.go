@@ -0,0 +1,97 @@
+package validation_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"github.com/fahrizalvianaz/shared-errors/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type registerRequest struct {
+	Name     string `json:"name" binding:"required,notblank,max=100"`
+	Username string `json:"username" binding:"required,username"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,password"`
+	ISBN     string `json:"isbn" binding:"omitempty,isbn"`
+	Stock    int    `json:"stock" binding:"gte=0"`
+}
+
+func bind(t *testing.T, body string) []apperror.FieldError {
+	gin.SetMode(gin.TestMode)
+	validation.Register()
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	var req registerRequest
+	err := ctx.ShouldBindJSON(&req)
+	if err == nil {
+		return nil
+	}
+	return apperror.BindError(err).Fields
+}
+
+func TestRegister_ReportsEveryField(t *testing.T) {
+	fields := bind(t, `{"name":"  ","username":"a!","email":"nope","password":"short","isbn":"978-0-306-40615-6","stock":-1}`)
+
+	require.Len(t, fields, 6)
+	assert.Equal(t, apperror.FieldError{Field: "name", Code: "notblank", Message: "must not be blank"}, fields[0])
+	assert.Equal(t, "username", fields[1].Code)
+	assert.Equal(t, apperror.FieldError{Field: "email", Code: "email", Message: "must be a valid email address"}, fields[2])
+	assert.Equal(t, "password", fields[3].Code)
+	assert.Equal(t, apperror.FieldError{Field: "isbn", Code: "isbn", Message: "must be a valid ISBN-10 or ISBN-13"}, fields[4])
+	assert.Equal(t, apperror.FieldError{Field: "stock", Code: "gte", Message: "must be at least 0"}, fields[5])
+}
+
+func TestRegister_AcceptsValidRequest(t *testing.T) {
+	fields := bind(t, `{"name":"Ayu","username":"ayu.lestari","email":"ayu@example.com","password":"password123","isbn":"978-0-306-40615-7","stock":0}`)
+
+	assert.Empty(t, fields)
+}
+
+func TestBindError_TypeMismatch(t *testing.T) {
+	fields := bind(t, `{"stock":"many"}`)
+
+	require.Len(t, fields, 1)
+	assert.Equal(t, apperror.FieldError{Field: "stock", Code: "type", Message: "must be a whole number"}, fields[0])
+}
+
+func TestValidISBN(t *testing.T) {
+	assert.True(t, validation.ValidISBN("0-306-40615-2"))
+	assert.True(t, validation.ValidISBN("978-0-306-40615-7"))
+	assert.True(t, validation.ValidISBN("0 8044 2957 x"))
+	assert.False(t, validation.ValidISBN("0-306-40615-3"))
+	assert.False(t, validation.ValidISBN("978-0-306-40615-6"))
+	assert.False(t, validation.ValidISBN("12345"))
+	assert.False(t, validation.ValidISBN("97803064061A7"))
+}
+
+func TestISBNConversion(t *testing.T) {
+	isbn13, ok := validation.ISBN13("0-306-40615-2")
+	assert.True(t, ok)
+	assert.Equal(t, "9780306406157", isbn13)
+
+	isbn13, ok = validation.ISBN13("978-0-306-40615-7")
+	assert.True(t, ok)
+	assert.Equal(t, "9780306406157", isbn13)
+
+	isbn10, ok := validation.ISBN10("9780306406157")
+	assert.True(t, ok)
+	assert.Equal(t, "0306406152", isbn10)
+
+	isbn10, ok = validation.ISBN10("978-0-8044-2957-3")
+	assert.True(t, ok)
+	assert.Equal(t, "080442957X", isbn10)
+
+	_, ok = validation.ISBN10("979-10-90636-07-1")
+	assert.False(t, ok)
+	_, ok = validation.ISBN13("0-306-40615-3")
+	assert.False(t, ok)
+}
@@ -0,0 +1,101 @@
+package validation
+
+import "strings"
+
+// NormalizeISBN strips hyphens and spaces and upper-cases a trailing x.
+func NormalizeISBN(value string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(value))
+}
+
+// ValidISBN checks the length and checksum of an ISBN-10 or ISBN-13,
+// ignoring hyphens and spaces.
+func ValidISBN(value string) bool {
+	digits := NormalizeISBN(value)
+	switch len(digits) {
+	case 10:
+		return validISBN10(digits)
+	case 13:
+		return validISBN13(digits)
+	default:
+		return false
+	}
+}
+
+func validISBN10(digits string) bool {
+	sum := 0
+	for i := 0; i < 10; i++ {
+		var value int
+		switch c := digits[i]; {
+		case c >= '0' && c <= '9':
+			value = int(c - '0')
+		case c == 'X' && i == 9:
+			value = 10
+		default:
+			return false
+		}
+		sum += value * (10 - i)
+	}
+	return sum%11 == 0
+}
+
+func validISBN13(digits string) bool {
+	sum := 0
+	for i := 0; i < 13; i++ {
+		c := digits[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(c-'0') * weight
+	}
+	return sum%10 == 0
+}
+
+// ISBN13 normalizes value and converts a valid ISBN-10 to its ISBN-13 form
+// under the 978 prefix. It reports false when value is not a valid ISBN.
+func ISBN13(value string) (string, bool) {
+	digits := NormalizeISBN(value)
+	switch {
+	case len(digits) == 13 && validISBN13(digits):
+		return digits, true
+	case len(digits) == 10 && validISBN10(digits):
+		prefix := "978" + digits[:9]
+		return prefix + isbn13CheckDigit(prefix), true
+	default:
+		return "", false
+	}
+}
+
+// ISBN10 converts a valid ISBN-13 back to ISBN-10. Only the 978 prefix has
+// an ISBN-10 equivalent; 979 numbers report false.
+func ISBN10(value string) (string, bool) {
+	digits, ok := ISBN13(value)
+	if !ok || !strings.HasPrefix(digits, "978") {
+		return "", false
+	}
+	body := digits[3:12]
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(body[i]-'0') * (10 - i)
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return body + "X", true
+	}
+	return body + string(rune('0'+check)), true
+}
+
+func isbn13CheckDigit(prefix string) string {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(prefix[i]-'0') * weight
+	}
+	return string(rune('0' + (10-sum%10)%10))
+}
@@ -0,0 +1,94 @@
+// Package validation configures gin's request validator for the services:
+// field names are reported by their json or form tag and the custom rules
+// below become available as binding tags.
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	PasswordMinLength = 8
+	// PasswordMaxLength is bcrypt's input limit in bytes.
+	PasswordMaxLength = 72
+)
+
+var once sync.Once
+
+// Register installs the tag name function and custom validators on gin's
+// default validator. It is safe to call more than once.
+func Register() {
+	once.Do(func() {
+		validate, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+
+		validate.RegisterTagNameFunc(tagName)
+		validate.RegisterValidation("notblank", notBlank)
+		validate.RegisterValidation("isbn", isbn)
+		validate.RegisterValidation("password", password)
+		validate.RegisterValidation("username", username)
+	})
+}
+
+func tagName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form", "uri"} {
+		name := strings.SplitN(field.Tag.Get(key), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+func notBlank(fl validator.FieldLevel) bool {
+	return strings.TrimSpace(fl.Field().String()) != ""
+}
+
+func isbn(fl validator.FieldLevel) bool {
+	return ValidISBN(fl.Field().String())
+}
+
+// password requires PasswordMinLength to PasswordMaxLength bytes with at
+// least one letter and one digit.
+func password(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if len(value) < PasswordMinLength || len(value) > PasswordMaxLength {
+		return false
+	}
+
+	var letter, digit bool
+	for _, r := range value {
+		switch {
+		case unicode.IsLetter(r):
+			letter = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+	return letter && digit
+}
+
+// username allows 3 to 32 letters, digits, dots, dashes and underscores.
+func username(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if len(value) < 3 || len(value) > 32 {
+		return false
+	}
+	for _, r := range value {
+		if !(r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' || r == '_')) {
+			return false
+		}
+	}
+	return true
+}
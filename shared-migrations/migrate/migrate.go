@@ -0,0 +1,233 @@
+// Package migrate applies versioned SQL migrations to PostgreSQL. Applied
+// versions are tracked in a schema_migrations table and every run holds a
+// session advisory lock so replicas starting together do not race.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"log/slog"
+	"time"
+)
+
+const DefaultTable = "schema_migrations"
+
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *sql.DB
+	table      string
+	lockID     int64
+	migrations []Migration
+}
+
+// New loads the migrations in fsys. table defaults to DefaultTable.
+func New(db *sql.DB, fsys fs.FS, table string) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if table == "" {
+		table = DefaultTable
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(table))
+	return &Migrator{
+		db:         db,
+		table:      table,
+		lockID:     int64(hash.Sum64() >> 1),
+		migrations: migrations,
+	}, nil
+}
+
+// Up applies every pending migration in version order.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		if err := m.createTable(ctx, conn); err != nil {
+			return err
+		}
+		done, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			slog.InfoContext(ctx, "applying migration", "version", migration.Version, "name", migration.Name)
+			insert := fmt.Sprintf("INSERT INTO %s (version, name) VALUES ($1, $2)", m.table)
+			if err := m.exec(ctx, conn, migration.Up, insert, migration.Version, migration.Name); err != nil {
+				return fmt.Errorf("apply %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rolls back the latest steps applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		if err := m.createTable(ctx, conn); err != nil {
+			return err
+		}
+		done, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down script", migration.Version, migration.Name)
+			}
+			slog.InfoContext(ctx, "reverting migration", "version", migration.Version, "name", migration.Name)
+			remove := fmt.Sprintf("DELETE FROM %s WHERE version = $1", m.table)
+			if err := m.exec(ctx, conn, migration.Down, remove, migration.Version); err != nil {
+				return fmt.Errorf("revert %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lists every known migration with the time it was applied, if any.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	done, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if appliedAt, ok := done[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Pending returns an error naming the first migration not yet applied. It is
+// meant for readiness checks.
+func (m *Migrator) Pending(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	var first Status
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			if pending == 0 {
+				first = status
+			}
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d pending migrations starting at %d_%s", pending, first.Version, first.Name)
+	}
+	return nil
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", m.lockID); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", m.lockID)
+
+	return fn(conn)
+}
+
+// createTable creates the bookkeeping table on first use. It only runs for
+// up and down, under the lock, so status and readiness checks stay reads.
+func (m *Migrator) createTable(ctx context.Context, conn *sql.Conn) error {
+	create := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	version BIGINT PRIMARY KEY,
+	name TEXT NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`, m.table)
+	if _, err := conn.ExecContext(ctx, create); err != nil {
+		return fmt.Errorf("create %s: %w", m.table, err)
+	}
+	return nil
+}
+
+// applied returns the applied versions and when they were applied. Before
+// the first up there is no table yet and nothing is applied.
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	done := make(map[int64]time.Time)
+	var exists bool
+	if err := conn.QueryRowContext(ctx, "SELECT to_regclass($1) IS NOT NULL", m.table).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return done, nil
+	}
+
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf("SELECT version, applied_at FROM %s", m.table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		done[version] = appliedAt
+	}
+	return done, rows.Err()
+}
+
+// exec runs script and the bookkeeping statement in one transaction so a
+// failed migration leaves no trace.
+func (m *Migrator) exec(ctx context.Context, conn *sql.Conn, script, bookkeeping string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,168 @@
+package migrate_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fahrizalvianaz/shared-migrations/migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var files = fstest.MapFS{
+	"20250101000000_create_books.up.sql":    {Data: []byte("CREATE TABLE books (id BIGSERIAL PRIMARY KEY);")},
+	"20250101000000_create_books.down.sql":  {Data: []byte("DROP TABLE books;")},
+	"20250201000000_add_book_isbn.up.sql":   {Data: []byte("ALTER TABLE books ADD COLUMN isbn TEXT;")},
+	"20250201000000_add_book_isbn.down.sql": {Data: []byte("ALTER TABLE books DROP COLUMN isbn;")},
+	"README.md":                             {Data: []byte("ignored")},
+}
+
+func expectTable(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectApplied(mock sqlmock.Sqlmock, versions ...int64) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT to_regclass($1) IS NOT NULL")).
+		WithArgs("schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	rows := sqlmock.NewRows([]string{"version", "applied_at"})
+	for _, version := range versions {
+		rows.AddRow(version, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, applied_at FROM schema_migrations")).WillReturnRows(rows)
+}
+
+func TestLoad_OrdersAndValidates(t *testing.T) {
+	migrations, err := migrate.Load(files)
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	assert.Equal(t, int64(20250101000000), migrations[0].Version)
+	assert.Equal(t, "add_book_isbn", migrations[1].Name)
+	assert.Equal(t, "DROP TABLE books;", migrations[0].Down)
+
+	_, err = migrate.Load(fstest.MapFS{"create_books.sql": {Data: []byte("SELECT 1")}})
+	assert.Error(t, err)
+
+	_, err = migrate.Load(fstest.MapFS{"1_only_down.down.sql": {Data: []byte("SELECT 1")}})
+	assert.Error(t, err)
+}
+
+func TestMigrator_UpAppliesPendingUnderLock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	m, err := migrate.New(db, files, "")
+	require.NoError(t, err)
+
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).WillReturnResult(sqlmock.NewResult(0, 0))
+	expectTable(mock)
+	expectApplied(mock, 20250101000000)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE books ADD COLUMN isbn TEXT;")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version, name) VALUES ($1, $2)")).
+		WithArgs(int64(20250201000000), "add_book_isbn").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := m.Up(context.Background())
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	assert.Equal(t, "add_book_isbn", applied[0].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_DownRevertsLatest(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	m, err := migrate.New(db, files, "")
+	require.NoError(t, err)
+
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).WillReturnResult(sqlmock.NewResult(0, 0))
+	expectTable(mock)
+	expectApplied(mock, 20250101000000, 20250201000000)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE books DROP COLUMN isbn;")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM schema_migrations WHERE version = $1")).
+		WithArgs(int64(20250201000000)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	reverted, err := m.Down(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, reverted, 1)
+	assert.Equal(t, int64(20250201000000), reverted[0].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_PendingAndStatusCommand(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	m, err := migrate.New(db, files, "")
+	require.NoError(t, err)
+
+	expectApplied(mock, 20250101000000)
+	assert.EqualError(t, m.Pending(context.Background()), "1 pending migrations starting at 20250201000000_add_book_isbn")
+
+	expectApplied(mock, 20250101000000)
+	out := &bytes.Buffer{}
+	require.NoError(t, migrate.Run(context.Background(), m, "", []string{"status"}, out))
+	assert.Contains(t, out.String(), "20250101000000  create_books   2025-01-01T00:00:00Z")
+	assert.Contains(t, out.String(), "20250201000000  add_book_isbn  pending")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_StatusBeforeFirstUp(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	m, err := migrate.New(db, files, "")
+	require.NoError(t, err)
+
+	// Only reads: the table is not created outside up and down.
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT to_regclass($1) IS NOT NULL")).
+		WithArgs("schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	assert.EqualError(t, m.Pending(context.Background()), "2 pending migrations starting at 20250101000000_create_books")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreate_WritesMigrationPair(t *testing.T) {
+	dir := t.TempDir()
+
+	paths, err := migrate.Create(dir, "Add book ISBN", time.Date(2025, 3, 20, 10, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "20250320103000_add_book_isbn.up.sql"),
+		filepath.Join(dir, "20250320103000_add_book_isbn.down.sql"),
+	}, paths)
+	for _, path := range paths {
+		_, err := os.Stat(path)
+		assert.NoError(t, err)
+	}
+
+	// A fresh pair loads as it is, so creating one cannot break startup.
+	migrations, err := migrate.Load(os.DirFS(dir))
+	require.NoError(t, err)
+	require.Len(t, migrations, 1)
+	assert.Contains(t, migrations[0].Up, "SELECT 1;")
+	assert.Contains(t, migrations[0].Down, "SELECT 1;")
+
+	_, err = migrate.Create(dir, "Add book ISBN", time.Date(2025, 3, 20, 10, 30, 0, 0, time.UTC))
+	assert.Error(t, err)
+}
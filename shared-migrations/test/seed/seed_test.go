@@ -0,0 +1,65 @@
+package seed_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fahrizalvianaz/shared-migrations/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixtures struct {
+	Books []struct {
+		Title string `yaml:"title" json:"title"`
+		Price int    `yaml:"price" json:"price"`
+	} `yaml:"books" json:"books"`
+}
+
+func TestParseArgs(t *testing.T) {
+	opts, err := seed.ParseArgs([]string{"--generate", "50"})
+	require.NoError(t, err)
+	assert.Equal(t, 50, opts.Generate)
+
+	opts, err = seed.ParseArgs([]string{"--file", "books.json"})
+	require.NoError(t, err)
+	assert.Equal(t, "books.json", opts.File)
+
+	_, err = seed.ParseArgs([]string{"--generate", "-1"})
+	assert.Error(t, err)
+
+	_, err = seed.ParseArgs([]string{"--unknown"})
+	assert.Error(t, err)
+}
+
+func TestReadFile_YAMLAndJSON(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "books.yaml")
+	jsonPath := filepath.Join(dir, "books.json")
+	require.NoError(t, os.WriteFile(yamlPath, []byte("books:\n  - title: Pulang\n    price: 110000\n"), 0o644))
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{"books":[{"title":"Pulang","price":110000}]}`), 0o644))
+
+	for _, path := range []string{yamlPath, jsonPath} {
+		var out fixtures
+		require.NoError(t, seed.ReadFile(path, &out))
+		require.Len(t, out.Books, 1)
+		assert.Equal(t, "Pulang", out.Books[0].Title)
+		assert.Equal(t, 110000, out.Books[0].Price)
+	}
+
+	var out fixtures
+	assert.Error(t, seed.Decode([]byte("books:\n  - titel: Pulang\n"), false, &out))
+}
+
+func TestGenerators_AreDeterministicAndUnique(t *testing.T) {
+	assert.Equal(t, seed.Title(7), seed.Title(7))
+	assert.Equal(t, seed.Rand(3).Int(), seed.Rand(3).Int())
+
+	names := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		name := seed.PersonName(i)
+		assert.False(t, names[name], "duplicate name %q", name)
+		names[name] = true
+	}
+}
@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+const DefaultSlowThreshold = 200 * time.Millisecond
+
+// GormLogger adapts GORM to slog. Statements are logged at debug level with
+// their bind parameters stripped, slow statements as warnings and failing
+// ones as errors.
+type GormLogger struct {
+	logger        *slog.Logger
+	slowThreshold time.Duration
+}
+
+// SlowThresholdFromEnv reads DB_SLOW_QUERY_THRESHOLD as a duration such as
+// "500ms", falling back to DefaultSlowThreshold.
+func SlowThresholdFromEnv() time.Duration {
+	threshold, err := time.ParseDuration(os.Getenv("DB_SLOW_QUERY_THRESHOLD"))
+	if err != nil {
+		return DefaultSlowThreshold
+	}
+	return threshold
+}
+
+func NewGormLogger(logger *slog.Logger, slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{
+		logger:        logger.With("component", "gorm"),
+		slowThreshold: slowThreshold,
+	}
+}
+
+// LogMode is a no-op, the level is owned by the slog handler.
+func (l *GormLogger) LogMode(gormLogger.LogLevel) gormLogger.Interface {
+	return l
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	l.logger.InfoContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	l.logger.WarnContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	l.logger.ErrorContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		l.logger.ErrorContext(ctx, "query failed", "sql", sql, "rows", rows, "elapsed", elapsed, "error", err)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold:
+		sql, rows := fc()
+		l.logger.WarnContext(ctx, "slow query", "sql", sql, "rows", rows, "elapsed", elapsed, "threshold", l.slowThreshold)
+	case l.logger.Enabled(ctx, slog.LevelDebug):
+		sql, rows := fc()
+		l.logger.DebugContext(ctx, "query", "sql", sql, "rows", rows, "elapsed", elapsed)
+	}
+}
+
+// ParamsFilter keeps bind parameters, which may hold passwords or personal
+// data, out of the logged SQL.
+func (l *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
@@ -0,0 +1,101 @@
+// Package logging sets up structured JSON logging on top of log/slog for the
+// services, together with the request id and access log middleware and a
+// GORM logger adapter.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are attribute keys whose values never reach the logs.
+var sensitiveKeys = []string{"password", "secret", "token", "authorization", "cookie", "dsn"}
+
+// inlineSecret matches key=value secrets embedded in free text such as a
+// connection string.
+var inlineSecret = regexp.MustCompile(`(?i)(password|secret|token)=\S+`)
+
+// Setup installs a JSON logger as the slog and log default. The level comes
+// from LOG_LEVEL (debug, info, warn or error) and defaults to info.
+func Setup(service string) *slog.Logger {
+	logger := New(os.Stdout, service, ParseLevel(os.Getenv("LOG_LEVEL")))
+	slog.SetDefault(logger)
+	return logger
+}
+
+// New builds a redacting JSON logger writing to w.
+func New(w io.Writer, service string, level slog.Level) *slog.Logger {
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redact,
+	})
+	return slog.New(contextHandler{handler}).With("service", service)
+}
+
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func redact(groups []string, attr slog.Attr) slog.Attr {
+	key := strings.ToLower(attr.Key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return slog.String(attr.Key, redacted)
+		}
+	}
+
+	if attr.Value.Kind() == slog.KindString {
+		value := attr.Value.String()
+		if inlineSecret.MatchString(value) {
+			return slog.String(attr.Key, inlineSecret.ReplaceAllString(value, "$1="+redacted))
+		}
+	}
+	return attr
+}
+
+// contextHandler adds the request and trace ids carried by the context to
+// every record logged with one of the *Context methods.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		record.AddAttrs(slog.String("trace_id", spanContext.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// Fatal logs err and exits, replacing log.Fatalf in the service entrypoints.
+func Fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
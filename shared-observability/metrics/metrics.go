@@ -0,0 +1,80 @@
+// Package metrics exposes Prometheus metrics for the services: RED metrics
+// per route, connection pool statistics and the Go runtime collectors that
+// come with the default registry.
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const Namespace = "bookstore"
+
+const unmatchedRoute = "unmatched"
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests handled, by route template and status code.",
+	}, []string{"service", "method", "route", "status"})
+
+	requestErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "http_request_errors_total",
+		Help:      "Number of HTTP requests answered with a 5xx status code.",
+	}, []string{"service", "method", "route"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests, by route template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "method", "route"})
+)
+
+// Middleware records request rate, errors and duration. Routes are labelled
+// with their template, such as /api/v1/books/:id, to keep cardinality low.
+func Middleware(service string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := ctx.Request.Method
+		status := ctx.Writer.Status()
+
+		requestsTotal.WithLabelValues(service, method, route, strconv.Itoa(status)).Inc()
+		requestDuration.WithLabelValues(service, method, route).Observe(time.Since(start).Seconds())
+		if status >= 500 {
+			requestErrorsTotal.WithLabelValues(service, method, route).Inc()
+		}
+	}
+}
+
+// Handler serves the default registry in the Prometheus text format.
+func Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.Handler())
+}
+
+// RegisterDBStats exports the sql.DBStats of the pool. Registering the same
+// database twice is not an error.
+func RegisterDBStats(db *sql.DB, dbName string) error {
+	err := prometheus.Register(collectors.NewDBStatsCollector(db, dbName))
+	var alreadyRegistered prometheus.AlreadyRegisteredError
+	if errors.As(err, &alreadyRegistered) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,105 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fahrizalvianaz/shared-observability/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func useLogger(t *testing.T, level slog.Level) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	previous := slog.Default()
+	slog.SetDefault(logging.New(buf, "test-service", level))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return buf
+}
+
+func TestLogger_RedactsSensitiveValues(t *testing.T) {
+	buf := useLogger(t, slog.LevelInfo)
+
+	slog.Info("connecting", "password", "hunter2", "dsn", "host=db user=app", "note", "host=db password=hunter2 port=5432")
+
+	records := decodeLines(t, buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "[REDACTED]", records[0]["password"])
+	assert.Equal(t, "[REDACTED]", records[0]["dsn"])
+	assert.Equal(t, "host=db password=[REDACTED] port=5432", records[0]["note"])
+	assert.Equal(t, "test-service", records[0]["service"])
+}
+
+func TestRequestID_PropagatesAndLogs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	buf := useLogger(t, slog.LevelInfo)
+
+	router := gin.New()
+	router.Use(logging.RequestID(), logging.Middleware(), logging.Recovery())
+	router.GET("/books/:id", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"requestId": logging.RequestIDFromContext(ctx.Request.Context())})
+	})
+	router.GET("/panic", func(ctx *gin.Context) { panic("boom") })
+
+	req := httptest.NewRequest(http.MethodGet, "/books/1", nil)
+	req.Header.Set(logging.RequestIDHeader, "abc-123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "abc-123", w.Header().Get(logging.RequestIDHeader))
+	assert.Contains(t, w.Body.String(), "abc-123")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	generated := w.Header().Get(logging.RequestIDHeader)
+	assert.Len(t, generated, 32)
+
+	records := decodeLines(t, buf)
+	require.Len(t, records, 3)
+	assert.Equal(t, "abc-123", records[0]["request_id"])
+	assert.Equal(t, "/books/:id", records[0]["route"])
+	assert.Equal(t, "panic recovered", records[1]["msg"])
+	assert.Equal(t, generated, records[2]["request_id"])
+	assert.Equal(t, "ERROR", records[2]["level"])
+}
+
+func TestGormLogger_Levels(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := logging.NewGormLogger(logging.New(buf, "test-service", slog.LevelInfo), 100*time.Millisecond)
+	ctx := context.Background()
+	fc := func() (string, int64) { return "SELECT * FROM books WHERE id = ?", 1 }
+
+	logger.Trace(ctx, time.Now(), fc, nil)
+	logger.Trace(ctx, time.Now().Add(-time.Second), fc, nil)
+
+	records := decodeLines(t, buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "slow query", records[0]["msg"])
+	assert.Equal(t, "WARN", records[0]["level"])
+
+	sql, params := logger.ParamsFilter(ctx, "SELECT ?", "secret")
+	assert.Equal(t, "SELECT ?", sql)
+	assert.Nil(t, params)
+}
@@ -0,0 +1,40 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fahrizalvianaz/shared-observability/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware_RecordsRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(metrics.Middleware("test-service"))
+	router.GET("/metrics", metrics.Handler())
+	router.GET("/books/:id", func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+	router.GET("/fail", func(ctx *gin.Context) {
+		ctx.Status(http.StatusInternalServerError)
+	})
+
+	for _, path := range []string{"/books/1", "/books/2", "/fail", "/nowhere"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+
+	assert.Contains(t, body, `bookstore_http_requests_total{method="GET",route="/books/:id",service="test-service",status="200"} 2`)
+	assert.Contains(t, body, `bookstore_http_requests_total{method="GET",route="unmatched",service="test-service",status="404"} 1`)
+	assert.Contains(t, body, `bookstore_http_request_errors_total{method="GET",route="/fail",service="test-service"} 1`)
+	assert.Contains(t, body, `bookstore_http_request_duration_seconds_count{method="GET",route="/books/:id",service="test-service"} 2`)
+	assert.True(t, strings.Contains(body, "go_goroutines"), "runtime metrics are exported")
+}
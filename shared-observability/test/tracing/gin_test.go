@@ -0,0 +1,103 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fahrizalvianaz/shared-observability/tracing"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setupRouter(t *testing.T) (*gin.Engine, *tracetest.SpanRecorder) {
+	gin.SetMode(gin.TestMode)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		provider.Shutdown(context.Background())
+	})
+
+	router := gin.New()
+	router.Use(tracing.Middleware("test-service")...)
+	router.GET("/books/:id", func(ctx *gin.Context) {
+		if ctx.Param("id") == "problem" {
+			ctx.Header("Content-Type", "application/problem+json")
+			ctx.String(http.StatusNotFound, `{"title":"Not Found","status":404}`)
+			return
+		}
+		if ctx.Param("id") == "missing" {
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "Book not found"})
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"message": "Book found"})
+	})
+	return router, recorder
+}
+
+func TestMiddleware_AddsTraceIDToErrors(t *testing.T) {
+	router, recorder := setupRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/books/missing", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	traceID := w.Header().Get(tracing.TraceIDHeader)
+	require.NotEmpty(t, traceID)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, traceID, body["traceId"])
+	assert.Equal(t, "Book not found", body["message"])
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "/books/:id", spans[0].Name())
+}
+
+func TestMiddleware_AddsTraceIDToProblemDocuments(t *testing.T) {
+	router, _ := setupRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/books/problem", nil))
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, w.Header().Get(tracing.TraceIDHeader), body["traceId"])
+	assert.Equal(t, "Not Found", body["title"])
+}
+
+func TestMiddleware_LeavesSuccessBodyAlone(t *testing.T) {
+	router, _ := setupRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/books/1", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, w.Header().Get(tracing.TraceIDHeader))
+	assert.JSONEq(t, `{"message":"Book found"}`, w.Body.String())
+}
+
+func TestMiddleware_ContinuesIncomingTrace(t *testing.T) {
+	router, _ := setupRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/books/missing", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", w.Header().Get(tracing.TraceIDHeader))
+}
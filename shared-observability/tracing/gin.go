@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+const TraceIDHeader = "X-Trace-Id"
+
+// Middleware starts a server span per request, continuing the caller's trace
+// when a traceparent header is present, and exposes the trace id to clients.
+func Middleware(serviceName string) gin.HandlersChain {
+	return gin.HandlersChain{
+		otelgin.Middleware(serviceName),
+		exposeTraceID,
+	}
+}
+
+// exposeTraceID sets the trace id header on every response and adds a
+// traceId field to JSON error bodies so a failing call can be looked up.
+func exposeTraceID(ctx *gin.Context) {
+	traceID := TraceID(ctx.Request.Context())
+	if traceID == "" {
+		ctx.Next()
+		return
+	}
+
+	ctx.Header(TraceIDHeader, traceID)
+	writer := &errorBodyWriter{ResponseWriter: ctx.Writer, traceID: traceID}
+	ctx.Writer = writer
+	ctx.Next()
+	writer.flush()
+}
+
+type errorBodyWriter struct {
+	gin.ResponseWriter
+	traceID string
+	body    bytes.Buffer
+}
+
+func (w *errorBodyWriter) buffering() bool {
+	contentType := w.Header().Get("Content-Type")
+	return w.Status() >= 400 && (strings.HasPrefix(contentType, "application/json") ||
+		strings.HasPrefix(contentType, "application/problem+json"))
+}
+
+func (w *errorBodyWriter) Write(data []byte) (int, error) {
+	if w.buffering() {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *errorBodyWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *errorBodyWriter) flush() {
+	if w.body.Len() == 0 {
+		return
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(w.body.Bytes(), &payload); err != nil {
+		w.ResponseWriter.Write(w.body.Bytes())
+		return
+	}
+	payload["traceId"] = w.traceID
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		w.ResponseWriter.Write(w.body.Bytes())
+		return
+	}
+	w.ResponseWriter.Write(body)
+}
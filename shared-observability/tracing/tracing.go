@@ -0,0 +1,128 @@
+// Package tracing wires OpenTelemetry into the services: the tracer provider
+// and its exporter, W3C trace context propagation and the instrumentation for
+// gin, GORM, outbound HTTP and gRPC.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+	ExporterOTLP   = "otlp"
+)
+
+const instrumentationName = "github.com/fahrizalvianaz/shared-observability/tracing"
+
+type Config struct {
+	ServiceName string
+	// Exporter is one of none, stdout, file or otlp. The otlp exporter is
+	// configured through the standard OTEL_EXPORTER_OTLP_* variables.
+	Exporter string
+	// FilePath is where the file exporter appends its spans.
+	FilePath string
+}
+
+// LoadConfig reads TRACING_EXPORTER and TRACING_FILE. Tracing stays disabled
+// unless an exporter is chosen.
+func LoadConfig(serviceName string) Config {
+	cfg := Config{
+		ServiceName: serviceName,
+		Exporter:    os.Getenv("TRACING_EXPORTER"),
+		FilePath:    os.Getenv("TRACING_FILE"),
+	}
+	if cfg.Exporter == "" {
+		cfg.Exporter = ExporterNone
+	}
+	if cfg.FilePath == "" {
+		cfg.FilePath = "traces.json"
+	}
+	return cfg
+}
+
+// Setup installs the global tracer provider and propagator. The returned
+// function flushes pending spans and must be called on shutdown.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter, closer, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			if closeErr := closer.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}, nil
+}
+
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch cfg.Exporter {
+	case ExporterNone:
+		return nil, nil, nil
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		return exporter, nil, err
+	case ExporterFile:
+		file, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open trace file: %w", err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		return exporter, file, err
+	case ExporterOTLP:
+		exporter, err := otlptracehttp.New(ctx)
+		return exporter, nil, err
+	default:
+		return nil, nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+}
+
+// TraceID returns the id of the span in ctx, or an empty string when the
+// request is not traced.
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
@@ -0,0 +1,55 @@
+// Package currency lets clients choose the currency prices are shown in.
+package currency
+
+import (
+	"strings"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// Header lists the currencies the client accepts, most preferred first
+	// or weighted with q values.
+	Header = "Accept-Currency"
+	// QueryParam names a single currency and takes precedence over Header.
+	QueryParam = "currency"
+	// ContentHeader reports the currency prices were converted to.
+	ContentHeader = "Content-Currency"
+)
+
+var ErrUnsupported = apperror.Validation("CURRENCY_UNSUPPORTED", "Requested currency is not supported")
+
+// Middleware stores the requested display currency in the request context
+// for money.DisplayFrom. An unsupported ?currency= is rejected; the header
+// is a preference, so when none of its currencies is supported prices are
+// shown as stored.
+func Middleware(rates money.Rates) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Writer.Header().Add("Vary", Header)
+
+		display := ""
+		if requested := strings.ToUpper(strings.TrimSpace(ctx.Query(QueryParam))); requested != "" {
+			if !rates.Supports(requested) {
+				apperror.Respond(ctx, ErrUnsupported)
+				ctx.Abort()
+				return
+			}
+			display = requested
+		} else {
+			for _, requested := range money.ParseAcceptCurrency(ctx.GetHeader(Header)) {
+				if rates.Supports(requested) {
+					display = requested
+					break
+				}
+			}
+		}
+
+		if display != "" {
+			ctx.Header(ContentHeader, display)
+			ctx.Request = ctx.Request.WithContext(money.WithDisplay(ctx.Request.Context(), display))
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,190 @@
+// Package health serves the /healthz and /readyz endpoints. Liveness only
+// reports that the process is serving; readiness runs every registered
+// dependency check and turns false once the service starts draining.
+// Optional dependencies the service can run without only mark it degraded.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const DefaultTimeout = 2 * time.Second
+
+const (
+	StatusOK          = "ok"
+	StatusDegraded    = "degraded"
+	StatusUnavailable = "unavailable"
+	StatusDraining    = "draining"
+)
+
+// Check returns nil when the dependency is usable. It must respect ctx.
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name     string
+	check    Check
+	optional bool
+}
+
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latencyMs"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Uptime string                 `json:"uptime"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+type Checker struct {
+	timeout  time.Duration
+	started  time.Time
+	draining atomic.Bool
+
+	mu     sync.RWMutex
+	checks []namedCheck
+}
+
+// New returns a Checker whose checks each get timeout to finish.
+func New(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Checker{timeout: timeout, started: time.Now()}
+}
+
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// AddOptional registers a dependency the service can serve without, such
+// as a cache. Its failure is reported as degraded and keeps the service
+// ready.
+func (c *Checker) AddOptional(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check, optional: true})
+}
+
+// Drain marks the service as not ready. It is called when graceful shutdown
+// begins so load balancers stop routing new traffic.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+func (c *Checker) Liveness(w http.ResponseWriter, r *http.Request) {
+	writeReport(w, http.StatusOK, Report{Status: StatusOK, Uptime: c.uptime()})
+}
+
+func (c *Checker) Readiness(w http.ResponseWriter, r *http.Request) {
+	report := c.Run(r.Context())
+
+	code := http.StatusOK
+	if report.Status != StatusOK && report.Status != StatusDegraded {
+		code = http.StatusServiceUnavailable
+	}
+	writeReport(w, code, report)
+}
+
+// Run executes all checks concurrently and aggregates their results.
+func (c *Checker) Run(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := append([]namedCheck(nil), c.checks...)
+	c.mu.RUnlock()
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.runCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Uptime: c.uptime(), Checks: make(map[string]CheckResult, len(checks))}
+	for i, check := range checks {
+		report.Checks[check.name] = results[i]
+		switch results[i].Status {
+		case StatusUnavailable:
+			report.Status = StatusUnavailable
+		case StatusDegraded:
+			if report.Status == StatusOK {
+				report.Status = StatusDegraded
+			}
+		}
+	}
+	if c.draining.Load() {
+		report.Status = StatusDraining
+	}
+	return report
+}
+
+func (c *Checker) runCheck(ctx context.Context, check namedCheck) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.check(ctx)
+	result := CheckResult{
+		Status:    StatusOK,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusUnavailable
+		if check.optional {
+			result.Status = StatusDegraded
+		}
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (c *Checker) uptime() string {
+	return time.Since(c.started).Round(time.Second).String()
+}
+
+func writeReport(w http.ResponseWriter, code int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(report)
+}
+
+// Ping checks a database connection pool.
+func Ping(db *sql.DB) Check {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// HTTP checks that url answers with a non-error status.
+func HTTP(client *http.Client, url string) Check {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if res.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("unexpected status %d", res.StatusCode)
+		}
+		return nil
+	}
+}
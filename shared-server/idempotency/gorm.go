@@ -0,0 +1,127 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Table is created by each service's migrations.
+const Table = "idempotency_keys"
+
+type idempotencyKey struct {
+	Scope       string    `gorm:"column:scope;primaryKey"`
+	Key         string    `gorm:"column:key;primaryKey"`
+	Fingerprint string    `gorm:"column:fingerprint"`
+	StatusCode  int       `gorm:"column:status_code"`
+	ContentType string    `gorm:"column:content_type"`
+	Body        []byte    `gorm:"column:body"`
+	Completed   bool      `gorm:"column:completed"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	ExpiresAt   time.Time `gorm:"column:expires_at"`
+}
+
+func (idempotencyKey) TableName() string {
+	return Table
+}
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+func (s *gormStore) Reserve(ctx context.Context, record *Record) (*Record, bool, error) {
+	row := fromRecord(record)
+	var existing idempotencyKey
+	reserved := false
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("scope = ? AND key = ? AND expires_at <= ?", row.Scope, row.Key, row.CreatedAt).
+			Delete(&idempotencyKey{}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 1 {
+			reserved = true
+			return nil
+		}
+		return tx.Where("scope = ? AND key = ?", row.Scope, row.Key).First(&existing).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if reserved {
+		return nil, true, nil
+	}
+	return existing.toRecord(), false, nil
+}
+
+func (s *gormStore) Complete(ctx context.Context, scope, key string, statusCode int, contentType string, body []byte) error {
+	result := s.db.WithContext(ctx).Model(&idempotencyKey{}).
+		Where("scope = ? AND key = ?", scope, key).
+		Updates(map[string]interface{}{
+			"status_code":  statusCode,
+			"content_type": contentType,
+			"body":         body,
+			"completed":    true,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *gormStore) Release(ctx context.Context, scope, key string) error {
+	err := s.db.WithContext(ctx).Where("scope = ? AND key = ?", scope, key).Delete(&idempotencyKey{}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (s *gormStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&idempotencyKey{})
+	return result.RowsAffected, result.Error
+}
+
+func fromRecord(record *Record) idempotencyKey {
+	return idempotencyKey{
+		Scope:       record.Scope,
+		Key:         record.Key,
+		Fingerprint: record.Fingerprint,
+		StatusCode:  record.StatusCode,
+		ContentType: record.ContentType,
+		Body:        record.Body,
+		Completed:   record.Completed,
+		CreatedAt:   record.CreatedAt,
+		ExpiresAt:   record.ExpiresAt,
+	}
+}
+
+func (k idempotencyKey) toRecord() *Record {
+	return &Record{
+		Scope:       k.Scope,
+		Key:         k.Key,
+		Fingerprint: k.Fingerprint,
+		StatusCode:  k.StatusCode,
+		ContentType: k.ContentType,
+		Body:        k.Body,
+		Completed:   k.Completed,
+		CreatedAt:   k.CreatedAt,
+		ExpiresAt:   k.ExpiresAt,
+	}
+}
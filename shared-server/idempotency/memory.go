@@ -0,0 +1,68 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]Record
+}
+
+// NewMemoryStore keeps records in process memory. It suits tests and single
+// instance deployments only.
+func NewMemoryStore() Store {
+	return &memoryStore{records: make(map[string]Record)}
+}
+
+func (s *memoryStore) Reserve(ctx context.Context, record *Record) (*Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := record.Scope + "\x00" + record.Key
+	if existing, ok := s.records[id]; ok && existing.ExpiresAt.After(record.CreatedAt) {
+		return &existing, false, nil
+	}
+	s.records[id] = *record
+	return nil, true, nil
+}
+
+func (s *memoryStore) Complete(ctx context.Context, scope, key string, statusCode int, contentType string, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := scope + "\x00" + key
+	record, ok := s.records[id]
+	if !ok {
+		return ErrNotFound
+	}
+	record.StatusCode = statusCode
+	record.ContentType = contentType
+	record.Body = append([]byte(nil), body...)
+	record.Completed = true
+	s.records[id] = record
+	return nil
+}
+
+func (s *memoryStore) Release(ctx context.Context, scope, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, scope+"\x00"+key)
+	return nil
+}
+
+func (s *memoryStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for id, record := range s.records {
+		if !record.ExpiresAt.After(now) {
+			delete(s.records, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
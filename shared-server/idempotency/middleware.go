@@ -0,0 +1,199 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// Header carries the client supplied key.
+	Header = "Idempotency-Key"
+	// ReplayedHeader is set on responses served from the store.
+	ReplayedHeader = "Idempotent-Replayed"
+
+	DefaultTTL = 24 * time.Hour
+	// MaxKeyLength bounds the key so it fits the primary key comfortably.
+	MaxKeyLength = 255
+)
+
+var (
+	ErrKeyTooLong    = apperror.Validation("IDEMPOTENCY_KEY_INVALID", fmt.Sprintf("Idempotency-Key must be at most %d characters", MaxKeyLength))
+	ErrKeyReused     = apperror.Validation("IDEMPOTENCY_KEY_REUSED", "Idempotency-Key was already used with a different request")
+	ErrKeyInProgress = apperror.Conflict("IDEMPOTENCY_REQUEST_IN_PROGRESS", "A request with this Idempotency-Key is still being processed")
+	ErrKeyAnonymous  = apperror.Validation("IDEMPOTENCY_KEY_ANONYMOUS", "Idempotency-Key is only accepted on requests that identify their client")
+)
+
+// ClientFunc names the client behind an anonymous request from its body,
+// such as the account a registration is for. It reports false when the
+// request does not say.
+type ClientFunc func(ctx *gin.Context, body []byte) (string, bool)
+
+// TTLFromEnv reads IDEMPOTENCY_TTL, falling back to DefaultTTL.
+func TTLFromEnv() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("IDEMPOTENCY_TTL"))
+	if err != nil || ttl <= 0 {
+		return DefaultTTL
+	}
+	return ttl
+}
+
+// Middleware makes the route safe to retry. Requests without an
+// Idempotency-Key pass through untouched. The first request with a key is
+// executed and its response stored for ttl; repeats with the same body get
+// that response back, a different body is rejected with 422 and a repeat
+// that races the original gets 409. Server errors are not stored so the
+// client can retry them.
+//
+// Keys are scoped by method, route and the authenticated user, so place the
+// middleware after authentication. A key on an anonymous request is
+// rejected, since unrelated clients would share it.
+func Middleware(store Store, ttl time.Duration) gin.HandlerFunc {
+	return AnonymousMiddleware(store, ttl, nil)
+}
+
+// AnonymousMiddleware is Middleware for routes open to anonymous requests.
+// Their keys are scoped by the client that client names instead of a user;
+// a key on a request it cannot place is rejected.
+func AnonymousMiddleware(store Store, ttl time.Duration, client ClientFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(Header)
+		if key == "" {
+			ctx.Next()
+			return
+		}
+		if len(key) > MaxKeyLength {
+			apperror.Respond(ctx, ErrKeyTooLong)
+			ctx.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			apperror.Respond(ctx, apperror.BindError(err))
+			ctx.Abort()
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope, ok := scope(ctx, body, client)
+		if !ok {
+			apperror.Respond(ctx, ErrKeyAnonymous)
+			ctx.Abort()
+			return
+		}
+
+		now := time.Now()
+		record := &Record{
+			Scope:       scope,
+			Key:         key,
+			Fingerprint: fingerprint(body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(ttl),
+		}
+
+		existing, reserved, err := store.Reserve(ctx.Request.Context(), record)
+		if err != nil {
+			apperror.Respond(ctx, apperror.Internal(err))
+			ctx.Abort()
+			return
+		}
+		if !reserved {
+			replay(ctx, record, existing)
+			return
+		}
+
+		// A panicking handler never reaches the code below, so free the key
+		// for a retry before the recovery middleware swallows the panic.
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				releaseCtx := context.WithoutCancel(ctx.Request.Context())
+				if err := store.Release(releaseCtx, record.Scope, record.Key); err != nil {
+					slog.ErrorContext(releaseCtx, "idempotency key not released", "key", record.Key, "error", err)
+				}
+				panic(recovered)
+			}
+		}()
+
+		writer := &recordingWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+		ctx.Next()
+
+		// The request context may already be cancelled once the client
+		// has gone, but the outcome still has to be recorded.
+		storeCtx := context.WithoutCancel(ctx.Request.Context())
+		if ctx.Writer.Status() >= http.StatusInternalServerError {
+			err = store.Release(storeCtx, record.Scope, record.Key)
+		} else {
+			err = store.Complete(storeCtx, record.Scope, record.Key, ctx.Writer.Status(), ctx.Writer.Header().Get("Content-Type"), writer.body.Bytes())
+		}
+		if err != nil {
+			slog.ErrorContext(storeCtx, "idempotency record not saved", "key", record.Key, "error", err)
+		}
+	}
+}
+
+func replay(ctx *gin.Context, record, existing *Record) {
+	switch {
+	case existing.Fingerprint != record.Fingerprint:
+		apperror.Respond(ctx, ErrKeyReused)
+	case !existing.Completed:
+		apperror.Respond(ctx, ErrKeyInProgress)
+	default:
+		ctx.Header(ReplayedHeader, "true")
+		ctx.Data(existing.StatusCode, existing.ContentType, existing.Body)
+	}
+	ctx.Abort()
+}
+
+// scope is where the key of the request is unique: its route and the user
+// or, failing that, the named client. It reports false for a request from
+// nobody in particular.
+func scope(ctx *gin.Context, body []byte, client ClientFunc) (string, bool) {
+	route := ctx.FullPath()
+	if route == "" {
+		route = ctx.Request.URL.Path
+	}
+	if userID, ok := ctx.Get("userID"); ok {
+		return fmt.Sprintf("%s %s user:%v", ctx.Request.Method, route, userID), true
+	}
+	if client == nil {
+		return "", false
+	}
+	name, ok := client(ctx, body)
+	if !ok || name == "" {
+		return "", false
+	}
+	return fmt.Sprintf("%s %s client:%s", ctx.Request.Method, route, name), true
+}
+
+func fingerprint(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// recordingWriter keeps a copy of the response body for the store.
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
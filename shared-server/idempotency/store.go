@@ -0,0 +1,38 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by Store.Complete when the key is no longer
+// reserved.
+var ErrNotFound = errors.New("idempotency record not found")
+
+// Record is one idempotency key. A record is in progress until Complete
+// stores the response that repeats replay.
+type Record struct {
+	Scope       string
+	Key         string
+	Fingerprint string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	Completed   bool
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+type Store interface {
+	// Reserve inserts record unless a live record with the same scope and
+	// key exists, in which case that record is returned and ok is false.
+	// Expired records are replaced.
+	Reserve(ctx context.Context, record *Record) (existing *Record, ok bool, err error)
+	// Complete stores the response for a reserved key.
+	Complete(ctx context.Context, scope, key string, statusCode int, contentType string, body []byte) error
+	// Release removes a reserved key so the request can be retried.
+	Release(ctx context.Context, scope, key string) error
+	// DeleteExpired removes records that expired before now.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
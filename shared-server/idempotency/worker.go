@@ -0,0 +1,37 @@
+package idempotency
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/fahrizalvianaz/shared-server/server"
+)
+
+// DefaultCleanupInterval is how often CleanupWorker purges expired keys.
+const DefaultCleanupInterval = time.Hour
+
+// CleanupWorker deletes expired records every interval until the runner
+// shuts down.
+func CleanupWorker(store Store, interval time.Duration) server.Worker {
+	return func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case now := <-ticker.C:
+				deleted, err := store.DeleteExpired(ctx, now)
+				if err != nil {
+					slog.ErrorContext(ctx, "idempotency cleanup failed", "error", err)
+					continue
+				}
+				if deleted > 0 {
+					slog.DebugContext(ctx, "idempotency keys expired", "deleted", deleted)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Package roles guards the routes reserved for administrators, such as
+// catalogue changes. Tokens carry no role, so the administrators are the
+// user ids a deployment lists in ADMIN_USER_IDS.
+package roles
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"github.com/gin-gonic/gin"
+)
+
+var ErrAdminOnly = apperror.Forbidden("ADMIN_ONLY", "Only administrators may do this")
+
+// Admins is the set of administrator user ids.
+type Admins map[uint]bool
+
+// AdminsFromEnv reads ADMIN_USER_IDS. Without it nobody is an
+// administrator and admin routes refuse every request.
+func AdminsFromEnv() (Admins, error) {
+	return ParseAdmins(os.Getenv("ADMIN_USER_IDS"))
+}
+
+// ParseAdmins reads a comma separated list of user ids.
+func ParseAdmins(list string) (Admins, error) {
+	admins := Admins{}
+	for _, field := range strings.Split(list, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(field, 10, 64)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("invalid admin user id %q", field)
+		}
+		admins[uint(id)] = true
+	}
+	return admins, nil
+}
+
+// RequireAdmin lets only administrators through. It reads the user JWTAuth
+// authenticated, so it goes after it.
+func RequireAdmin(admins Admins) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, ok := ctx.Get("userID")
+		if !ok {
+			apperror.Respond(ctx, apperror.Unauthorized(apperror.CodeUnauthorized, "User not found"))
+			ctx.Abort()
+			return
+		}
+		if id, ok := userID.(uint); !ok || !admins[id] {
+			apperror.Respond(ctx, ErrAdminOnly)
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config holds the HTTP listener settings shared by the services.
+type Config struct {
+	Addr              string
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	ShutdownTimeout   time.Duration
+	// DrainDelay keeps serving after readiness has flipped so load
+	// balancers notice before connections are closed.
+	DrainDelay time.Duration
+}
+
+// LoadConfig reads the HTTP_* and SHUTDOWN_TIMEOUT variables, keeping the
+// defaults for anything unset or malformed.
+func LoadConfig() Config {
+	return Config{
+		Addr:              envString("HTTP_ADDR", ":8080"),
+		ReadTimeout:       envDuration("HTTP_READ_TIMEOUT", 15*time.Second),
+		ReadHeaderTimeout: envDuration("HTTP_READ_HEADER_TIMEOUT", 5*time.Second),
+		WriteTimeout:      envDuration("HTTP_WRITE_TIMEOUT", 30*time.Second),
+		IdleTimeout:       envDuration("HTTP_IDLE_TIMEOUT", 60*time.Second),
+		MaxHeaderBytes:    envInt("HTTP_MAX_HEADER_BYTES", 1<<20),
+		ShutdownTimeout:   envDuration("SHUTDOWN_TIMEOUT", 20*time.Second),
+		DrainDelay:        envDuration("SHUTDOWN_DRAIN_DELAY", 0),
+	}
+}
+
+func envString(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func envInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
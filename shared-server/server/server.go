@@ -0,0 +1,158 @@
+// Package server runs a service's HTTP server together with its background
+// workers and shuts everything down in order on SIGINT or SIGTERM.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Worker runs until ctx is cancelled. Returning early with an error stops
+// the whole runner.
+type Worker func(ctx context.Context) error
+
+// Closer releases a resource once the server and workers have stopped.
+type Closer func(ctx context.Context) error
+
+type namedWorker struct {
+	name string
+	run  Worker
+}
+
+type namedCloser struct {
+	name  string
+	close Closer
+}
+
+type Runner struct {
+	cfg        Config
+	httpServer *http.Server
+	workers    []namedWorker
+	closers    []namedCloser
+	drainHooks []func()
+}
+
+func New(cfg Config, handler http.Handler) *Runner {
+	return &Runner{
+		cfg: cfg,
+		httpServer: &http.Server{
+			Addr:              cfg.Addr,
+			Handler:           handler,
+			ReadTimeout:       cfg.ReadTimeout,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+			MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		},
+	}
+}
+
+// AddWorker registers a background task started alongside the HTTP server.
+// Workers are cancelled after the HTTP server has drained.
+func (r *Runner) AddWorker(name string, worker Worker) {
+	r.workers = append(r.workers, namedWorker{name: name, run: worker})
+}
+
+// OnDrain registers a hook run as soon as shutdown starts, before the HTTP
+// server stops accepting requests. It is used to fail readiness checks.
+func (r *Runner) OnDrain(hook func()) {
+	r.drainHooks = append(r.drainHooks, hook)
+}
+
+// OnShutdown registers a closer. Closers run after the workers have
+// returned, in reverse registration order, so a database registered first
+// is closed last.
+func (r *Runner) OnShutdown(name string, closer Closer) {
+	r.closers = append(r.closers, namedCloser{name: name, close: closer})
+}
+
+// Run listens on the configured address and blocks until a signal arrives,
+// ctx is cancelled or a worker fails.
+func (r *Runner) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", r.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", r.cfg.Addr, err)
+	}
+	return r.Serve(ctx, listener)
+}
+
+// Serve is Run on an existing listener.
+func (r *Runner) Serve(ctx context.Context, listener net.Listener) error {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	workerCtx, cancelWorkers := context.WithCancel(context.Background())
+	defer cancelWorkers()
+
+	failures := make(chan error, len(r.workers)+1)
+	var wg sync.WaitGroup
+	for _, worker := range r.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := worker.run(workerCtx); err != nil && workerCtx.Err() == nil {
+				failures <- fmt.Errorf("worker %s: %w", worker.name, err)
+			}
+		}()
+	}
+
+	go func() {
+		if err := r.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			failures <- fmt.Errorf("http server: %w", err)
+		}
+	}()
+	slog.Info("server started", "addr", listener.Addr().String())
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		slog.Info("shutdown requested")
+	case runErr = <-failures:
+		slog.Error("server failed, shutting down", "error", runErr)
+	}
+
+	for _, hook := range r.drainHooks {
+		hook()
+	}
+	if r.cfg.DrainDelay > 0 {
+		time.Sleep(r.cfg.DrainDelay)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), r.cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := r.httpServer.Shutdown(shutdownCtx); err != nil {
+		slog.Error("http server did not drain in time", "error", err)
+		r.httpServer.Close()
+	}
+
+	cancelWorkers()
+	workersDone := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(workersDone)
+	}()
+	select {
+	case <-workersDone:
+	case <-shutdownCtx.Done():
+		slog.Error("workers did not stop in time")
+	}
+
+	for i := len(r.closers) - 1; i >= 0; i-- {
+		closer := r.closers[i]
+		if err := closer.close(shutdownCtx); err != nil {
+			slog.Error("shutdown step failed", "step", closer.name, "error", err)
+		}
+	}
+
+	slog.Info("server stopped")
+	return runErr
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"context"
+	"net"
+)
+
+// GracefulServer is satisfied by *grpc.Server.
+type GracefulServer interface {
+	Serve(listener net.Listener) error
+	GracefulStop()
+}
+
+// ServeWorker serves srv on listener until the runner shuts down, then
+// stops it gracefully so in-flight calls can finish.
+func ServeWorker(srv GracefulServer, listener net.Listener) Worker {
+	return func(ctx context.Context) error {
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			<-ctx.Done()
+			srv.GracefulStop()
+		}()
+
+		err := srv.Serve(listener)
+		if ctx.Err() != nil {
+			<-stopped
+			return nil
+		}
+		return err
+	}
+}
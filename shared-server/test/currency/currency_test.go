@@ -0,0 +1,53 @@
+package currency_test
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-server/currency"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(target, accept string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	rates := money.NewTable("USD", map[string]*big.Rat{"IDR": big.NewRat(16000, 1), "EUR": big.NewRat(1, 2)})
+
+	router := gin.New()
+	router.GET("/books", currency.Middleware(rates), func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, money.DisplayFrom(ctx.Request.Context()))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if accept != "" {
+		req.Header.Set(currency.Header, accept)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware_PicksDisplayCurrency(t *testing.T) {
+	w := serve("/books", "GBP, eur;q=0.9, USD;q=0.5")
+	assert.Equal(t, "EUR", w.Body.String())
+	assert.Equal(t, "EUR", w.Header().Get(currency.ContentHeader))
+	assert.Equal(t, currency.Header, w.Header().Get("Vary"))
+
+	w = serve("/books?currency=usd", "EUR")
+	assert.Equal(t, "USD", w.Body.String(), "query param wins over the header")
+
+	w = serve("/books", "GBP")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "", w.Body.String())
+	assert.Empty(t, w.Header().Get(currency.ContentHeader))
+}
+
+func TestMiddleware_RejectsUnsupportedQuery(t *testing.T) {
+	w := serve("/books?currency=GBP", "")
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "CURRENCY_UNSUPPORTED")
+}
@@ -0,0 +1,86 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fahrizalvianaz/shared-server/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readiness(t *testing.T, checker *health.Checker) (int, health.Report) {
+	w := httptest.NewRecorder()
+	checker.Readiness(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var report health.Report
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	return w.Code, report
+}
+
+func TestReadiness_AllChecksPass(t *testing.T) {
+	checker := health.New(time.Second)
+	checker.Add("database", func(ctx context.Context) error { return nil })
+
+	code, report := readiness(t, checker)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, health.StatusOK, report.Status)
+	assert.Equal(t, health.StatusOK, report.Checks["database"].Status)
+}
+
+func TestReadiness_FailingAndSlowChecks(t *testing.T) {
+	checker := health.New(50 * time.Millisecond)
+	checker.Add("database", func(ctx context.Context) error { return nil })
+	checker.Add("migrations", func(ctx context.Context) error { return errors.New("2 pending migrations") })
+	checker.Add("book-service", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	code, report := readiness(t, checker)
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, health.StatusUnavailable, report.Status)
+	assert.Equal(t, health.StatusOK, report.Checks["database"].Status)
+	assert.Equal(t, "2 pending migrations", report.Checks["migrations"].Error)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["book-service"].Error)
+	assert.GreaterOrEqual(t, report.Checks["book-service"].LatencyMs, float64(50))
+}
+
+func TestReadiness_OptionalCheckDegrades(t *testing.T) {
+	checker := health.New(time.Second)
+	checker.Add("database", func(ctx context.Context) error { return nil })
+	checker.AddOptional("cache", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	code, report := readiness(t, checker)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, health.StatusDegraded, report.Status)
+	assert.Equal(t, health.StatusDegraded, report.Checks["cache"].Status)
+	assert.Equal(t, "connection refused", report.Checks["cache"].Error)
+
+	checker.Add("migrations", func(ctx context.Context) error { return errors.New("2 pending migrations") })
+	code, report = readiness(t, checker)
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, health.StatusUnavailable, report.Status)
+}
+
+func TestReadiness_DrainingAndLiveness(t *testing.T) {
+	checker := health.New(time.Second)
+	checker.Drain()
+
+	code, report := readiness(t, checker)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, health.StatusDraining, report.Status)
+
+	w := httptest.NewRecorder()
+	checker.Liveness(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,226 @@
+package idempotency_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fahrizalvianaz/shared-server/idempotency"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signedIn stands in for JWTAuth.
+func signedIn(userID uint) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set("userID", userID)
+	}
+}
+
+func newRouter(store idempotency.Store, calls *int32, status int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/books/add", signedIn(1), idempotency.Middleware(store, time.Hour), func(ctx *gin.Context) {
+		n := atomic.AddInt32(calls, 1)
+		ctx.JSON(status, gin.H{"call": n})
+	})
+	return router
+}
+
+func post(router http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/books/add", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(idempotency.Header, key)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware_ReplaysStoredResponse(t *testing.T) {
+	var calls int32
+	router := newRouter(idempotency.NewMemoryStore(), &calls, http.StatusCreated)
+
+	first := post(router, "key-1", `{"title":"Dune"}`)
+	second := post(router, "key-1", `{"title":"Dune"}`)
+
+	assert.Equal(t, int32(1), calls)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "true", second.Header().Get(idempotency.ReplayedHeader))
+	assert.Empty(t, first.Header().Get(idempotency.ReplayedHeader))
+}
+
+func TestMiddleware_RejectsDifferentBody(t *testing.T) {
+	var calls int32
+	router := newRouter(idempotency.NewMemoryStore(), &calls, http.StatusCreated)
+
+	post(router, "key-1", `{"title":"Dune"}`)
+	w := post(router, "key-1", `{"title":"Emma"}`)
+
+	assert.Equal(t, int32(1), calls)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "IDEMPOTENCY_KEY_REUSED")
+}
+
+func TestMiddleware_RequestInProgress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	router := gin.New()
+	router.POST("/books/add", signedIn(1), idempotency.Middleware(idempotency.NewMemoryStore(), time.Hour), func(ctx *gin.Context) {
+		close(started)
+		<-release
+		ctx.JSON(http.StatusCreated, gin.H{})
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- post(router, "key-1", `{}`) }()
+	<-started
+
+	w := post(router, "key-1", `{}`)
+	close(release)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "IDEMPOTENCY_REQUEST_IN_PROGRESS")
+	assert.Equal(t, http.StatusCreated, (<-done).Code)
+}
+
+func TestMiddleware_ServerErrorsAreNotStored(t *testing.T) {
+	var calls int32
+	router := newRouter(idempotency.NewMemoryStore(), &calls, http.StatusInternalServerError)
+
+	post(router, "key-1", `{}`)
+	w := post(router, "key-1", `{}`)
+
+	assert.Equal(t, int32(2), calls)
+	assert.Empty(t, w.Header().Get(idempotency.ReplayedHeader))
+}
+
+func TestMiddleware_WithoutKey(t *testing.T) {
+	var calls int32
+	router := newRouter(idempotency.NewMemoryStore(), &calls, http.StatusCreated)
+
+	post(router, "", `{}`)
+	post(router, "", `{}`)
+
+	assert.Equal(t, int32(2), calls)
+}
+
+func TestMiddleware_KeyTooLong(t *testing.T) {
+	var calls int32
+	router := newRouter(idempotency.NewMemoryStore(), &calls, http.StatusCreated)
+
+	w := post(router, strings.Repeat("k", idempotency.MaxKeyLength+1), `{}`)
+
+	assert.Equal(t, int32(0), calls)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestMemoryStore_ExpiredRecords(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+	ctx := context.Background()
+	past := time.Now().Add(-2 * time.Hour)
+	_, _, err := store.Reserve(ctx, &idempotency.Record{Scope: "s", Key: "k", CreatedAt: past, ExpiresAt: past.Add(time.Hour)})
+	require.NoError(t, err)
+
+	_, ok, err := store.Reserve(ctx, &idempotency.Record{Scope: "s", Key: "k", CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	assert.True(t, ok, "expired key is reusable")
+
+	deleted, err := store.DeleteExpired(ctx, time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
+
+func TestMiddleware_ReleasesKeyOnPanic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var calls int32
+	router := gin.New()
+	router.Use(gin.CustomRecovery(func(ctx *gin.Context, _ any) {
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	router.POST("/books/add", signedIn(1), idempotency.Middleware(idempotency.NewMemoryStore(), time.Hour), func(ctx *gin.Context) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			panic("boom")
+		}
+		ctx.JSON(http.StatusCreated, gin.H{})
+	})
+
+	first := post(router, "key-1", `{}`)
+	second := post(router, "key-1", `{}`)
+
+	assert.Equal(t, http.StatusInternalServerError, first.Code)
+	assert.Equal(t, int32(2), calls)
+	assert.Equal(t, http.StatusCreated, second.Code)
+}
+
+func TestMiddleware_ScopesKeysByUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var calls int32
+	store := idempotency.NewMemoryStore()
+	router := gin.New()
+	router.POST("/books/add", func(ctx *gin.Context) {
+		ctx.Set("userID", ctx.GetHeader("X-User"))
+	}, idempotency.Middleware(store, time.Hour), func(ctx *gin.Context) {
+		ctx.JSON(http.StatusCreated, gin.H{"call": atomic.AddInt32(&calls, 1)})
+	})
+
+	for _, user := range []string{"1", "2"} {
+		req := httptest.NewRequest(http.MethodPost, "/books/add", strings.NewReader(`{}`))
+		req.Header.Set(idempotency.Header, "key-1")
+		req.Header.Set("X-User", user)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Empty(t, w.Header().Get(idempotency.ReplayedHeader))
+	}
+	assert.Equal(t, int32(2), calls)
+}
+
+func TestMiddleware_RejectsAnonymousKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var calls int32
+	router := gin.New()
+	router.POST("/books/add", idempotency.Middleware(idempotency.NewMemoryStore(), time.Hour), func(ctx *gin.Context) {
+		atomic.AddInt32(&calls, 1)
+		ctx.JSON(http.StatusCreated, gin.H{})
+	})
+
+	w := post(router, "key-1", `{}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "IDEMPOTENCY_KEY_ANONYMOUS")
+
+	w = post(router, "", `{}`)
+	assert.Equal(t, http.StatusCreated, w.Code, "requests without a key still go through")
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestAnonymousMiddleware_ScopesKeysByClient(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var calls int32
+	byTitle := func(ctx *gin.Context, body []byte) (string, bool) {
+		title := strings.TrimSuffix(strings.TrimPrefix(string(body), `{"title":"`), `"}`)
+		return title, title != string(body)
+	}
+	router := gin.New()
+	router.POST("/books/add", idempotency.AnonymousMiddleware(idempotency.NewMemoryStore(), time.Hour, byTitle), func(ctx *gin.Context) {
+		ctx.JSON(http.StatusCreated, gin.H{"call": atomic.AddInt32(&calls, 1)})
+	})
+
+	post(router, "key-1", `{"title":"Dune"}`)
+	second := post(router, "key-1", `{"title":"Dune"}`)
+	other := post(router, "key-1", `{"title":"Emma"}`)
+	unnamed := post(router, "key-1", `{}`)
+
+	assert.Equal(t, "true", second.Header().Get(idempotency.ReplayedHeader))
+	assert.Equal(t, http.StatusCreated, other.Code, "another client reuses the key without a clash")
+	assert.Empty(t, other.Header().Get(idempotency.ReplayedHeader))
+	assert.Equal(t, http.StatusUnprocessableEntity, unnamed.Code)
+	assert.Equal(t, int32(2), calls)
+}
@@ -0,0 +1,60 @@
+package roles_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fahrizalvianaz/shared-server/roles"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAdmins(t *testing.T) {
+	admins, err := roles.ParseAdmins(" 1, 7,,")
+	require.NoError(t, err)
+	assert.Equal(t, roles.Admins{1: true, 7: true}, admins)
+
+	admins, err = roles.ParseAdmins("")
+	require.NoError(t, err)
+	assert.Empty(t, admins)
+
+	_, err = roles.ParseAdmins("1,admin")
+	assert.Error(t, err)
+	_, err = roles.ParseAdmins("0")
+	assert.Error(t, err)
+}
+
+func TestRequireAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name   string
+		userID interface{}
+		status int
+	}{
+		{"admin", uint(1), http.StatusOK},
+		{"normal user", uint(2), http.StatusForbidden},
+		{"anonymous", nil, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/categories", func(ctx *gin.Context) {
+				if tt.userID != nil {
+					ctx.Set("userID", tt.userID)
+				}
+			}, roles.RequireAdmin(roles.Admins{1: true}), func(ctx *gin.Context) {
+				ctx.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/categories", nil))
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusForbidden {
+				assert.Contains(t, w.Body.String(), "ADMIN_ONLY")
+			}
+		})
+	}
+}
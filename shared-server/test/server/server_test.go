@@ -0,0 +1,97 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fahrizalvianaz/shared-server/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() server.Config {
+	return server.Config{ShutdownTimeout: 2 * time.Second}
+}
+
+func TestRunner_DrainsInFlightRequests(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		io.WriteString(w, "done")
+	})
+
+	var mu sync.Mutex
+	var order []string
+	record := func(step string) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, step)
+	}
+
+	runner := server.New(testConfig(), handler)
+	runner.AddWorker("ticker", func(ctx context.Context) error {
+		<-ctx.Done()
+		record("worker")
+		return nil
+	})
+	runner.OnDrain(func() { record("drain") })
+	runner.OnShutdown("database", func(ctx context.Context) error {
+		record("database")
+		return nil
+	})
+	runner.OnShutdown("grpc", func(ctx context.Context) error {
+		record("grpc")
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- runner.Serve(ctx, listener) }()
+
+	bodyCh := make(chan string, 1)
+	go func() {
+		res, err := http.Get("http://" + listener.Addr().String())
+		if err != nil {
+			bodyCh <- err.Error()
+			return
+		}
+		defer res.Body.Close()
+		body, _ := io.ReadAll(res.Body)
+		bodyCh <- string(body)
+	}()
+
+	<-started
+	cancel()
+
+	assert.Equal(t, "done", <-bodyCh)
+	assert.NoError(t, <-runErr)
+	assert.Equal(t, []string{"drain", "worker", "grpc", "database"}, order)
+}
+
+func TestRunner_StopsWhenWorkerFails(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	closed := false
+	boom := errors.New("boom")
+	runner := server.New(testConfig(), http.NotFoundHandler())
+	runner.AddWorker("broken", func(ctx context.Context) error { return boom })
+	runner.OnShutdown("database", func(ctx context.Context) error {
+		closed = true
+		return nil
+	})
+
+	err = runner.Serve(context.Background(), listener)
+	assert.ErrorIs(t, err, boom)
+	assert.True(t, closed)
+}
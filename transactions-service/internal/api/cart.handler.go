@@ -0,0 +1,142 @@
+package api
+
+import (
+	"strconv"
+	"transactions-service/internal"
+	"transactions-service/internal/api/dto"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	genericResponse "github.com/fahrizalvianaz/shared-response/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+// CartTokenHeader carries the token of a guest cart both ways.
+const CartTokenHeader = "X-Cart-Token"
+
+type CartHandler struct {
+	cartService        internal.CartService
+	transactionService internal.TransactionService
+}
+
+func NewCartHandler(cartService internal.CartService, transactionService internal.TransactionService) *CartHandler {
+	return &CartHandler{
+		cartService:        cartService,
+		transactionService: transactionService,
+	}
+}
+
+func (c *CartHandler) Find(ctx *gin.Context) {
+	response, err := c.cartService.Find(ctx.Request.Context(), cartOwner(ctx))
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	respondCart(ctx, "Cart found", response)
+}
+
+func (c *CartHandler) AddItem(ctx *gin.Context) {
+	var req dto.CartItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	response, err := c.cartService.AddItem(ctx.Request.Context(), cartOwner(ctx), req)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	respondCart(ctx, "Book added to cart", response)
+}
+
+func (c *CartHandler) UpdateItem(ctx *gin.Context) {
+	bookID, err := strconv.ParseUint(ctx.Param("bookId"), 10, 64)
+	if err != nil {
+		apperror.Respond(ctx, apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	var req dto.CartQuantityRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	response, err := c.cartService.UpdateItem(ctx.Request.Context(), cartOwner(ctx), uint(bookID), req)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	respondCart(ctx, "Cart updated", response)
+}
+
+func (c *CartHandler) RemoveItem(ctx *gin.Context) {
+	bookID, err := strconv.ParseUint(ctx.Param("bookId"), 10, 64)
+	if err != nil {
+		apperror.Respond(ctx, apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	response, err := c.cartService.RemoveItem(ctx.Request.Context(), cartOwner(ctx), uint(bookID))
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	respondCart(ctx, "Book removed from cart", response)
+}
+
+func (c *CartHandler) Clear(ctx *gin.Context) {
+	response, err := c.cartService.Clear(ctx.Request.Context(), cartOwner(ctx))
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	respondCart(ctx, "Cart cleared", response)
+}
+
+// Checkout needs a signed-in user; guests keep their cart until then.
+func (c *CartHandler) Checkout(ctx *gin.Context) {
+	owner := cartOwner(ctx)
+	if owner.UserID == 0 {
+		apperror.Respond(ctx, apperror.Unauthorized(apperror.CodeUnauthorized, "Sign in to check out"))
+		return
+	}
+
+	var req dto.CheckoutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	response, err := c.transactionService.Checkout(ctx.Request.Context(), owner, req)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.CreatedResponse(ctx, "Checkout successfully", response)
+}
+
+// cartOwner reads the signed-in user, if any, and the guest cart token, if
+// one was sent.
+func cartOwner(ctx *gin.Context) internal.CartOwner {
+	owner := internal.CartOwner{Token: ctx.GetHeader(CartTokenHeader)}
+	if userID, exist := ctx.Get("userID"); exist {
+		owner.UserID = userID.(uint)
+	}
+	return owner
+}
+
+// respondCart hands a guest the token of their cart in a header as well as
+// in the body.
+func respondCart(ctx *gin.Context, message string, response *dto.CartResponse) {
+	if response.Token != "" {
+		ctx.Header(CartTokenHeader, response.Token)
+	}
+	genericResponse.OkResponse(ctx, message, response)
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"transactions-service/internal"
+	"transactions-service/internal/client"
+
+	"github.com/fahrizalvianaz/shared-contracts/bookpb"
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-contracts/userpb"
+	"github.com/fahrizalvianaz/shared-middleware/middleware"
+	"github.com/fahrizalvianaz/shared-server/currency"
+	"github.com/fahrizalvianaz/shared-server/idempotency"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+func CartRoutes(router *gin.RouterGroup, db *gorm.DB, bookConn, userConn grpc.ClientConnInterface, rates money.Rates) {
+	cartRepository := internal.NewCartRepository(db)
+	bookClient := client.NewBookClient(bookpb.NewBookServiceClient(bookConn))
+	userClient := client.NewUserClient(userpb.NewUserServiceClient(userConn))
+	promotionService := internal.NewPromotionService(internal.NewPromotionRepository(db), bookClient, rates)
+	transactionService := internal.NewTransactionService(internal.NewTransactionRepository(db), cartRepository, promotionService, bookClient, userClient, rates)
+	cartHandler := NewCartHandler(internal.NewCartService(cartRepository, bookClient, rates), transactionService)
+
+	router.Use(optionalAuth(), forwardToken(), currency.Middleware(rates))
+	router.GET("/items", cartHandler.Find)
+	router.POST("/items", cartHandler.AddItem)
+	router.DELETE("/items", cartHandler.Clear)
+	router.PATCH("/items/:bookId", cartHandler.UpdateItem)
+	router.DELETE("/items/:bookId", cartHandler.RemoveItem)
+	router.POST("/checkout", idempotency.Middleware(idempotency.NewGormStore(db), idempotency.TTLFromEnv()), cartHandler.Checkout)
+}
+
+// optionalAuth authenticates requests that carry a token and lets the rest
+// through as guests. A token that is sent still has to be valid.
+func optionalAuth() gin.HandlerFunc {
+	auth := middleware.JWTAuth()
+	return func(ctx *gin.Context) {
+		if ctx.GetHeader("Authorization") == "" {
+			ctx.Next()
+			return
+		}
+		auth(ctx)
+	}
+}
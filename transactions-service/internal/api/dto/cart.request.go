@@ -0,0 +1,9 @@
+package dto
+
+type CartQuantityRequest struct {
+	Quantity int `json:"quantity" binding:"required,min=1,max=100" example:"2"`
+}
+
+type CheckoutRequest struct {
+	Coupon string `json:"coupon" binding:"omitempty,max=32" example:"WEEKEND10"`
+}
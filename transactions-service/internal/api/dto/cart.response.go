@@ -0,0 +1,34 @@
+package dto
+
+import "github.com/fahrizalvianaz/shared-contracts/money"
+
+// CartItemResponse shows a cart item against the book as it is now.
+// UnitPrice is the current price and AddedPrice, set only when it differs,
+// the one the customer last saw. Problem, when set, says why the item
+// would stop a checkout.
+type CartItemResponse struct {
+	BookID     uint         `json:"bookId"`
+	Title      string       `json:"title,omitempty"`
+	Quantity   int          `json:"quantity"`
+	UnitPrice  money.Money  `json:"unitPrice"`
+	AddedPrice *money.Money `json:"addedPrice,omitempty"`
+	Total      money.Money  `json:"total"`
+	Stock      int          `json:"stock"`
+	Problem    string       `json:"problem,omitempty"`
+}
+
+// CartResponse gives prices in the display currency the client asked for.
+// Token is set for guest carts and has to be sent back to reach the cart
+// again. Subtotal is left out when the items are priced in different
+// currencies.
+type CartResponse struct {
+	Token    string             `json:"token,omitempty"`
+	Items    []CartItemResponse `json:"items"`
+	Subtotal *money.Money       `json:"subtotal,omitempty"`
+}
+
+// CheckoutResponse holds one transaction per book of the cart.
+type CheckoutResponse struct {
+	Transactions []TransactionResponse `json:"transactions"`
+	Total        money.Money           `json:"total"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+type BorrowRequest struct {
+	BookID uint `json:"bookId" binding:"required,min=1" example:"1"`
+}
+
+// LoanListRequest filters loans by status; without one it lists the books
+// still out.
+type LoanListRequest struct {
+	Page   int    `form:"page" binding:"omitempty,min=1" example:"1"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100" example:"10"`
+	Status string `form:"status" binding:"omitempty,oneof=active overdue returned" example:"active"`
+}
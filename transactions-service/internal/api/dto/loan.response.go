@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+)
+
+// LoanResponse gives the fine in the display currency the client asked
+// for. For a loan still out it is what the loan owes so far.
+type LoanResponse struct {
+	ID           uint        `json:"id"`
+	BookID       uint        `json:"bookId"`
+	Status       string      `json:"status"`
+	BorrowedAt   time.Time   `json:"borrowedAt"`
+	DueAt        time.Time   `json:"dueAt"`
+	ReturnedAt   *time.Time  `json:"returnedAt,omitempty"`
+	Renewals     int         `json:"renewals"`
+	RenewalsLeft int         `json:"renewalsLeft"`
+	Fine         money.Money `json:"fine"`
+	FinePaidAt   *time.Time  `json:"finePaidAt,omitempty"`
+}
+
+type LoanListResponse struct {
+	Loans []LoanResponse `json:"loans"`
+	Page  int            `json:"page"`
+	Limit int            `json:"limit"`
+	Total int64          `json:"total"`
+}
+
+// FinesResponse lists the loans with a fine outstanding. Total is left out
+// when the fines are in different currencies.
+type FinesResponse struct {
+	Fines []LoanResponse `json:"fines"`
+	Total *money.Money   `json:"total,omitempty"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+// MoneyRequest is an amount in minor units. The currency defaults to IDR.
+type MoneyRequest struct {
+	Amount   int64  `json:"amount" binding:"min=1,max=100000000000" example:"500000"`
+	Currency string `json:"currency" binding:"omitempty,iso4217" example:"IDR"`
+}
+
+// PromotionRequest defines a promotion. Percentage promotions need
+// percentOff, fixed ones amountOff and buy_x_get_y ones buyQuantity and
+// getQuantity. A code makes it a coupon; without one it applies to every
+// qualifying cart.
+type PromotionRequest struct {
+	Name           string        `json:"name" binding:"required,notblank,max=255" example:"Weekend sale"`
+	Kind           string        `json:"kind" binding:"required,oneof=percentage fixed buy_x_get_y" example:"percentage"`
+	Code           string        `json:"code" binding:"omitempty,alphanum,min=3,max=32" example:"WEEKEND10"`
+	PercentOff     int           `json:"percentOff" binding:"omitempty,min=1,max=100" example:"10"`
+	AmountOff      *MoneyRequest `json:"amountOff"`
+	BuyQuantity    int           `json:"buyQuantity" binding:"omitempty,min=1,max=100"`
+	GetQuantity    int           `json:"getQuantity" binding:"omitempty,min=1,max=100"`
+	BookID         *uint         `json:"bookId" binding:"omitempty,min=1"`
+	MinSubtotal    *MoneyRequest `json:"minSubtotal"`
+	Priority       int           `json:"priority" binding:"min=-1000,max=1000"`
+	StartsAt       *time.Time    `json:"startsAt"`
+	EndsAt         *time.Time    `json:"endsAt"`
+	MaxRedemptions *int          `json:"maxRedemptions" binding:"omitempty,min=1"`
+	MaxPerUser     *int          `json:"maxPerUser" binding:"omitempty,min=1"`
+}
+
+type PromotionListRequest struct {
+	Page   int   `form:"page" binding:"omitempty,min=1" example:"1"`
+	Limit  int   `form:"limit" binding:"omitempty,min=1,max=100" example:"10"`
+	Active *bool `form:"active"`
+}
+
+type CartItemRequest struct {
+	BookID   uint `json:"bookId" binding:"required,min=1" example:"1"`
+	Quantity int  `json:"quantity" binding:"required,min=1,max=100" example:"1"`
+}
+
+// QuoteRequest asks what a cart would cost with the promotions running now.
+type QuoteRequest struct {
+	Items  []CartItemRequest `json:"items" binding:"required,min=1,max=50,dive"`
+	Coupon string            `json:"coupon" binding:"omitempty,max=32" example:"WEEKEND10"`
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+)
+
+type PromotionResponse struct {
+	ID             uint         `json:"id"`
+	Name           string       `json:"name"`
+	Kind           string       `json:"kind"`
+	Code           *string      `json:"code,omitempty"`
+	PercentOff     int          `json:"percentOff,omitempty"`
+	AmountOff      *money.Money `json:"amountOff,omitempty"`
+	BuyQuantity    int          `json:"buyQuantity,omitempty"`
+	GetQuantity    int          `json:"getQuantity,omitempty"`
+	BookID         *uint        `json:"bookId,omitempty"`
+	MinSubtotal    *money.Money `json:"minSubtotal,omitempty"`
+	Priority       int          `json:"priority"`
+	StartsAt       time.Time    `json:"startsAt"`
+	EndsAt         *time.Time   `json:"endsAt,omitempty"`
+	MaxRedemptions *int         `json:"maxRedemptions,omitempty"`
+	MaxPerUser     *int         `json:"maxPerUser,omitempty"`
+	Redemptions    int          `json:"redemptions"`
+	Active         bool         `json:"active"`
+	CreatedAt      time.Time    `json:"createdAt"`
+}
+
+type PromotionListResponse struct {
+	Promotions []PromotionResponse `json:"promotions"`
+	Page       int                 `json:"page"`
+	Limit      int                 `json:"limit"`
+	Total      int64               `json:"total"`
+}
+
+// AppliedPromotionResponse is a promotion and what it took off.
+type AppliedPromotionResponse struct {
+	ID       uint        `json:"id"`
+	Name     string      `json:"name"`
+	Code     *string     `json:"code,omitempty"`
+	Discount money.Money `json:"discount"`
+}
+
+type QuoteItemResponse struct {
+	BookID    uint        `json:"bookId"`
+	Quantity  int         `json:"quantity"`
+	UnitPrice money.Money `json:"unitPrice"`
+	Discount  money.Money `json:"discount"`
+	Total     money.Money `json:"total"`
+}
+
+// QuoteResponse prices a cart in the display currency the client asked
+// for; BaseTotal is then the total that would be charged.
+type QuoteResponse struct {
+	Items      []QuoteItemResponse        `json:"items"`
+	Promotions []AppliedPromotionResponse `json:"promotions"`
+	Subtotal   money.Money                `json:"subtotal"`
+	Discount   money.Money                `json:"discount"`
+	Total      money.Money                `json:"total"`
+	BaseTotal  *money.Money               `json:"baseTotal,omitempty"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+type PurchaseRequest struct {
+	BookID   uint   `json:"bookId" binding:"required,min=1" example:"1"`
+	Quantity int    `json:"quantity" binding:"omitempty,min=1,max=100" example:"1"`
+	Coupon   string `json:"coupon" binding:"omitempty,max=32" example:"WEEKEND10"`
+}
+
+type ListRequest struct {
+	Page  int `form:"page" binding:"omitempty,min=1" example:"1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100" example:"10"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+)
+
+// TransactionResponse gives prices in the display currency the client
+// asked for; BaseTotal is then the total as charged.
+type TransactionResponse struct {
+	ID         uint                       `json:"id"`
+	BookID     uint                       `json:"bookId"`
+	UserID     uint                       `json:"userId"`
+	Quantity   int                        `json:"quantity"`
+	UnitPrice  money.Money                `json:"unitPrice"`
+	Discount   money.Money                `json:"discount"`
+	Total      money.Money                `json:"total"`
+	BaseTotal  *money.Money               `json:"baseTotal,omitempty"`
+	Promotions []AppliedPromotionResponse `json:"promotions,omitempty"`
+	CreatedAt  time.Time                  `json:"createdAt"`
+}
+
+type ListResponse struct {
+	Transactions []TransactionResponse `json:"transactions"`
+	Page         int                   `json:"page"`
+	Limit        int                   `json:"limit"`
+	Total        int64                 `json:"total"`
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"strconv"
+	"transactions-service/internal"
+	"transactions-service/internal/api/dto"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	genericResponse "github.com/fahrizalvianaz/shared-response/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+type LoanHandler struct {
+	loanService internal.LoanService
+}
+
+func NewLoanHandler(loanService internal.LoanService) *LoanHandler {
+	return &LoanHandler{
+		loanService: loanService,
+	}
+}
+
+func (l *LoanHandler) Borrow(ctx *gin.Context) {
+	userID, exist := ctx.Get("userID")
+	if !exist {
+		apperror.Respond(ctx, apperror.Unauthorized(apperror.CodeUnauthorized, "User not found"))
+		return
+	}
+
+	var req dto.BorrowRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	response, err := l.loanService.Borrow(ctx.Request.Context(), userID.(uint), req)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.CreatedResponse(ctx, "Book borrowed", response)
+}
+
+func (l *LoanHandler) FindAll(ctx *gin.Context) {
+	userID, exist := ctx.Get("userID")
+	if !exist {
+		apperror.Respond(ctx, apperror.Unauthorized(apperror.CodeUnauthorized, "User not found"))
+		return
+	}
+
+	var req dto.LoanListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	response, err := l.loanService.FindByUserID(ctx.Request.Context(), userID.(uint), req)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Loans found", response)
+}
+
+func (l *LoanHandler) Fines(ctx *gin.Context) {
+	userID, exist := ctx.Get("userID")
+	if !exist {
+		apperror.Respond(ctx, apperror.Unauthorized(apperror.CodeUnauthorized, "User not found"))
+		return
+	}
+
+	response, err := l.loanService.Fines(ctx.Request.Context(), userID.(uint))
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Fines found", response)
+}
+
+func (l *LoanHandler) Renew(ctx *gin.Context) {
+	l.act(ctx, l.loanService.Renew, "Loan renewed")
+}
+
+func (l *LoanHandler) Return(ctx *gin.Context) {
+	l.act(ctx, l.loanService.Return, "Book returned")
+}
+
+func (l *LoanHandler) PayFine(ctx *gin.Context) {
+	l.act(ctx, l.loanService.PayFine, "Fine paid")
+}
+
+// act runs an action on the loan in the path for the signed-in user.
+func (l *LoanHandler) act(ctx *gin.Context, action func(ctx context.Context, userID, id uint) (*dto.LoanResponse, error), message string) {
+	userID, exist := ctx.Get("userID")
+	if !exist {
+		apperror.Respond(ctx, apperror.Unauthorized(apperror.CodeUnauthorized, "User not found"))
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apperror.Respond(ctx, apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	response, err := action(ctx.Request.Context(), userID.(uint), uint(id))
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, message, response)
+}
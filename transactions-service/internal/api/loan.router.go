@@ -0,0 +1,31 @@
+package api
+
+import (
+	"transactions-service/internal"
+	"transactions-service/internal/client"
+
+	"github.com/fahrizalvianaz/shared-contracts/bookpb"
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-contracts/userpb"
+	"github.com/fahrizalvianaz/shared-middleware/middleware"
+	"github.com/fahrizalvianaz/shared-server/currency"
+	"github.com/fahrizalvianaz/shared-server/idempotency"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+func LoanRoutes(router *gin.RouterGroup, db *gorm.DB, bookConn, userConn grpc.ClientConnInterface, rates money.Rates) {
+	bookClient := client.NewBookClient(bookpb.NewBookServiceClient(bookConn))
+	userClient := client.NewUserClient(userpb.NewUserServiceClient(userConn))
+	loanService := internal.NewLoanService(internal.NewLoanRepository(db), bookClient, userClient, rates, internal.LoanPolicyFromEnv())
+	loanHandler := NewLoanHandler(loanService)
+
+	router.Use(middleware.JWTAuth(), forwardToken(), currency.Middleware(rates))
+	router.GET("", loanHandler.FindAll)
+	router.POST("", idempotency.Middleware(idempotency.NewGormStore(db), idempotency.TTLFromEnv()), loanHandler.Borrow)
+	router.GET("/fines", loanHandler.Fines)
+	router.POST("/:id/renew", loanHandler.Renew)
+	router.POST("/:id/return", loanHandler.Return)
+	router.POST("/:id/fine/payment", loanHandler.PayFine)
+}
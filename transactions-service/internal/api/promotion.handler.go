@@ -0,0 +1,108 @@
+package api
+
+import (
+	"strconv"
+	"transactions-service/internal"
+	"transactions-service/internal/api/dto"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	genericResponse "github.com/fahrizalvianaz/shared-response/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+type PromotionHandler struct {
+	promotionService internal.PromotionService
+}
+
+func NewPromotionHandler(promotionService internal.PromotionService) *PromotionHandler {
+	return &PromotionHandler{
+		promotionService: promotionService,
+	}
+}
+
+func (p *PromotionHandler) Create(ctx *gin.Context) {
+	var req dto.PromotionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	response, err := p.promotionService.Create(ctx.Request.Context(), req)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.CreatedResponse(ctx, "Promotion created", response)
+}
+
+func (p *PromotionHandler) FindAll(ctx *gin.Context) {
+	var req dto.PromotionListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	response, err := p.promotionService.FindAll(ctx.Request.Context(), req)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Promotions found", response)
+}
+
+func (p *PromotionHandler) FindByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apperror.Respond(ctx, apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	response, err := p.promotionService.FindByID(ctx.Request.Context(), uint(id))
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Promotion found", response)
+}
+
+func (p *PromotionHandler) Deactivate(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		apperror.Respond(ctx, apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	if err := p.promotionService.Deactivate(ctx.Request.Context(), uint(id)); err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Promotion deactivated", nil)
+}
+
+// Quote prices a cart with the promotions the user could redeem now,
+// without buying anything.
+func (p *PromotionHandler) Quote(ctx *gin.Context) {
+	userID, exist := ctx.Get("userID")
+	if !exist {
+		apperror.Respond(ctx, apperror.Unauthorized(apperror.CodeUnauthorized, "User not found"))
+		return
+	}
+
+	var req dto.QuoteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	response, err := p.promotionService.Quote(ctx.Request.Context(), userID.(uint), req)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Cart priced", response)
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"transactions-service/internal"
+	"transactions-service/internal/client"
+
+	"github.com/fahrizalvianaz/shared-contracts/bookpb"
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-middleware/middleware"
+	"github.com/fahrizalvianaz/shared-server/currency"
+	"github.com/fahrizalvianaz/shared-server/roles"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+func PromotionRoutes(router *gin.RouterGroup, db *gorm.DB, bookConn grpc.ClientConnInterface, rates money.Rates, admins roles.Admins) {
+	bookClient := client.NewBookClient(bookpb.NewBookServiceClient(bookConn))
+	promotionService := internal.NewPromotionService(internal.NewPromotionRepository(db), bookClient, rates)
+	promotionHandler := NewPromotionHandler(promotionService)
+
+	router.Use(middleware.JWTAuth(), forwardToken(), currency.Middleware(rates))
+
+	// Shoppers quote and look up codes; managing promotions is for
+	// administrators.
+	admin := router.Group("", roles.RequireAdmin(admins))
+
+	admin.GET("", promotionHandler.FindAll)
+	admin.POST("", promotionHandler.Create)
+	router.POST("/quote", promotionHandler.Quote)
+	router.GET("/:id", promotionHandler.FindByID)
+	admin.DELETE("/:id", promotionHandler.Deactivate)
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"transactions-service/internal"
+	"transactions-service/internal/api/dto"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	genericResponse "github.com/fahrizalvianaz/shared-response/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+type TransactionHandler struct {
+	transactionService internal.TransactionService
+}
+
+func NewTransactionHandler(transactionService internal.TransactionService) *TransactionHandler {
+	return &TransactionHandler{
+		transactionService: transactionService,
+	}
+}
+
+func (t *TransactionHandler) Purchase(ctx *gin.Context) {
+	userID, exist := ctx.Get("userID")
+	if !exist {
+		apperror.Respond(ctx, apperror.Unauthorized(apperror.CodeUnauthorized, "User not found"))
+		return
+	}
+
+	var req dto.PurchaseRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	response, err := t.transactionService.Purchase(ctx.Request.Context(), userID.(uint), req)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.CreatedResponse(ctx, "Purchase successfully", response)
+}
+
+func (t *TransactionHandler) FindAll(ctx *gin.Context) {
+	userID, exist := ctx.Get("userID")
+	if !exist {
+		apperror.Respond(ctx, apperror.Unauthorized(apperror.CodeUnauthorized, "User not found"))
+		return
+	}
+
+	var req dto.ListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		apperror.Respond(ctx, apperror.BindError(err))
+		return
+	}
+
+	response, err := t.transactionService.FindByUserID(ctx.Request.Context(), userID.(uint), req)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Transactions found", response)
+}
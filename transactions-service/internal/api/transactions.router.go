@@ -0,0 +1,41 @@
+package api
+
+import (
+	"strings"
+	"transactions-service/internal"
+	"transactions-service/internal/client"
+
+	"github.com/fahrizalvianaz/shared-contracts/bookpb"
+	"github.com/fahrizalvianaz/shared-contracts/interceptor"
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-contracts/userpb"
+	"github.com/fahrizalvianaz/shared-middleware/middleware"
+	"github.com/fahrizalvianaz/shared-server/currency"
+	"github.com/fahrizalvianaz/shared-server/idempotency"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+func TransactionRoutes(router *gin.RouterGroup, db *gorm.DB, bookConn, userConn grpc.ClientConnInterface, rates money.Rates) {
+	transactionRepository := internal.NewTransactionRepository(db)
+	bookClient := client.NewBookClient(bookpb.NewBookServiceClient(bookConn))
+	userClient := client.NewUserClient(userpb.NewUserServiceClient(userConn))
+	promotionService := internal.NewPromotionService(internal.NewPromotionRepository(db), bookClient, rates)
+	transactionService := internal.NewTransactionService(transactionRepository, internal.NewCartRepository(db), promotionService, bookClient, userClient, rates)
+	transactionHandler := NewTransactionHandler(transactionService)
+
+	router.Use(middleware.JWTAuth(), forwardToken(), currency.Middleware(rates))
+	router.GET("", transactionHandler.FindAll)
+	router.POST("/purchase", idempotency.Middleware(idempotency.NewGormStore(db), idempotency.TTLFromEnv()), transactionHandler.Purchase)
+}
+
+// forwardToken keeps the already validated bearer token on the request
+// context so internal gRPC calls are made on behalf of the same user.
+func forwardToken() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token := strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		ctx.Request = ctx.Request.WithContext(interceptor.WithToken(ctx.Request.Context(), token))
+		ctx.Next()
+	}
+}
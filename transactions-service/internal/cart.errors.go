@@ -0,0 +1,44 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCartItemNotFound = apperror.NotFound("CART_ITEM_NOT_FOUND", "Book is not in the cart")
+	ErrCartEmpty        = apperror.Validation("CART_EMPTY", "Cart is empty")
+	ErrCartFull         = apperror.Validation("CART_FULL", fmt.Sprintf("Cart cannot hold more than %d books", MaxCartItems))
+	ErrCartQuantity     = apperror.Validation("CART_QUANTITY_EXCEEDED", fmt.Sprintf("Cart cannot hold more than %d copies of a book", MaxCartQuantity))
+	ErrCartChanged      = apperror.Conflict("CART_CHANGED", "Books in the cart changed price or availability; review the cart and check out again")
+
+	// ErrOutOfStock matches book-service's code.
+	ErrOutOfStock = apperror.Conflict("INSUFFICIENT_STOCK", "Not enough stock for this book")
+)
+
+// Codes of the fields ErrCartChanged lists, one per cart item that needs
+// another look.
+const (
+	CartItemUnavailable = "unavailable"
+	CartItemOutOfStock  = "insufficient_stock"
+	CartItemRepriced    = "price_changed"
+)
+
+var cartProblemMessages = map[string]string{
+	CartItemUnavailable: "Book is no longer available",
+	CartItemOutOfStock:  "Not enough stock for the quantity in the cart",
+	CartItemRepriced:    "Price changed since the book was added",
+}
+
+// cartError translates repository errors into domain errors.
+func cartError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrCartItemNotFound.Wrap(err)
+	default:
+		return apperror.Internal(err)
+	}
+}
@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+)
+
+const (
+	// MaxCartItems and MaxCartQuantity match what a quote accepts, so any
+	// cart can be priced.
+	MaxCartItems    = 50
+	MaxCartQuantity = 100
+)
+
+// Cart holds the books a customer means to buy. It belongs either to a
+// user or, before they sign in, to a guest who holds its Token.
+type Cart struct {
+	ID         uint       `gorm:"primaryKey"`
+	UserID     *uint      `gorm:"column:user_id;uniqueIndex"`
+	Token      *string    `gorm:"column:token;uniqueIndex"`
+	Items      []CartItem `gorm:"foreignKey:CartID"`
+	CreatedAt  time.Time  `gorm:"column:created_at;autoCreateTime"`
+	ModifiedAt time.Time  `gorm:"column:modified_at;autoUpdateTime"`
+}
+
+func (Cart) TableName() string {
+	return "carts"
+}
+
+// CartItem is one book in a cart. UnitPrice is the price the customer last
+// saw, in minor units of Currency, so a change since can be pointed out
+// before checkout.
+type CartItem struct {
+	ID         uint      `gorm:"primaryKey"`
+	CartID     uint      `gorm:"column:cart_id;not null;uniqueIndex:idx_cart_items_cart_book"`
+	BookID     uint      `gorm:"column:book_id;not null;uniqueIndex:idx_cart_items_cart_book"`
+	Quantity   int       `gorm:"column:quantity;not null"`
+	UnitPrice  int64     `gorm:"column:unit_price;not null"`
+	Currency   string    `gorm:"column:currency;not null"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
+	ModifiedAt time.Time `gorm:"column:modified_at;autoUpdateTime"`
+}
+
+func (CartItem) TableName() string {
+	return "cart_items"
+}
+
+func (c *CartItem) UnitMoney() money.Money {
+	return money.New(c.UnitPrice, c.Currency)
+}
@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CartRepository interface {
+	FindByToken(ctx context.Context, token string) (*Cart, error)
+	FindOrCreateByUser(ctx context.Context, userID uint) (*Cart, error)
+	Create(ctx context.Context, cart *Cart) (*Cart, error)
+	SetItem(ctx context.Context, item CartItem) error
+	RemoveItem(ctx context.Context, cartID, bookID uint) error
+	Clear(ctx context.Context, cartID uint) error
+	Merge(ctx context.Context, guestID, cartID uint) error
+	DeleteIdleGuests(ctx context.Context, before time.Time) (int64, error)
+}
+
+type cartRepository struct {
+	db *gorm.DB
+}
+
+func NewCartRepository(db *gorm.DB) CartRepository {
+	return &cartRepository{
+		db: db,
+	}
+}
+
+func (c *cartRepository) FindByToken(ctx context.Context, token string) (*Cart, error) {
+	var cart Cart
+	err := c.db.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Where("token = ?", token).
+		First(&cart).Error
+	if err != nil {
+		return nil, err
+	}
+	return &cart, nil
+}
+
+// FindOrCreateByUser returns the cart of userID, creating an empty one the
+// first time. Concurrent first requests end up with the same cart.
+func (c *cartRepository) FindOrCreateByUser(ctx context.Context, userID uint) (*Cart, error) {
+	db := c.db.WithContext(ctx)
+	err := db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "user_id"}}, DoNothing: true}).
+		Create(&Cart{UserID: &userID}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var cart Cart
+	err = db.Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Where("user_id = ?", userID).
+		First(&cart).Error
+	if err != nil {
+		return nil, err
+	}
+	return &cart, nil
+}
+
+func (c *cartRepository) Create(ctx context.Context, cart *Cart) (*Cart, error) {
+	if err := c.db.WithContext(ctx).Create(cart).Error; err != nil {
+		return nil, err
+	}
+	return cart, nil
+}
+
+// SetItem puts item in its cart, replacing the quantity and price of the
+// same book if it is already there.
+func (c *cartRepository) SetItem(ctx context.Context, item CartItem) error {
+	return c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "cart_id"}, {Name: "book_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"quantity", "unit_price", "currency", "modified_at"}),
+		}).Create(&item).Error
+		if err != nil {
+			return err
+		}
+		return touch(tx, item.CartID)
+	})
+}
+
+func (c *cartRepository) RemoveItem(ctx context.Context, cartID, bookID uint) error {
+	return c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("cart_id = ? AND book_id = ?", cartID, bookID).Delete(&CartItem{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return touch(tx, cartID)
+	})
+}
+
+func (c *cartRepository) Clear(ctx context.Context, cartID uint) error {
+	return c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("cart_id = ?", cartID).Delete(&CartItem{}).Error; err != nil {
+			return err
+		}
+		return touch(tx, cartID)
+	})
+}
+
+// Merge moves the items of the guest cart into cart and deletes the guest
+// cart. A book in both ends up with the quantities added together, up to
+// MaxCartQuantity, and keeps the price cart already had for it.
+func (c *cartRepository) Merge(ctx context.Context, guestID, cartID uint) error {
+	return c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			INSERT INTO cart_items (cart_id, book_id, quantity, unit_price, currency, created_at, modified_at)
+			SELECT ?, book_id, quantity, unit_price, currency, created_at, ?
+			FROM cart_items WHERE cart_id = ?
+			ON CONFLICT (cart_id, book_id) DO UPDATE SET
+				quantity = LEAST(cart_items.quantity + EXCLUDED.quantity, ?),
+				modified_at = EXCLUDED.modified_at`,
+			cartID, time.Now(), guestID, MaxCartQuantity).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&Cart{}, guestID).Error; err != nil {
+			return err
+		}
+		return touch(tx, cartID)
+	})
+}
+
+// DeleteIdleGuests removes guest carts left untouched since before, with
+// their items.
+func (c *cartRepository) DeleteIdleGuests(ctx context.Context, before time.Time) (int64, error) {
+	result := c.db.WithContext(ctx).Where("user_id IS NULL AND modified_at < ?", before).Delete(&Cart{})
+	return result.RowsAffected, result.Error
+}
+
+// touch marks a cart as changed, which keeps a guest cart in use from
+// being cleaned up.
+func touch(tx *gorm.DB, cartID uint) error {
+	return tx.Model(&Cart{}).Where("id = ?", cartID).Update("modified_at", time.Now()).Error
+}
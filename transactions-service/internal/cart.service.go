@@ -0,0 +1,345 @@
+package internal
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"os"
+	"slices"
+	"time"
+	"transactions-service/internal/api/dto"
+	"transactions-service/internal/client"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultCartGuestTTL        = 30 * 24 * time.Hour
+	DefaultCartCleanupInterval = time.Hour
+)
+
+// CartGuestTTLFromEnv reads CART_GUEST_TTL, falling back to
+// DefaultCartGuestTTL.
+func CartGuestTTLFromEnv() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("CART_GUEST_TTL"))
+	if err != nil || ttl <= 0 {
+		return DefaultCartGuestTTL
+	}
+	return ttl
+}
+
+// CartOwner says whose cart a request works on: a signed-in user, a guest
+// holding a cart token, or both when a guest has just signed in.
+type CartOwner struct {
+	UserID uint
+	Token  string
+}
+
+type CartService interface {
+	Find(ctx context.Context, owner CartOwner) (*dto.CartResponse, error)
+	AddItem(ctx context.Context, owner CartOwner, request dto.CartItemRequest) (*dto.CartResponse, error)
+	UpdateItem(ctx context.Context, owner CartOwner, bookID uint, request dto.CartQuantityRequest) (*dto.CartResponse, error)
+	RemoveItem(ctx context.Context, owner CartOwner, bookID uint) (*dto.CartResponse, error)
+	Clear(ctx context.Context, owner CartOwner) (*dto.CartResponse, error)
+}
+
+type cartService struct {
+	cartRepository CartRepository
+	bookClient     client.BookClient
+	rates          money.Rates
+}
+
+func NewCartService(cartRepository CartRepository, bookClient client.BookClient, rates money.Rates) CartService {
+	return &cartService{
+		cartRepository: cartRepository,
+		bookClient:     bookClient,
+		rates:          rates,
+	}
+}
+
+// Find shows the cart checked against the books as they are now.
+func (c *cartService) Find(ctx context.Context, owner CartOwner) (*dto.CartResponse, error) {
+	cart, err := openCart(ctx, c.cartRepository, owner, false)
+	if err != nil {
+		return nil, err
+	}
+	return c.respond(ctx, cart)
+}
+
+// AddItem puts quantity more copies of a book in the cart.
+func (c *cartService) AddItem(ctx context.Context, owner CartOwner, request dto.CartItemRequest) (*dto.CartResponse, error) {
+	cart, err := openCart(ctx, c.cartRepository, owner, true)
+	if err != nil {
+		return nil, err
+	}
+
+	quantity := request.Quantity
+	if index := cart.itemIndex(request.BookID); index >= 0 {
+		quantity += cart.Items[index].Quantity
+	} else if len(cart.Items) >= MaxCartItems {
+		return nil, ErrCartFull
+	}
+	if quantity > MaxCartQuantity {
+		return nil, ErrCartQuantity
+	}
+	return c.setItem(ctx, cart, request.BookID, quantity)
+}
+
+func (c *cartService) UpdateItem(ctx context.Context, owner CartOwner, bookID uint, request dto.CartQuantityRequest) (*dto.CartResponse, error) {
+	cart, err := openCart(ctx, c.cartRepository, owner, false)
+	if err != nil {
+		return nil, err
+	}
+	if cart.itemIndex(bookID) < 0 {
+		return nil, ErrCartItemNotFound
+	}
+	return c.setItem(ctx, cart, bookID, request.Quantity)
+}
+
+func (c *cartService) RemoveItem(ctx context.Context, owner CartOwner, bookID uint) (*dto.CartResponse, error) {
+	cart, err := openCart(ctx, c.cartRepository, owner, false)
+	if err != nil {
+		return nil, err
+	}
+	index := cart.itemIndex(bookID)
+	if index < 0 {
+		return nil, ErrCartItemNotFound
+	}
+	if err := c.cartRepository.RemoveItem(ctx, cart.ID, bookID); err != nil {
+		return nil, cartError(err)
+	}
+	cart.Items = slices.Delete(cart.Items, index, index+1)
+	return c.respond(ctx, cart)
+}
+
+func (c *cartService) Clear(ctx context.Context, owner CartOwner) (*dto.CartResponse, error) {
+	cart, err := openCart(ctx, c.cartRepository, owner, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(cart.Items) > 0 {
+		if err := c.cartRepository.Clear(ctx, cart.ID); err != nil {
+			return nil, cartError(err)
+		}
+		cart.Items = nil
+	}
+	return c.respond(ctx, cart)
+}
+
+// setItem checks that quantity copies of the book can be bought and stores
+// them at the current price, which the customer sees in the response.
+func (c *cartService) setItem(ctx context.Context, cart *Cart, bookID uint, quantity int) (*dto.CartResponse, error) {
+	books, err := c.bookClient.FindByIDs(ctx, []uint{bookID})
+	if err != nil {
+		return nil, err
+	}
+	if len(books) == 0 {
+		return nil, ErrBookNotFound
+	}
+	if books[0].Stock < quantity {
+		return nil, ErrOutOfStock
+	}
+
+	item := CartItem{
+		CartID:    cart.ID,
+		BookID:    bookID,
+		Quantity:  quantity,
+		UnitPrice: books[0].Price.Amount,
+		Currency:  books[0].Price.Currency,
+	}
+	if err := c.cartRepository.SetItem(ctx, item); err != nil {
+		return nil, cartError(err)
+	}
+	if index := cart.itemIndex(bookID); index >= 0 {
+		cart.Items[index] = item
+	} else {
+		cart.Items = append(cart.Items, item)
+	}
+	return c.respond(ctx, cart)
+}
+
+func (c *cartService) respond(ctx context.Context, cart *Cart) (*dto.CartResponse, error) {
+	books, err := findCartBooks(ctx, c.bookClient, cart.Items)
+	if err != nil {
+		return nil, err
+	}
+	return toCartResponse(ctx, c.rates, cart, books), nil
+}
+
+func (c *Cart) itemIndex(bookID uint) int {
+	return slices.IndexFunc(c.Items, func(item CartItem) bool { return item.BookID == bookID })
+}
+
+// openCart finds the cart of owner. A signed-in user always has one. A
+// guest gets one, under a new token, only when create is set and otherwise
+// sees an empty cart. A guest cart sent along by a signed-in user is merged
+// into theirs, which is how a guest's cart carries over to their account.
+func openCart(ctx context.Context, cartRepository CartRepository, owner CartOwner, create bool) (*Cart, error) {
+	var guest *Cart
+	if owner.Token != "" {
+		cart, err := cartRepository.FindByToken(ctx, owner.Token)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			// Cleaned up, or merged by an earlier request.
+		case err != nil:
+			return nil, apperror.Internal(err)
+		default:
+			guest = cart
+		}
+	}
+
+	if owner.UserID == 0 {
+		if guest != nil {
+			return guest, nil
+		}
+		if !create {
+			return &Cart{}, nil
+		}
+		token, err := newCartToken()
+		if err != nil {
+			return nil, apperror.Internal(err)
+		}
+		cart, err := cartRepository.Create(ctx, &Cart{Token: &token})
+		if err != nil {
+			return nil, apperror.Internal(err)
+		}
+		return cart, nil
+	}
+
+	cart, err := cartRepository.FindOrCreateByUser(ctx, owner.UserID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if guest == nil {
+		return cart, nil
+	}
+	if err := cartRepository.Merge(ctx, guest.ID, cart.ID); err != nil {
+		return nil, apperror.Internal(err)
+	}
+	cartsMergedTotal.Inc()
+	cart, err = cartRepository.FindOrCreateByUser(ctx, owner.UserID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return cart, nil
+}
+
+// newCartToken returns a random token that is hard enough to guess to be
+// the only key to a guest cart.
+func newCartToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// findCartBooks looks up the books of items, keyed by id. Books that no
+// longer exist are missing from the map.
+func findCartBooks(ctx context.Context, bookClient client.BookClient, items []CartItem) (map[uint]client.Book, error) {
+	books := make(map[uint]client.Book, len(items))
+	if len(items) == 0 {
+		return books, nil
+	}
+	ids := make([]uint, len(items))
+	for i, item := range items {
+		ids[i] = item.BookID
+	}
+	found, err := bookClient.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, book := range found {
+		books[book.ID] = book
+	}
+	return books, nil
+}
+
+// cartItemProblem says why item cannot be checked out as it is, or returns
+// "" when it can. ok reports whether book was found.
+func cartItemProblem(item CartItem, book client.Book, ok bool) string {
+	switch {
+	case !ok:
+		return CartItemUnavailable
+	case book.Stock < item.Quantity:
+		return CartItemOutOfStock
+	case book.Price != item.UnitMoney():
+		return CartItemRepriced
+	default:
+		return ""
+	}
+}
+
+func toCartResponse(ctx context.Context, rates money.Rates, cart *Cart, books map[uint]client.Book) *dto.CartResponse {
+	response := &dto.CartResponse{Items: make([]dto.CartItemResponse, 0, len(cart.Items))}
+	if cart.Token != nil {
+		response.Token = *cart.Token
+	}
+
+	var subtotal money.Money
+	mixed := false
+	for i, item := range cart.Items {
+		added, _ := displayMoney(ctx, rates, item.UnitMoney())
+		book, ok := books[item.BookID]
+		itemResponse := dto.CartItemResponse{
+			BookID:    item.BookID,
+			Quantity:  item.Quantity,
+			UnitPrice: added,
+			Problem:   cartItemProblem(item, book, ok),
+		}
+		if ok {
+			itemResponse.Title = book.Title
+			itemResponse.Stock = book.Stock
+			itemResponse.UnitPrice, _ = displayMoney(ctx, rates, book.Price)
+			if book.Price != item.UnitMoney() {
+				itemResponse.AddedPrice = &added
+			}
+		}
+		itemResponse.Total = itemResponse.UnitPrice.Mul(int64(item.Quantity))
+		response.Items = append(response.Items, itemResponse)
+
+		if i == 0 {
+			subtotal = money.New(0, itemResponse.Total.Currency)
+		}
+		if itemResponse.Total.Currency != subtotal.Currency {
+			mixed = true
+			continue
+		}
+		subtotal.Amount += itemResponse.Total.Amount
+	}
+	if len(cart.Items) > 0 && !mixed {
+		response.Subtotal = &subtotal
+	}
+	return response
+}
+
+// CartCleanupWorker deletes guest carts left untouched for longer than ttl,
+// checking every interval until ctx is cancelled.
+func CartCleanupWorker(cartRepository CartRepository, ttl, interval time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case now := <-ticker.C:
+				deleted, err := cartRepository.DeleteIdleGuests(ctx, now.Add(-ttl))
+				if err != nil {
+					if ctx.Err() == nil {
+						slog.ErrorContext(ctx, "guest cart cleanup failed", "error", err)
+					}
+					continue
+				}
+				if deleted > 0 {
+					slog.InfoContext(ctx, "idle guest carts deleted", "carts", deleted)
+				}
+			}
+		}
+	}
+}
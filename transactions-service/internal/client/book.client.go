@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+
+	"github.com/fahrizalvianaz/shared-contracts/bookpb"
+	"github.com/fahrizalvianaz/shared-contracts/money"
+)
+
+type Book struct {
+	ID    uint
+	Title string
+	Price money.Money
+	Stock int
+}
+
+type BookClient interface {
+	FindByIDs(ctx context.Context, bookIDs []uint) ([]Book, error)
+	ReserveStock(ctx context.Context, bookID uint, quantity int) (*Book, error)
+	ReserveStocks(ctx context.Context, quantities map[uint]int) ([]Book, error)
+	ReleaseStock(ctx context.Context, bookID uint, quantity int) (*Book, error)
+}
+
+type bookClient struct {
+	client bookpb.BookServiceClient
+}
+
+func NewBookClient(client bookpb.BookServiceClient) BookClient {
+	return &bookClient{
+		client: client,
+	}
+}
+
+// FindByIDs returns the books that exist among bookIDs, without reserving
+// anything.
+func (c *bookClient) FindByIDs(ctx context.Context, bookIDs []uint) ([]Book, error) {
+	ids := make([]uint64, len(bookIDs))
+	for i, id := range bookIDs {
+		ids[i] = uint64(id)
+	}
+	response, err := c.client.GetBooks(ctx, &bookpb.GetBooksRequest{Ids: ids})
+	if err != nil {
+		return nil, unwrap(err)
+	}
+
+	books := make([]Book, 0, len(response.GetBooks()))
+	for _, book := range response.GetBooks() {
+		books = append(books, *toBook(book))
+	}
+	return books, nil
+}
+
+func (c *bookClient) ReserveStock(ctx context.Context, bookID uint, quantity int) (*Book, error) {
+	book, err := c.client.ReserveStock(ctx, &bookpb.ReserveStockRequest{
+		Id:       uint64(bookID),
+		Quantity: int64(quantity),
+	})
+	if err != nil {
+		return nil, unwrap(err)
+	}
+
+	return toBook(book), nil
+}
+
+// ReserveStocks reserves quantities, keyed by book id, all or nothing.
+func (c *bookClient) ReserveStocks(ctx context.Context, quantities map[uint]int) ([]Book, error) {
+	request := &bookpb.ReserveStocksRequest{Items: make([]*bookpb.ReserveStockRequest, 0, len(quantities))}
+	for id, quantity := range quantities {
+		request.Items = append(request.Items, &bookpb.ReserveStockRequest{Id: uint64(id), Quantity: int64(quantity)})
+	}
+	response, err := c.client.ReserveStocks(ctx, request)
+	if err != nil {
+		return nil, unwrap(err)
+	}
+
+	books := make([]Book, 0, len(response.GetBooks()))
+	for _, book := range response.GetBooks() {
+		books = append(books, *toBook(book))
+	}
+	return books, nil
+}
+
+func (c *bookClient) ReleaseStock(ctx context.Context, bookID uint, quantity int) (*Book, error) {
+	book, err := c.client.ReleaseStock(ctx, &bookpb.ReserveStockRequest{
+		Id:       uint64(bookID),
+		Quantity: int64(quantity),
+	})
+	if err != nil {
+		return nil, unwrap(err)
+	}
+
+	return toBook(book), nil
+}
+
+func toBook(book *bookpb.Book) *Book {
+	// Book services that predate currencies only ever priced in rupiah.
+	currency := book.GetCurrency()
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	return &Book{
+		ID:    uint(book.GetId()),
+		Title: book.GetTitle(),
+		Price: money.New(book.GetPrice(), currency),
+		Stock: int(book.GetStock()),
+	}
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fahrizalvianaz/shared-contracts/interceptor"
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"github.com/fahrizalvianaz/shared-observability/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const callTimeout = 5 * time.Second
+
+// Dial opens a connection to one of the internal gRPC services. Calls get a
+// default deadline and carry the caller's token as authorization metadata,
+// along with serviceToken to identify this service.
+func Dial(addr, serviceToken string) (*grpc.ClientConn, error) {
+	return grpc.NewClient(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		tracing.DialOption(),
+		grpc.WithChainUnaryInterceptor(
+			interceptor.UnaryClientDeadline(callTimeout),
+			interceptor.UnaryClientAuth(),
+			interceptor.UnaryClientServiceToken(serviceToken),
+		),
+	)
+}
+
+// Ready checks that conn can reach its service, waiting for the connection
+// to come up until ctx expires.
+func Ready(conn *grpc.ClientConn) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		conn.Connect()
+		for {
+			state := conn.GetState()
+			if state == connectivity.Ready {
+				return nil
+			}
+			if !conn.WaitForStateChange(ctx, state) {
+				return fmt.Errorf("connection is %s", strings.ToLower(state.String()))
+			}
+		}
+	}
+}
+
+// unwrap turns a gRPC status back into the upstream service's domain error.
+func unwrap(err error) error {
+	return apperror.FromStatus(err)
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+
+	"github.com/fahrizalvianaz/shared-contracts/userpb"
+)
+
+type User struct {
+	ID       uint
+	Name     string
+	Username string
+	Email    string
+}
+
+type UserClient interface {
+	FindByID(ctx context.Context, userID uint) (*User, error)
+}
+
+type userClient struct {
+	client userpb.UserServiceClient
+}
+
+func NewUserClient(client userpb.UserServiceClient) UserClient {
+	return &userClient{
+		client: client,
+	}
+}
+
+func (c *userClient) FindByID(ctx context.Context, userID uint) (*User, error) {
+	user, err := c.client.GetUser(ctx, &userpb.GetUserRequest{Id: uint64(userID)})
+	if err != nil {
+		return nil, unwrap(err)
+	}
+
+	return &User{
+		ID:       uint(user.GetId()),
+		Name:     user.GetName(),
+		Username: user.GetUsername(),
+		Email:    user.GetEmail(),
+	}, nil
+}
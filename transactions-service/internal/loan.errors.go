@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"errors"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrLoanNotFound     = apperror.NotFound("LOAN_NOT_FOUND", "Loan not found")
+	ErrLoanReturned     = apperror.Conflict("LOAN_RETURNED", "Book has already been returned")
+	ErrLoanOverdue      = apperror.Conflict("LOAN_OVERDUE", "Overdue loans cannot be renewed")
+	ErrRenewalLimit     = apperror.Conflict("LOAN_RENEWAL_LIMIT", "Loan has been renewed as often as allowed")
+	ErrLoanLimit        = apperror.Conflict("LOAN_LIMIT", "You have borrowed as many books as allowed")
+	ErrAlreadyBorrowed  = apperror.Conflict("LOAN_EXISTS", "You are already borrowing this book")
+	ErrLoansOutstanding = apperror.Conflict("LOANS_OUTSTANDING", "Return overdue books and pay outstanding fines before borrowing")
+	ErrFineNotDue       = apperror.Conflict("FINE_NOT_DUE", "Return the book before paying its fine")
+	ErrNoFine           = apperror.Conflict("FINE_NOT_OUTSTANDING", "Loan has no outstanding fine")
+)
+
+// ErrOpenLoanLimit is returned by LoanRepository.Create when the user
+// already has as many loans open as allowed.
+var ErrOpenLoanLimit = errors.New("open loan limit reached")
+
+// loanError translates repository errors into domain errors.
+func loanError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrLoanNotFound.Wrap(err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return ErrAlreadyBorrowed.Wrap(err)
+	case errors.Is(err, ErrOpenLoanLimit):
+		return ErrLoanLimit.Wrap(err)
+	default:
+		return apperror.Internal(err)
+	}
+}
@@ -0,0 +1,62 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+)
+
+const (
+	LoanActive   = "active"
+	LoanOverdue  = "overdue"
+	LoanReturned = "returned"
+)
+
+const fineDay = 24 * time.Hour
+
+// Loan is one copy of a book lent to a user. DailyFine is the rate, in minor
+// units of Currency, fixed when the book was borrowed; Fine is what the
+// loan owes so far, settled when FinePaidAt is set.
+type Loan struct {
+	ID         uint       `gorm:"primaryKey"`
+	UserID     uint       `gorm:"column:user_id;not null;index"`
+	BookID     uint       `gorm:"column:book_id;not null;index"`
+	Status     string     `gorm:"column:status;not null;default:active"`
+	BorrowedAt time.Time  `gorm:"column:borrowed_at;not null"`
+	DueAt      time.Time  `gorm:"column:due_at;not null"`
+	ReturnedAt *time.Time `gorm:"column:returned_at"`
+	Renewals   int        `gorm:"column:renewals;not null;default:0"`
+	DailyFine  int64      `gorm:"column:daily_fine;not null;default:0"`
+	Fine       int64      `gorm:"column:fine;not null;default:0"`
+	Currency   string     `gorm:"column:currency;not null;default:IDR"`
+	FinePaidAt *time.Time `gorm:"column:fine_paid_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at;autoCreateTime"`
+	ModifiedAt time.Time  `gorm:"column:modified_at;autoUpdateTime"`
+}
+
+func (Loan) TableName() string {
+	return "loans"
+}
+
+// StatusAt is the status of the loan at now, which may be ahead of Status
+// until the scheduler next runs.
+func (l *Loan) StatusAt(now time.Time) string {
+	if l.ReturnedAt == nil && now.After(l.DueAt) {
+		return LoanOverdue
+	}
+	return l.Status
+}
+
+// FineAt is the fine owed at now: DailyFine for every day, or part of a
+// day, the loan is late. Returned loans keep the fine worked out on return.
+func (l *Loan) FineAt(now time.Time) int64 {
+	if l.ReturnedAt != nil || !now.After(l.DueAt) {
+		return l.Fine
+	}
+	days := (now.Sub(l.DueAt) + fineDay - 1) / fineDay
+	return int64(days) * l.DailyFine
+}
+
+func (l *Loan) FineMoney(now time.Time) money.Money {
+	return money.New(l.FineAt(now), l.Currency)
+}
@@ -0,0 +1,198 @@
+package internal
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// fineSQL works out the fine of a loan still out at the time given twice as
+// arguments, by the same rule as Loan.FineAt.
+const fineSQL = `CASE WHEN due_at < CAST(? AS TIMESTAMPTZ)
+	THEN daily_fine * CAST(CEIL(EXTRACT(EPOCH FROM (CAST(? AS TIMESTAMPTZ) - due_at)) / 86400) AS BIGINT)
+	ELSE 0 END`
+
+type LoanRepository interface {
+	Create(ctx context.Context, loan *Loan, maxActive int) (*Loan, error)
+	FindByID(ctx context.Context, id, userID uint) (*Loan, error)
+	FindByUserID(ctx context.Context, userID uint, status string, now time.Time, offset, limit int) ([]Loan, int64, error)
+	FindOpen(ctx context.Context, userID uint) ([]Loan, error)
+	FindOutstanding(ctx context.Context, userID uint, now time.Time) ([]Loan, error)
+	Renew(ctx context.Context, id, userID uint, period time.Duration, maxRenewals int, now time.Time) (*Loan, error)
+	Return(ctx context.Context, id, userID uint, now time.Time) (*Loan, error)
+	PayFine(ctx context.Context, id, userID uint, now time.Time) (*Loan, error)
+	MarkOverdue(ctx context.Context, now time.Time) (int64, error)
+}
+
+type loanRepository struct {
+	db *gorm.DB
+}
+
+func NewLoanRepository(db *gorm.DB) LoanRepository {
+	return &loanRepository{
+		db: db,
+	}
+}
+
+// Create stores loan unless its user already has maxActive loans open, in
+// which case it fails with ErrOpenLoanLimit. Borrows by the same user take
+// a lock on that user first, so they are counted one after the other; row
+// locks would not do, as a user without open loans has no rows to lock.
+func (l *loanRepository) Create(ctx context.Context, loan *Loan, maxActive int) (*Loan, error) {
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended('loans:' || CAST(? AS TEXT), 0))", loan.UserID).Error; err != nil {
+			return err
+		}
+		var open int64
+		if err := tx.Model(&Loan{}).Where("user_id = ? AND returned_at IS NULL", loan.UserID).Count(&open).Error; err != nil {
+			return err
+		}
+		if open >= int64(maxActive) {
+			return ErrOpenLoanLimit
+		}
+		return tx.Create(loan).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loan, nil
+}
+
+func (l *loanRepository) FindByID(ctx context.Context, id, userID uint) (*Loan, error) {
+	var loan Loan
+	if err := l.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&loan).Error; err != nil {
+		return nil, err
+	}
+	return &loan, nil
+}
+
+// FindByUserID lists the loans of userID in the given status at now, or
+// every loan not yet returned when status is empty.
+func (l *loanRepository) FindByUserID(ctx context.Context, userID uint, status string, now time.Time, offset, limit int) ([]Loan, int64, error) {
+	var loans []Loan
+	var total int64
+
+	db := l.db.WithContext(ctx).Model(&Loan{}).Where("user_id = ?", userID)
+	switch status {
+	case LoanActive:
+		db = db.Where("returned_at IS NULL AND due_at >= ?", now)
+	case LoanOverdue:
+		db = db.Where("returned_at IS NULL AND due_at < ?", now)
+	case LoanReturned:
+		db = db.Where("returned_at IS NOT NULL")
+	default:
+		db = db.Where("returned_at IS NULL")
+	}
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := db.Order("due_at, id").Offset(offset).Limit(limit).Find(&loans).Error; err != nil {
+		return nil, 0, err
+	}
+	return loans, total, nil
+}
+
+func (l *loanRepository) FindOpen(ctx context.Context, userID uint) ([]Loan, error) {
+	var loans []Loan
+	if err := l.db.WithContext(ctx).Where("user_id = ? AND returned_at IS NULL", userID).Find(&loans).Error; err != nil {
+		return nil, err
+	}
+	return loans, nil
+}
+
+// FindOutstanding returns the loans of userID that are overdue at now or
+// were returned late with the fine still unpaid.
+func (l *loanRepository) FindOutstanding(ctx context.Context, userID uint, now time.Time) ([]Loan, error) {
+	var loans []Loan
+	err := l.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Where("(returned_at IS NULL AND due_at < ?) OR (returned_at IS NOT NULL AND fine > 0 AND fine_paid_at IS NULL)", now).
+		Order("due_at, id").
+		Find(&loans).Error
+	if err != nil {
+		return nil, err
+	}
+	return loans, nil
+}
+
+// Renew pushes the due date of a loan back by period, unless it is
+// returned, overdue at now or out of renewals, in which case it fails with
+// gorm.ErrRecordNotFound.
+func (l *loanRepository) Renew(ctx context.Context, id, userID uint, period time.Duration, maxRenewals int, now time.Time) (*Loan, error) {
+	var loan Loan
+	result := l.db.WithContext(ctx).Model(&loan).Clauses(clause.Returning{}).
+		Where("id = ? AND user_id = ? AND returned_at IS NULL AND due_at >= ? AND renewals < ?", id, userID, now, maxRenewals).
+		Updates(map[string]interface{}{
+			"due_at":   gorm.Expr("due_at + ? * INTERVAL '1 second'", int64(period/time.Second)),
+			"renewals": gorm.Expr("renewals + 1"),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &loan, nil
+}
+
+// Return closes a loan at now and fixes its fine, or fails with
+// gorm.ErrRecordNotFound when there is no such loan still out.
+func (l *loanRepository) Return(ctx context.Context, id, userID uint, now time.Time) (*Loan, error) {
+	var loan Loan
+	result := l.db.WithContext(ctx).Model(&loan).Clauses(clause.Returning{}).
+		Where("id = ? AND user_id = ? AND returned_at IS NULL", id, userID).
+		Updates(map[string]interface{}{
+			"status":      LoanReturned,
+			"returned_at": now,
+			"fine":        gorm.Expr(fineSQL, now, now),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &loan, nil
+}
+
+// PayFine settles the fine of a returned loan, or fails with
+// gorm.ErrRecordNotFound when there is none outstanding.
+func (l *loanRepository) PayFine(ctx context.Context, id, userID uint, now time.Time) (*Loan, error) {
+	var loan Loan
+	result := l.db.WithContext(ctx).Model(&loan).Clauses(clause.Returning{}).
+		Where("id = ? AND user_id = ? AND returned_at IS NOT NULL AND fine > 0 AND fine_paid_at IS NULL", id, userID).
+		Update("fine_paid_at", now)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &loan, nil
+}
+
+// MarkOverdue moves loans past their due date at now to overdue and brings
+// the fines of every overdue loan up to date. It returns how many loans
+// went overdue.
+func (l *loanRepository) MarkOverdue(ctx context.Context, now time.Time) (int64, error) {
+	var marked int64
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Loan{}).
+			Where("status = ? AND due_at < ?", LoanActive, now).
+			Update("status", LoanOverdue)
+		if result.Error != nil {
+			return result.Error
+		}
+		marked = result.RowsAffected
+
+		return tx.Model(&Loan{}).
+			Where("status = ?", LoanOverdue).
+			Update("fine", gorm.Expr(fineSQL, now, now)).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return marked, nil
+}
@@ -0,0 +1,339 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+	"transactions-service/internal/api/dto"
+	"transactions-service/internal/client"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultLoanPeriod          = 14 * 24 * time.Hour
+	DefaultLoanMaxRenewals     = 2
+	DefaultLoanMaxActive       = 5
+	DefaultLoanOverdueInterval = time.Hour
+)
+
+// LoanPolicy holds the lending rules. DailyFine is charged for every day,
+// or part of a day, a book is kept past its due date.
+type LoanPolicy struct {
+	Period      time.Duration
+	MaxRenewals int
+	MaxActive   int
+	DailyFine   money.Money
+}
+
+// LoanPolicyFromEnv reads LOAN_PERIOD, LOAN_MAX_RENEWALS, LOAN_MAX_ACTIVE
+// and LOAN_DAILY_FINE, a decimal amount in LOAN_FINE_CURRENCY, falling back
+// to the defaults and no fine.
+func LoanPolicyFromEnv() LoanPolicy {
+	policy := LoanPolicy{
+		Period:      DefaultLoanPeriod,
+		MaxRenewals: DefaultLoanMaxRenewals,
+		MaxActive:   DefaultLoanMaxActive,
+		DailyFine:   money.New(0, money.DefaultCurrency),
+	}
+	if period, err := time.ParseDuration(os.Getenv("LOAN_PERIOD")); err == nil && period > 0 {
+		policy.Period = period
+	}
+	if renewals, err := strconv.Atoi(os.Getenv("LOAN_MAX_RENEWALS")); err == nil && renewals >= 0 {
+		policy.MaxRenewals = renewals
+	}
+	if active, err := strconv.Atoi(os.Getenv("LOAN_MAX_ACTIVE")); err == nil && active > 0 {
+		policy.MaxActive = active
+	}
+	currency := os.Getenv("LOAN_FINE_CURRENCY")
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	if fine, err := money.Parse(os.Getenv("LOAN_DAILY_FINE"), currency); err == nil && fine.Amount >= 0 {
+		policy.DailyFine = fine
+	}
+	return policy
+}
+
+// LoanOverdueIntervalFromEnv reads LOAN_OVERDUE_INTERVAL, falling back to
+// DefaultLoanOverdueInterval.
+func LoanOverdueIntervalFromEnv() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("LOAN_OVERDUE_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return DefaultLoanOverdueInterval
+	}
+	return interval
+}
+
+type LoanService interface {
+	Borrow(ctx context.Context, userID uint, request dto.BorrowRequest) (*dto.LoanResponse, error)
+	Renew(ctx context.Context, userID, id uint) (*dto.LoanResponse, error)
+	Return(ctx context.Context, userID, id uint) (*dto.LoanResponse, error)
+	FindByUserID(ctx context.Context, userID uint, request dto.LoanListRequest) (*dto.LoanListResponse, error)
+	Fines(ctx context.Context, userID uint) (*dto.FinesResponse, error)
+	PayFine(ctx context.Context, userID, id uint) (*dto.LoanResponse, error)
+}
+
+type loanService struct {
+	loanRepository LoanRepository
+	bookClient     client.BookClient
+	userClient     client.UserClient
+	rates          money.Rates
+	policy         LoanPolicy
+}
+
+func NewLoanService(loanRepository LoanRepository, bookClient client.BookClient, userClient client.UserClient, rates money.Rates, policy LoanPolicy) LoanService {
+	return &loanService{
+		loanRepository: loanRepository,
+		bookClient:     bookClient,
+		userClient:     userClient,
+		rates:          rates,
+		policy:         policy,
+	}
+}
+
+// Borrow lends one copy of a book, taking it out of stock. Users at their
+// loan limit, or with overdue books or unpaid fines, cannot borrow.
+func (l *loanService) Borrow(ctx context.Context, userID uint, request dto.BorrowRequest) (*dto.LoanResponse, error) {
+	if _, err := l.userClient.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	open, err := l.loanRepository.FindOpen(ctx, userID)
+	if err != nil {
+		return nil, loanError(err)
+	}
+	for _, loan := range open {
+		if loan.BookID == request.BookID {
+			return nil, ErrAlreadyBorrowed
+		}
+	}
+	// Checked again by Create, which holds for concurrent borrows; this
+	// only spares reserving a copy that would have to be given back.
+	if len(open) >= l.policy.MaxActive {
+		return nil, ErrLoanLimit
+	}
+	outstanding, err := l.loanRepository.FindOutstanding(ctx, userID, now)
+	if err != nil {
+		return nil, loanError(err)
+	}
+	if len(outstanding) > 0 {
+		return nil, ErrLoansOutstanding
+	}
+
+	if _, err := l.bookClient.ReserveStock(ctx, request.BookID, 1); err != nil {
+		return nil, err
+	}
+	loan, err := l.loanRepository.Create(ctx, &Loan{
+		UserID:     userID,
+		BookID:     request.BookID,
+		Status:     LoanActive,
+		BorrowedAt: now,
+		DueAt:      now.Add(l.policy.Period),
+		DailyFine:  l.policy.DailyFine.Amount,
+		Currency:   l.policy.DailyFine.Currency,
+	}, l.policy.MaxActive)
+	if err != nil {
+		l.releaseCopy(ctx, request.BookID)
+		return nil, loanError(err)
+	}
+	loanEventsTotal.WithLabelValues("borrowed").Inc()
+
+	response := l.toLoanResponse(ctx, loan, now)
+	return &response, nil
+}
+
+// Renew gives a loan another loan period from its current due date, as
+// long as it is not overdue and has renewals left.
+func (l *loanService) Renew(ctx context.Context, userID, id uint) (*dto.LoanResponse, error) {
+	now := time.Now()
+	loan, err := l.loanRepository.Renew(ctx, id, userID, l.policy.Period, l.policy.MaxRenewals, now)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, l.whyNot(ctx, userID, id, now)
+	}
+	if err != nil {
+		return nil, loanError(err)
+	}
+	loanEventsTotal.WithLabelValues("renewed").Inc()
+
+	response := l.toLoanResponse(ctx, loan, now)
+	return &response, nil
+}
+
+// Return closes a loan, fixing any fine it ran up, and puts the copy back
+// in stock.
+func (l *loanService) Return(ctx context.Context, userID, id uint) (*dto.LoanResponse, error) {
+	now := time.Now()
+	loan, err := l.loanRepository.Return(ctx, id, userID, now)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, l.whyNot(ctx, userID, id, now)
+	}
+	if err != nil {
+		return nil, loanError(err)
+	}
+	l.releaseCopy(ctx, loan.BookID)
+	loanEventsTotal.WithLabelValues("returned").Inc()
+
+	response := l.toLoanResponse(ctx, loan, now)
+	return &response, nil
+}
+
+func (l *loanService) FindByUserID(ctx context.Context, userID uint, request dto.LoanListRequest) (*dto.LoanListResponse, error) {
+	page, limit := request.Page, request.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+
+	now := time.Now()
+	loans, total, err := l.loanRepository.FindByUserID(ctx, userID, request.Status, now, (page-1)*limit, limit)
+	if err != nil {
+		return nil, loanError(err)
+	}
+
+	response := &dto.LoanListResponse{
+		Loans: make([]dto.LoanResponse, 0, len(loans)),
+		Page:  page,
+		Limit: limit,
+		Total: total,
+	}
+	for _, loan := range loans {
+		response.Loans = append(response.Loans, l.toLoanResponse(ctx, &loan, now))
+	}
+	return response, nil
+}
+
+// Fines lists what userID owes: the fines of books returned late and the
+// ones overdue books are still running up.
+func (l *loanService) Fines(ctx context.Context, userID uint) (*dto.FinesResponse, error) {
+	now := time.Now()
+	loans, err := l.loanRepository.FindOutstanding(ctx, userID, now)
+	if err != nil {
+		return nil, loanError(err)
+	}
+
+	response := &dto.FinesResponse{Fines: make([]dto.LoanResponse, 0, len(loans))}
+	var total money.Money
+	mixed := false
+	for _, loan := range loans {
+		if loan.FineAt(now) == 0 {
+			continue
+		}
+		loanResponse := l.toLoanResponse(ctx, &loan, now)
+		if len(response.Fines) == 0 {
+			total = money.New(0, loanResponse.Fine.Currency)
+		}
+		response.Fines = append(response.Fines, loanResponse)
+		if loanResponse.Fine.Currency != total.Currency {
+			mixed = true
+			continue
+		}
+		total.Amount += loanResponse.Fine.Amount
+	}
+	if len(response.Fines) > 0 && !mixed {
+		response.Total = &total
+	}
+	return response, nil
+}
+
+// PayFine records that the fine of a returned loan was paid.
+func (l *loanService) PayFine(ctx context.Context, userID, id uint) (*dto.LoanResponse, error) {
+	now := time.Now()
+	loan, err := l.loanRepository.PayFine(ctx, id, userID, now)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, l.whyNotPayable(ctx, userID, id)
+	}
+	if err != nil {
+		return nil, loanError(err)
+	}
+
+	response := l.toLoanResponse(ctx, loan, now)
+	return &response, nil
+}
+
+// whyNot explains why a renewal or return found no loan to change.
+func (l *loanService) whyNot(ctx context.Context, userID, id uint, now time.Time) error {
+	loan, err := l.loanRepository.FindByID(ctx, id, userID)
+	switch {
+	case err != nil:
+		return loanError(err)
+	case loan.ReturnedAt != nil:
+		return ErrLoanReturned
+	case now.After(loan.DueAt):
+		return ErrLoanOverdue
+	default:
+		return ErrRenewalLimit
+	}
+}
+
+func (l *loanService) whyNotPayable(ctx context.Context, userID, id uint) error {
+	loan, err := l.loanRepository.FindByID(ctx, id, userID)
+	switch {
+	case err != nil:
+		return loanError(err)
+	case loan.ReturnedAt == nil:
+		return ErrFineNotDue
+	default:
+		return ErrNoFine
+	}
+}
+
+// releaseCopy puts a borrowed copy back in stock. The loan is already
+// settled either way, so a failure is only logged for the stock to be
+// corrected by hand.
+func (l *loanService) releaseCopy(ctx context.Context, bookID uint) {
+	if _, err := l.bookClient.ReleaseStock(ctx, bookID, 1); err != nil {
+		slog.ErrorContext(ctx, "releasing loaned copy failed", "bookId", bookID, "error", err)
+	}
+}
+
+func (l *loanService) toLoanResponse(ctx context.Context, loan *Loan, now time.Time) dto.LoanResponse {
+	fine, _ := displayMoney(ctx, l.rates, loan.FineMoney(now))
+	return dto.LoanResponse{
+		ID:           loan.ID,
+		BookID:       loan.BookID,
+		Status:       loan.StatusAt(now),
+		BorrowedAt:   loan.BorrowedAt,
+		DueAt:        loan.DueAt,
+		ReturnedAt:   loan.ReturnedAt,
+		Renewals:     loan.Renewals,
+		RenewalsLeft: max(l.policy.MaxRenewals-loan.Renewals, 0),
+		Fine:         fine,
+		FinePaidAt:   loan.FinePaidAt,
+	}
+}
+
+// LoanOverdueWorker marks loans overdue and brings their fines up to date,
+// every interval until ctx is cancelled.
+func LoanOverdueWorker(loanRepository LoanRepository, interval time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			marked, err := loanRepository.MarkOverdue(ctx, time.Now())
+			switch {
+			case err != nil && ctx.Err() == nil:
+				slog.ErrorContext(ctx, "marking overdue loans failed", "error", err)
+			case marked > 0:
+				loansOverdueTotal.Add(float64(marked))
+				slog.InfoContext(ctx, "loans went overdue", "loans", marked)
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	}
+}
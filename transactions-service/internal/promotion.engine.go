@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"sort"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+)
+
+// CartLine is one book of a cart being priced.
+type CartLine struct {
+	BookID    uint
+	Quantity  int
+	UnitPrice money.Money
+}
+
+// PricedLine is a cart line after promotions. Promotions holds the share of
+// Discount each applied promotion gave, keyed by promotion id.
+type PricedLine struct {
+	CartLine
+	Discount   int64
+	Promotions map[uint]int64
+}
+
+func (l *PricedLine) Subtotal() int64 {
+	return l.UnitPrice.Amount * int64(l.Quantity)
+}
+
+func (l *PricedLine) Total() int64 {
+	return l.Subtotal() - l.Discount
+}
+
+// AppliedPromotion is a promotion that took something off the cart.
+type AppliedPromotion struct {
+	Promotion *Promotion
+	Discount  int64
+}
+
+// Pricing is the outcome of evaluating promotions against a cart. Amounts
+// are in minor units of Currency.
+type Pricing struct {
+	Currency string
+	Subtotal int64
+	Discount int64
+	Lines    []PricedLine
+	Applied  []AppliedPromotion
+}
+
+func (p *Pricing) Total() int64 {
+	return p.Subtotal - p.Discount
+}
+
+// Applies reports whether the promotion with id took something off.
+func (p *Pricing) Applies(id uint) bool {
+	for _, applied := range p.Applied {
+		if applied.Promotion.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate prices cart with promotions that are already known to be
+// running and within their caps. They apply in priority order, highest
+// first, each to what the earlier ones left, so a line never goes below
+// zero. Promotions in another currency than the cart cannot compare their
+// amounts and are skipped.
+func Evaluate(cart []CartLine, promotions []Promotion) (*Pricing, error) {
+	pricing := &Pricing{Lines: make([]PricedLine, len(cart))}
+	for i, line := range cart {
+		if i == 0 {
+			pricing.Currency = line.UnitPrice.Currency
+		}
+		if line.UnitPrice.Currency != pricing.Currency {
+			return nil, ErrMixedCurrency
+		}
+		pricing.Lines[i] = PricedLine{CartLine: line, Promotions: map[uint]int64{}}
+		pricing.Subtotal += pricing.Lines[i].Subtotal()
+	}
+
+	ordered := make([]*Promotion, len(promotions))
+	for i := range promotions {
+		ordered[i] = &promotions[i]
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority > ordered[j].Priority
+		}
+		return ordered[i].ID < ordered[j].ID
+	})
+
+	for _, promotion := range ordered {
+		if !qualifies(promotion, pricing) {
+			continue
+		}
+		var total int64
+		budget := promotion.AmountOff
+		for i := range pricing.Lines {
+			line := &pricing.Lines[i]
+			if promotion.BookID != nil && *promotion.BookID != line.BookID {
+				continue
+			}
+			discount := min(lineDiscount(promotion, line, budget), line.Total())
+			if discount <= 0 {
+				continue
+			}
+			budget -= discount
+			line.Discount += discount
+			line.Promotions[promotion.ID] += discount
+			total += discount
+		}
+		if total > 0 {
+			pricing.Discount += total
+			pricing.Applied = append(pricing.Applied, AppliedPromotion{Promotion: promotion, Discount: total})
+		}
+	}
+	return pricing, nil
+}
+
+// qualifies checks the cart-wide conditions of a promotion.
+func qualifies(promotion *Promotion, pricing *Pricing) bool {
+	needsCurrency := promotion.Kind == PromotionFixed || promotion.MinSubtotal > 0
+	if needsCurrency && promotion.Currency != pricing.Currency {
+		return false
+	}
+	return pricing.Subtotal >= promotion.MinSubtotal
+}
+
+// lineDiscount is what promotion takes off one eligible line, before
+// capping at what is left of it. budget is what remains of a fixed amount.
+func lineDiscount(promotion *Promotion, line *PricedLine, budget int64) int64 {
+	switch promotion.Kind {
+	case PromotionPercentage:
+		return line.Total() * int64(promotion.PercentOff) / 100
+	case PromotionFixed:
+		return budget
+	case PromotionBuyXGetY:
+		group := promotion.BuyQuantity + promotion.GetQuantity
+		if group == 0 {
+			return 0
+		}
+		free := line.Quantity / group * promotion.GetQuantity
+		return line.UnitPrice.Amount * int64(free)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPromotionNotFound   = apperror.NotFound("PROMOTION_NOT_FOUND", "Promotion not found")
+	ErrPromotionWindow     = apperror.Validation("PROMOTION_WINDOW_INVALID", "Promotion must end after it starts")
+	ErrPromotionRule       = apperror.Validation("PROMOTION_RULE_INVALID", "Promotion is missing the settings its kind needs")
+	ErrPromotionExhausted  = apperror.Conflict("PROMOTION_EXHAUSTED", "Promotion has reached its redemption limit")
+	ErrCouponExists        = apperror.Conflict("COUPON_EXISTS", "Coupon code is already in use")
+	ErrCouponInvalid       = apperror.Validation("COUPON_INVALID", "Coupon code is not valid")
+	ErrCouponExpired       = apperror.Validation("COUPON_EXPIRED", "Coupon is not valid at this time")
+	ErrCouponNotApplicable = apperror.Validation("COUPON_NOT_APPLICABLE", "Coupon does not apply to this cart")
+	ErrMixedCurrency       = apperror.Validation("CART_MIXED_CURRENCY", "Cart items must share one currency")
+
+	// ErrBookNotFound matches book-service's code. Batch lookups answer
+	// with fewer books rather than this error, so it is raised here.
+	ErrBookNotFound = apperror.NotFound("BOOK_NOT_FOUND", "Book not found")
+)
+
+// CapError is returned by a redemption that would go over a promotion's
+// global or per-user cap.
+type CapError struct {
+	PromotionID uint
+}
+
+func (c *CapError) Error() string {
+	return fmt.Sprintf("promotion %d reached its redemption cap", c.PromotionID)
+}
+
+// promotionError translates repository errors into domain errors.
+func promotionError(err error) error {
+	var capErr *CapError
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrPromotionNotFound.Wrap(err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return ErrCouponExists.Wrap(err)
+	case errors.As(err, &capErr):
+		return ErrPromotionExhausted.Wrap(err)
+	default:
+		return apperror.Internal(err)
+	}
+}
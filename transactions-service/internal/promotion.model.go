@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"gorm.io/gorm"
+)
+
+const (
+	PromotionPercentage = "percentage"
+	PromotionFixed      = "fixed"
+	PromotionBuyXGetY   = "buy_x_get_y"
+)
+
+// Promotion is a discount rule. Promotions without a Code apply to every
+// qualifying cart; those with one are coupons the customer has to enter.
+// Fixed amounts and the minimum subtotal are in minor units of Currency.
+type Promotion struct {
+	ID             uint           `gorm:"primaryKey"`
+	Name           string         `gorm:"column:name;not null"`
+	Kind           string         `gorm:"column:kind;not null"`
+	Code           *string        `gorm:"column:code;uniqueIndex"`
+	PercentOff     int            `gorm:"column:percent_off;not null;default:0"`
+	AmountOff      int64          `gorm:"column:amount_off;not null;default:0"`
+	Currency       string         `gorm:"column:currency;not null;default:IDR"`
+	BuyQuantity    int            `gorm:"column:buy_quantity;not null;default:0"`
+	GetQuantity    int            `gorm:"column:get_quantity;not null;default:0"`
+	BookID         *uint          `gorm:"column:book_id"`
+	MinSubtotal    int64          `gorm:"column:min_subtotal;not null;default:0"`
+	Priority       int            `gorm:"column:priority;not null;default:0"`
+	StartsAt       time.Time      `gorm:"column:starts_at;not null"`
+	EndsAt         *time.Time     `gorm:"column:ends_at"`
+	MaxRedemptions *int           `gorm:"column:max_redemptions"`
+	MaxPerUser     *int           `gorm:"column:max_per_user"`
+	Redemptions    int            `gorm:"column:redemptions;not null;default:0"`
+	Active         bool           `gorm:"column:active;not null;default:true"`
+	CreatedAt      time.Time      `gorm:"column:created_at;autoCreateTime"`
+	ModifiedAt     time.Time      `gorm:"column:modified_at;autoUpdateTime"`
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+}
+
+func (Promotion) TableName() string {
+	return "promotions"
+}
+
+// Running reports whether the promotion can be redeemed at now, ignoring
+// its caps.
+func (p *Promotion) Running(now time.Time) bool {
+	return p.Active && !p.StartsAt.After(now) && (p.EndsAt == nil || p.EndsAt.After(now))
+}
+
+// Exhausted reports whether the global cap is used up, or the per-user cap
+// after used redemptions by the same user.
+func (p *Promotion) Exhausted(used int) bool {
+	return (p.MaxRedemptions != nil && p.Redemptions >= *p.MaxRedemptions) ||
+		(p.MaxPerUser != nil && used >= *p.MaxPerUser)
+}
+
+// PromotionUsage counts the redemptions of a promotion by one user.
+type PromotionUsage struct {
+	PromotionID uint `gorm:"primaryKey;column:promotion_id"`
+	UserID      uint `gorm:"primaryKey;column:user_id"`
+	Count       int  `gorm:"column:count;not null;default:0"`
+}
+
+func (PromotionUsage) TableName() string {
+	return "promotion_usages"
+}
+
+// TransactionPromotion records a promotion applied to a transaction and its
+// share of the discount. Name and code are copied so the record survives
+// later edits to the promotion.
+type TransactionPromotion struct {
+	ID            uint      `gorm:"primaryKey"`
+	TransactionID uint      `gorm:"column:transaction_id;not null;index"`
+	PromotionID   uint      `gorm:"column:promotion_id;not null;index"`
+	Name          string    `gorm:"column:name;not null"`
+	Code          *string   `gorm:"column:code"`
+	Discount      int64     `gorm:"column:discount;not null"`
+	Currency      string    `gorm:"column:currency;not null"`
+	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (TransactionPromotion) TableName() string {
+	return "transaction_promotions"
+}
+
+func (t *TransactionPromotion) Money() money.Money {
+	return money.New(t.Discount, t.Currency)
+}
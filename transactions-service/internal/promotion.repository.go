@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PromotionRepository interface {
+	Create(ctx context.Context, promotion *Promotion) (*Promotion, error)
+	FindAll(ctx context.Context, active *bool, offset, limit int) ([]Promotion, int64, error)
+	FindByID(ctx context.Context, id uint) (*Promotion, error)
+	FindByCode(ctx context.Context, code string) (*Promotion, error)
+	FindRunning(ctx context.Context, now time.Time) ([]Promotion, error)
+	Usage(ctx context.Context, userID uint, promotionIDs []uint) (map[uint]int, error)
+	Deactivate(ctx context.Context, id uint) error
+}
+
+type promotionRepository struct {
+	db *gorm.DB
+}
+
+func NewPromotionRepository(db *gorm.DB) PromotionRepository {
+	return &promotionRepository{
+		db: db,
+	}
+}
+
+func (p *promotionRepository) Create(ctx context.Context, promotion *Promotion) (*Promotion, error) {
+	if err := p.db.WithContext(ctx).Create(promotion).Error; err != nil {
+		return nil, err
+	}
+	return promotion, nil
+}
+
+func (p *promotionRepository) FindAll(ctx context.Context, active *bool, offset, limit int) ([]Promotion, int64, error) {
+	var promotions []Promotion
+	var total int64
+
+	db := p.db.WithContext(ctx).Model(&Promotion{})
+	if active != nil {
+		db = db.Where("active = ?", *active)
+	}
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := db.Order("id DESC").Offset(offset).Limit(limit).Find(&promotions).Error; err != nil {
+		return nil, 0, err
+	}
+	return promotions, total, nil
+}
+
+func (p *promotionRepository) FindByID(ctx context.Context, id uint) (*Promotion, error) {
+	var promotion Promotion
+	if err := p.db.WithContext(ctx).First(&promotion, id).Error; err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+func (p *promotionRepository) FindByCode(ctx context.Context, code string) (*Promotion, error) {
+	var promotion Promotion
+	if err := p.db.WithContext(ctx).Where("code = ?", code).First(&promotion).Error; err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// FindRunning returns the automatic promotions that can be redeemed at now
+// and are still under their global cap.
+func (p *promotionRepository) FindRunning(ctx context.Context, now time.Time) ([]Promotion, error) {
+	var promotions []Promotion
+	err := p.db.WithContext(ctx).
+		Where("code IS NULL AND active AND starts_at <= ? AND (ends_at IS NULL OR ends_at > ?)", now, now).
+		Where("max_redemptions IS NULL OR redemptions < max_redemptions").
+		Find(&promotions).Error
+	if err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+// Usage counts the redemptions of each promotion by userID.
+func (p *promotionRepository) Usage(ctx context.Context, userID uint, promotionIDs []uint) (map[uint]int, error) {
+	usage := make(map[uint]int, len(promotionIDs))
+	if len(promotionIDs) == 0 {
+		return usage, nil
+	}
+	var rows []PromotionUsage
+	err := p.db.WithContext(ctx).
+		Where("user_id = ? AND promotion_id IN ?", userID, promotionIDs).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		usage[row.PromotionID] = row.Count
+	}
+	return usage, nil
+}
+
+func (p *promotionRepository) Deactivate(ctx context.Context, id uint) error {
+	result := p.db.WithContext(ctx).Model(&Promotion{}).Where("id = ?", id).Update("active", false)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// redeem counts one redemption of a promotion by userID inside tx, or fails
+// with *CapError when either cap is used up. The counter update locks the
+// promotion row until tx ends, so redemptions of one promotion run one at
+// a time and the per-user count cannot race.
+func redeem(tx *gorm.DB, promotionID, userID uint) error {
+	result := tx.Model(&Promotion{}).
+		Where("id = ? AND (max_redemptions IS NULL OR redemptions < max_redemptions)", promotionID).
+		Update("redemptions", gorm.Expr("redemptions + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return &CapError{PromotionID: promotionID}
+	}
+
+	var promotion Promotion
+	if err := tx.Select("id", "max_per_user").First(&promotion, promotionID).Error; err != nil {
+		return err
+	}
+	usage := PromotionUsage{PromotionID: promotionID, UserID: userID, Count: 1}
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "promotion_id"}, {Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"count": gorm.Expr("promotion_usages.count + 1")}),
+	}, clause.Returning{Columns: []clause.Column{{Name: "count"}}}).Create(&usage).Error
+	if err != nil {
+		return err
+	}
+	if promotion.MaxPerUser != nil && usage.Count > *promotion.MaxPerUser {
+		return &CapError{PromotionID: promotionID}
+	}
+	return nil
+}
@@ -0,0 +1,330 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"transactions-service/internal/api/dto"
+	"transactions-service/internal/client"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"gorm.io/gorm"
+)
+
+type PromotionService interface {
+	Create(ctx context.Context, request dto.PromotionRequest) (*dto.PromotionResponse, error)
+	FindAll(ctx context.Context, request dto.PromotionListRequest) (*dto.PromotionListResponse, error)
+	FindByID(ctx context.Context, id uint) (*dto.PromotionResponse, error)
+	Deactivate(ctx context.Context, id uint) error
+	Quote(ctx context.Context, userID uint, request dto.QuoteRequest) (*dto.QuoteResponse, error)
+	Candidates(ctx context.Context, userID uint, code string) ([]Promotion, error)
+}
+
+type promotionService struct {
+	promotionRepository PromotionRepository
+	bookClient          client.BookClient
+	rates               money.Rates
+}
+
+func NewPromotionService(promotionRepository PromotionRepository, bookClient client.BookClient, rates money.Rates) PromotionService {
+	return &promotionService{
+		promotionRepository: promotionRepository,
+		bookClient:          bookClient,
+		rates:               rates,
+	}
+}
+
+func (p *promotionService) Create(ctx context.Context, request dto.PromotionRequest) (*dto.PromotionResponse, error) {
+	promotion, err := toPromotion(request)
+	if err != nil {
+		return nil, err
+	}
+	result, err := p.promotionRepository.Create(ctx, promotion)
+	if err != nil {
+		return nil, promotionError(err)
+	}
+	response := toPromotionResponse(result)
+	return &response, nil
+}
+
+func (p *promotionService) FindAll(ctx context.Context, request dto.PromotionListRequest) (*dto.PromotionListResponse, error) {
+	page, limit := request.Page, request.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+
+	promotions, total, err := p.promotionRepository.FindAll(ctx, request.Active, (page-1)*limit, limit)
+	if err != nil {
+		return nil, promotionError(err)
+	}
+
+	response := &dto.PromotionListResponse{
+		Promotions: make([]dto.PromotionResponse, 0, len(promotions)),
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+	}
+	for _, promotion := range promotions {
+		response.Promotions = append(response.Promotions, toPromotionResponse(&promotion))
+	}
+	return response, nil
+}
+
+func (p *promotionService) FindByID(ctx context.Context, id uint) (*dto.PromotionResponse, error) {
+	promotion, err := p.promotionRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, promotionError(err)
+	}
+	response := toPromotionResponse(promotion)
+	return &response, nil
+}
+
+// Deactivate stops a promotion for good. It is kept, not deleted, because
+// past transactions refer to it.
+func (p *promotionService) Deactivate(ctx context.Context, id uint) error {
+	if err := p.promotionRepository.Deactivate(ctx, id); err != nil {
+		return promotionError(err)
+	}
+	return nil
+}
+
+// Quote prices a cart at current book prices without reserving stock or
+// redeeming anything.
+func (p *promotionService) Quote(ctx context.Context, userID uint, request dto.QuoteRequest) (*dto.QuoteResponse, error) {
+	code := NormalizeCoupon(request.Coupon)
+	candidates, err := p.Candidates(ctx, userID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(request.Items))
+	for i, item := range request.Items {
+		ids[i] = item.BookID
+	}
+	books, err := p.bookClient.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	prices := make(map[uint]money.Money, len(books))
+	for _, book := range books {
+		prices[book.ID] = book.Price
+	}
+
+	cart := make([]CartLine, 0, len(request.Items))
+	for _, item := range request.Items {
+		price, ok := prices[item.BookID]
+		if !ok {
+			return nil, ErrBookNotFound
+		}
+		cart = append(cart, CartLine{BookID: item.BookID, Quantity: item.Quantity, UnitPrice: price})
+	}
+
+	pricing, err := PriceCart(cart, candidates, code)
+	if err != nil {
+		return nil, err
+	}
+	return toQuoteResponse(ctx, p.rates, pricing), nil
+}
+
+// Candidates returns the promotions a cart of userID could use now: every
+// running automatic promotion still within its caps and, when code is set,
+// the coupon it names. A coupon that cannot be used is an error rather than
+// silently left out.
+func (p *promotionService) Candidates(ctx context.Context, userID uint, code string) ([]Promotion, error) {
+	now := time.Now()
+	candidates, err := p.promotionRepository.FindRunning(ctx, now)
+	if err != nil {
+		return nil, promotionError(err)
+	}
+
+	if code != "" {
+		coupon, err := p.promotionRepository.FindByCode(ctx, code)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, ErrCouponInvalid.Wrap(err)
+		case err != nil:
+			return nil, promotionError(err)
+		case !coupon.Active:
+			return nil, ErrCouponInvalid
+		case !coupon.Running(now):
+			return nil, ErrCouponExpired
+		}
+		candidates = append(candidates, *coupon)
+	}
+
+	ids := make([]uint, len(candidates))
+	for i, promotion := range candidates {
+		ids[i] = promotion.ID
+	}
+	usage, err := p.promotionRepository.Usage(ctx, userID, ids)
+	if err != nil {
+		return nil, promotionError(err)
+	}
+
+	usable := candidates[:0]
+	for _, promotion := range candidates {
+		if !promotion.Exhausted(usage[promotion.ID]) {
+			usable = append(usable, promotion)
+			continue
+		}
+		if promotion.Code != nil {
+			return nil, ErrPromotionExhausted
+		}
+	}
+	return usable, nil
+}
+
+// PriceCart evaluates cart against candidates and makes sure a coupon the
+// customer entered took something off.
+func PriceCart(cart []CartLine, candidates []Promotion, code string) (*Pricing, error) {
+	pricing, err := Evaluate(cart, candidates)
+	if err != nil {
+		return nil, err
+	}
+	if code == "" {
+		return pricing, nil
+	}
+	for _, applied := range pricing.Applied {
+		if applied.Promotion.Code != nil {
+			return pricing, nil
+		}
+	}
+	return nil, ErrCouponNotApplicable
+}
+
+// NormalizeCoupon makes coupon codes case-insensitive.
+func NormalizeCoupon(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func toPromotion(request dto.PromotionRequest) (*Promotion, error) {
+	promotion := &Promotion{
+		Name:           request.Name,
+		Kind:           request.Kind,
+		PercentOff:     request.PercentOff,
+		BuyQuantity:    request.BuyQuantity,
+		GetQuantity:    request.GetQuantity,
+		BookID:         request.BookID,
+		Priority:       request.Priority,
+		StartsAt:       time.Now(),
+		EndsAt:         request.EndsAt,
+		MaxRedemptions: request.MaxRedemptions,
+		MaxPerUser:     request.MaxPerUser,
+		Currency:       money.DefaultCurrency,
+		Active:         true,
+	}
+	if code := NormalizeCoupon(request.Code); code != "" {
+		promotion.Code = &code
+	}
+	if request.StartsAt != nil {
+		promotion.StartsAt = *request.StartsAt
+	}
+	if promotion.EndsAt != nil && !promotion.EndsAt.After(promotion.StartsAt) {
+		return nil, ErrPromotionWindow
+	}
+
+	// Fixed amounts and the minimum subtotal share the promotion's currency.
+	var currencies []string
+	if request.AmountOff != nil {
+		promotion.AmountOff = request.AmountOff.Amount
+		currencies = append(currencies, request.AmountOff.Currency)
+	}
+	if request.MinSubtotal != nil {
+		promotion.MinSubtotal = request.MinSubtotal.Amount
+		currencies = append(currencies, request.MinSubtotal.Currency)
+	}
+	for i, currency := range currencies {
+		if currency == "" {
+			currency = money.DefaultCurrency
+		}
+		if i > 0 && currency != promotion.Currency {
+			return nil, ErrPromotionRule.WithMessage("Amount off and minimum subtotal must share a currency")
+		}
+		promotion.Currency = currency
+	}
+
+	switch {
+	case promotion.Kind == PromotionPercentage && promotion.PercentOff == 0,
+		promotion.Kind == PromotionFixed && promotion.AmountOff == 0,
+		promotion.Kind == PromotionBuyXGetY && (promotion.BuyQuantity == 0 || promotion.GetQuantity == 0):
+		return nil, ErrPromotionRule
+	}
+	return promotion, nil
+}
+
+func toPromotionResponse(promotion *Promotion) dto.PromotionResponse {
+	response := dto.PromotionResponse{
+		ID:             promotion.ID,
+		Name:           promotion.Name,
+		Kind:           promotion.Kind,
+		Code:           promotion.Code,
+		PercentOff:     promotion.PercentOff,
+		BuyQuantity:    promotion.BuyQuantity,
+		GetQuantity:    promotion.GetQuantity,
+		BookID:         promotion.BookID,
+		Priority:       promotion.Priority,
+		StartsAt:       promotion.StartsAt,
+		EndsAt:         promotion.EndsAt,
+		MaxRedemptions: promotion.MaxRedemptions,
+		MaxPerUser:     promotion.MaxPerUser,
+		Redemptions:    promotion.Redemptions,
+		Active:         promotion.Active,
+		CreatedAt:      promotion.CreatedAt,
+	}
+	if promotion.AmountOff > 0 {
+		amountOff := money.New(promotion.AmountOff, promotion.Currency)
+		response.AmountOff = &amountOff
+	}
+	if promotion.MinSubtotal > 0 {
+		minSubtotal := money.New(promotion.MinSubtotal, promotion.Currency)
+		response.MinSubtotal = &minSubtotal
+	}
+	return response
+}
+
+func toQuoteResponse(ctx context.Context, rates money.Rates, pricing *Pricing) *dto.QuoteResponse {
+	subtotal := money.New(pricing.Subtotal, pricing.Currency)
+	discount := money.New(pricing.Discount, pricing.Currency)
+	total := money.New(pricing.Total(), pricing.Currency)
+	_, converted := displayMoney(ctx, rates, total)
+
+	response := &dto.QuoteResponse{
+		Items:      make([]dto.QuoteItemResponse, 0, len(pricing.Lines)),
+		Promotions: make([]dto.AppliedPromotionResponse, 0, len(pricing.Applied)),
+	}
+	for _, line := range pricing.Lines {
+		unitPrice, _ := displayMoney(ctx, rates, line.UnitPrice)
+		lineDiscount, _ := displayMoney(ctx, rates, money.New(line.Discount, pricing.Currency))
+		lineTotal := unitPrice.Mul(int64(line.Quantity)).Sub(lineDiscount)
+		response.Items = append(response.Items, dto.QuoteItemResponse{
+			BookID:    line.BookID,
+			Quantity:  line.Quantity,
+			UnitPrice: unitPrice,
+			Discount:  lineDiscount,
+			Total:     lineTotal,
+		})
+	}
+	for _, applied := range pricing.Applied {
+		appliedDiscount, _ := displayMoney(ctx, rates, money.New(applied.Discount, pricing.Currency))
+		response.Promotions = append(response.Promotions, dto.AppliedPromotionResponse{
+			ID:       applied.Promotion.ID,
+			Name:     applied.Promotion.Name,
+			Code:     applied.Promotion.Code,
+			Discount: appliedDiscount,
+		})
+	}
+	response.Subtotal, _ = displayMoney(ctx, rates, subtotal)
+	response.Discount, _ = displayMoney(ctx, rates, discount)
+	response.Total = response.Subtotal.Sub(response.Discount)
+	if converted {
+		response.BaseTotal = &total
+	}
+	return response
+}
@@ -0,0 +1,37 @@
+package internal
+
+import (
+	"github.com/fahrizalvianaz/shared-observability/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var promotionRedemptionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Name:      "promotion_redemptions_total",
+	Help:      "Number of promotions redeemed at checkout by kind.",
+}, []string{"kind"})
+
+var cartsMergedTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Name:      "carts_merged_total",
+	Help:      "Number of guest carts merged into a user's cart on sign-in.",
+})
+
+var cartCheckoutsTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Name:      "cart_checkouts_total",
+	Help:      "Number of carts checked out.",
+})
+
+var loanEventsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Name:      "loan_events_total",
+	Help:      "Number of books borrowed, renewed and returned.",
+}, []string{"event"})
+
+var loansOverdueTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Name:      "loans_overdue_total",
+	Help:      "Number of loans that went overdue.",
+})
@@ -3,18 +3,32 @@ package internal
 import (
 	"time"
 
+	"github.com/fahrizalvianaz/shared-contracts/money"
 	"gorm.io/gorm"
 )
 
-type Book struct {
-	ID         uint           `gorm:"primaryKey"`
-	BookID     uint           `gorm:"column:book_id;not null"`
-	UserID     uint           `gorm:"column:user_id;not null"`
-	CreatedAt  time.Time      `gorm:"column:created_at;autoCreateTime"`
-	ModifiedAt time.Time      `gorm:"column:modified_at;autoUpdateTime"`
-	DeletedAt  gorm.DeletedAt `gorm:"index"`
+// Transaction records a purchase. UnitPrice is the book price in effect
+// when the stock was reserved, in minor units of Currency, so later price
+// changes leave it untouched. Discount is what Promotions took off the
+// line in total.
+type Transaction struct {
+	ID         uint                   `gorm:"primaryKey"`
+	BookID     uint                   `gorm:"column:book_id;not null;index"`
+	UserID     uint                   `gorm:"column:user_id;not null;index"`
+	Quantity   int                    `gorm:"column:quantity;not null;default:1"`
+	UnitPrice  int64                  `gorm:"column:unit_price;not null;default:0"`
+	Currency   string                 `gorm:"column:currency;not null;default:IDR"`
+	Discount   int64                  `gorm:"column:discount;not null;default:0"`
+	Promotions []TransactionPromotion `gorm:"foreignKey:TransactionID"`
+	CreatedAt  time.Time              `gorm:"column:created_at;autoCreateTime"`
+	ModifiedAt time.Time              `gorm:"column:modified_at;autoUpdateTime"`
+	DeletedAt  gorm.DeletedAt         `gorm:"index"`
 }
 
-func (Book) TableName() string {
+func (Transaction) TableName() string {
 	return "transactions"
 }
+
+func (t *Transaction) UnitMoney() money.Money {
+	return money.New(t.UnitPrice, t.Currency)
+}
@@ -0,0 +1,71 @@
+package internal
+
+import (
+	"context"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+type TransactionRepository interface {
+	Checkout(ctx context.Context, userID uint, transactions []Transaction, promotionIDs []uint, cartID uint) error
+	FindByUserID(ctx context.Context, userID uint, offset, limit int) ([]Transaction, int64, error)
+}
+
+type transactionRepository struct {
+	db *gorm.DB
+}
+
+func NewTransactionRepository(db *gorm.DB) TransactionRepository {
+	return &transactionRepository{
+		db: db,
+	}
+}
+
+// Checkout stores the transactions of one purchase, with their applied
+// promotions, and redeems each promotion once for userID. It is all or
+// nothing: a promotion over its cap fails it with *CapError and nothing is
+// stored. When cartID is set, the books bought leave that cart in the same
+// transaction.
+func (t *transactionRepository) Checkout(ctx context.Context, userID uint, transactions []Transaction, promotionIDs []uint, cartID uint) error {
+	// Locking promotions in id order keeps concurrent checkouts from
+	// deadlocking on each other.
+	promotionIDs = slices.Sorted(slices.Values(promotionIDs))
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, id := range promotionIDs {
+			if err := redeem(tx, id, userID); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(&transactions).Error; err != nil {
+			return err
+		}
+		if cartID == 0 {
+			return nil
+		}
+		bookIDs := make([]uint, len(transactions))
+		for i, transaction := range transactions {
+			bookIDs[i] = transaction.BookID
+		}
+		if err := tx.Where("cart_id = ? AND book_id IN ?", cartID, bookIDs).Delete(&CartItem{}).Error; err != nil {
+			return err
+		}
+		return touch(tx, cartID)
+	})
+}
+
+func (t *transactionRepository) FindByUserID(ctx context.Context, userID uint, offset, limit int) ([]Transaction, int64, error) {
+	var transactions []Transaction
+	var total int64
+
+	db := t.db.WithContext(ctx).Model(&Transaction{}).Where("user_id = ?", userID)
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := db.Preload("Promotions").Order("id DESC").Offset(offset).Limit(limit).Find(&transactions)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return transactions, total, nil
+}
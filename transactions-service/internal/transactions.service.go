@@ -0,0 +1,347 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"transactions-service/internal/api/dto"
+	"transactions-service/internal/client"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+)
+
+const (
+	defaultPageLimit = 10
+	maxPageLimit     = 100
+)
+
+type TransactionService interface {
+	Purchase(ctx context.Context, userID uint, request dto.PurchaseRequest) (*dto.TransactionResponse, error)
+	Checkout(ctx context.Context, owner CartOwner, request dto.CheckoutRequest) (*dto.CheckoutResponse, error)
+	FindByUserID(ctx context.Context, userID uint, request dto.ListRequest) (*dto.ListResponse, error)
+}
+
+type transactionService struct {
+	transactionRepository TransactionRepository
+	cartRepository        CartRepository
+	promotionService      PromotionService
+	bookClient            client.BookClient
+	userClient            client.UserClient
+	rates                 money.Rates
+}
+
+func NewTransactionService(transactionRepository TransactionRepository, cartRepository CartRepository, promotionService PromotionService, bookClient client.BookClient, userClient client.UserClient, rates money.Rates) TransactionService {
+	return &transactionService{
+		transactionRepository: transactionRepository,
+		cartRepository:        cartRepository,
+		promotionService:      promotionService,
+		bookClient:            bookClient,
+		userClient:            userClient,
+		rates:                 rates,
+	}
+}
+
+func (t *transactionService) Purchase(ctx context.Context, userID uint, request dto.PurchaseRequest) (*dto.TransactionResponse, error) {
+	quantity := request.Quantity
+	if quantity < 1 {
+		quantity = 1
+	}
+
+	if _, err := t.userClient.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	code := NormalizeCoupon(request.Coupon)
+	candidates, err := t.promotionService.Candidates(ctx, userID, code)
+	if err != nil {
+		return nil, err
+	}
+	if code != "" {
+		books, err := t.bookClient.FindByIDs(ctx, []uint{request.BookID})
+		if err != nil {
+			return nil, err
+		}
+		if len(books) == 0 {
+			return nil, ErrBookNotFound
+		}
+		cart := []CartLine{{BookID: request.BookID, Quantity: quantity, UnitPrice: books[0].Price}}
+		if _, err := PriceCart(cart, candidates, code); err != nil {
+			return nil, err
+		}
+	}
+
+	book, err := t.bookClient.ReserveStock(ctx, request.BookID, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	cart := []CartLine{{BookID: request.BookID, Quantity: quantity, UnitPrice: book.Price}}
+	transactions, err := t.checkout(ctx, userID, cart, candidates, code, 0)
+	if err != nil {
+		t.releaseStock(ctx, map[uint]int{request.BookID: quantity})
+		return nil, err
+	}
+
+	response := t.toTransactionResponse(ctx, &transactions[0])
+	return &response, nil
+}
+
+// Checkout buys the whole cart of owner in one step: the books are priced
+// together, so cart-wide promotions apply, reserved all or nothing and
+// stored as one transaction each, after which they leave the cart.
+//
+// Items that changed price or can no longer be bought fail the checkout
+// with ErrCartChanged, listing them. The new prices are kept, so checking
+// out again goes through once the customer has seen them.
+func (t *transactionService) Checkout(ctx context.Context, owner CartOwner, request dto.CheckoutRequest) (*dto.CheckoutResponse, error) {
+	if _, err := t.userClient.FindByID(ctx, owner.UserID); err != nil {
+		return nil, err
+	}
+
+	cart, err := openCart(ctx, t.cartRepository, owner, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(cart.Items) == 0 {
+		return nil, ErrCartEmpty
+	}
+	books, err := findCartBooks(ctx, t.bookClient, cart.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []apperror.FieldError
+	lines := make([]CartLine, 0, len(cart.Items))
+	quantities := make(map[uint]int, len(cart.Items))
+	for _, item := range cart.Items {
+		book, ok := books[item.BookID]
+		problem := cartItemProblem(item, book, ok)
+		if ok && book.Price != item.UnitMoney() {
+			item.UnitPrice, item.Currency = book.Price.Amount, book.Price.Currency
+			if err := t.cartRepository.SetItem(ctx, item); err != nil {
+				return nil, cartError(err)
+			}
+		}
+		if problem != "" {
+			problems = append(problems, apperror.FieldError{
+				Field:   fmt.Sprintf("items.%d", item.BookID),
+				Code:    problem,
+				Message: cartProblemMessages[problem],
+			})
+			continue
+		}
+		lines = append(lines, CartLine{BookID: item.BookID, Quantity: item.Quantity, UnitPrice: book.Price})
+		quantities[item.BookID] = item.Quantity
+	}
+	if len(problems) > 0 {
+		return nil, ErrCartChanged.WithFields(problems...)
+	}
+
+	code := NormalizeCoupon(request.Coupon)
+	candidates, err := t.promotionService.Candidates(ctx, owner.UserID, code)
+	if err != nil {
+		return nil, err
+	}
+	// As in Purchase, everything that can fail is checked before stock is
+	// reserved.
+	if _, err := PriceCart(lines, candidates, code); err != nil {
+		return nil, err
+	}
+
+	reserved, err := t.bookClient.ReserveStocks(ctx, quantities)
+	if err != nil {
+		return nil, err
+	}
+	prices := make(map[uint]money.Money, len(reserved))
+	for _, book := range reserved {
+		prices[book.ID] = book.Price
+	}
+	for i := range lines {
+		lines[i].UnitPrice = prices[lines[i].BookID]
+	}
+
+	transactions, err := t.checkout(ctx, owner.UserID, lines, candidates, code, cart.ID)
+	if err != nil {
+		t.releaseStock(ctx, quantities)
+		return nil, err
+	}
+	cartCheckoutsTotal.Inc()
+
+	response := &dto.CheckoutResponse{Transactions: make([]dto.TransactionResponse, 0, len(transactions))}
+	for i := range transactions {
+		transactionResponse := t.toTransactionResponse(ctx, &transactions[i])
+		if i == 0 {
+			response.Total = money.New(0, transactionResponse.Total.Currency)
+		}
+		response.Total.Amount += transactionResponse.Total.Amount
+		response.Transactions = append(response.Transactions, transactionResponse)
+	}
+	return response, nil
+}
+
+// checkout prices cart and stores one transaction per line, redeeming the
+// promotions that applied and, when cartID is set, taking the lines out of
+// that cart. An automatic promotion that reached its cap in the meantime is
+// dropped and the cart priced again; a coupon that did fails the checkout.
+func (t *transactionService) checkout(ctx context.Context, userID uint, cart []CartLine, candidates []Promotion, code string, cartID uint) ([]Transaction, error) {
+	for {
+		pricing, err := PriceCart(cart, candidates, code)
+		if err != nil {
+			return nil, err
+		}
+
+		transactions := make([]Transaction, len(pricing.Lines))
+		for i, line := range pricing.Lines {
+			transactions[i] = Transaction{
+				BookID:     line.BookID,
+				UserID:     userID,
+				Quantity:   line.Quantity,
+				UnitPrice:  line.UnitPrice.Amount,
+				Currency:   line.UnitPrice.Currency,
+				Discount:   line.Discount,
+				Promotions: lineTransactionPromotions(pricing, line),
+			}
+		}
+		promotionIDs := make([]uint, len(pricing.Applied))
+		for i, applied := range pricing.Applied {
+			promotionIDs[i] = applied.Promotion.ID
+		}
+
+		err = t.transactionRepository.Checkout(ctx, userID, transactions, promotionIDs, cartID)
+		var capErr *CapError
+		switch {
+		case err == nil:
+			for _, applied := range pricing.Applied {
+				promotionRedemptionsTotal.WithLabelValues(applied.Promotion.Kind).Inc()
+			}
+			return transactions, nil
+		case !errors.As(err, &capErr):
+			return nil, apperror.Internal(err)
+		}
+
+		remaining := candidates[:0:0]
+		for _, promotion := range candidates {
+			if promotion.ID != capErr.PromotionID {
+				remaining = append(remaining, promotion)
+				continue
+			}
+			if promotion.Code != nil {
+				return nil, ErrPromotionExhausted.Wrap(err)
+			}
+		}
+		candidates = remaining
+	}
+}
+
+// releaseStock gives back stock reserved for a purchase or checkout that
+// could not be stored. It has failed either way, so a failure is only
+// logged for the stock to be corrected by hand.
+func (t *transactionService) releaseStock(ctx context.Context, quantities map[uint]int) {
+	// The release has to happen even when the caller has gone away.
+	ctx = context.WithoutCancel(ctx)
+	for bookID, quantity := range quantities {
+		if _, err := t.bookClient.ReleaseStock(ctx, bookID, quantity); err != nil {
+			slog.ErrorContext(ctx, "releasing reserved stock failed", "bookId", bookID, "quantity", quantity, "error", err)
+		}
+	}
+}
+
+// lineTransactionPromotions lists the promotions that took something off
+// line.
+func lineTransactionPromotions(pricing *Pricing, line PricedLine) []TransactionPromotion {
+	var promotions []TransactionPromotion
+	for _, applied := range pricing.Applied {
+		discount := line.Promotions[applied.Promotion.ID]
+		if discount == 0 {
+			continue
+		}
+		promotions = append(promotions, TransactionPromotion{
+			PromotionID: applied.Promotion.ID,
+			Name:        applied.Promotion.Name,
+			Code:        applied.Promotion.Code,
+			Discount:    discount,
+			Currency:    pricing.Currency,
+		})
+	}
+	return promotions
+}
+
+func (t *transactionService) FindByUserID(ctx context.Context, userID uint, request dto.ListRequest) (*dto.ListResponse, error) {
+	page, limit := request.Page, request.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+
+	transactions, total, err := t.transactionRepository.FindByUserID(ctx, userID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+
+	response := &dto.ListResponse{
+		Transactions: make([]dto.TransactionResponse, 0, len(transactions)),
+		Page:         page,
+		Limit:        limit,
+		Total:        total,
+	}
+	for _, transaction := range transactions {
+		response.Transactions = append(response.Transactions, t.toTransactionResponse(ctx, &transaction))
+	}
+
+	return response, nil
+}
+
+func (t *transactionService) toTransactionResponse(ctx context.Context, transaction *Transaction) dto.TransactionResponse {
+	quantity := int64(transaction.Quantity)
+	total := transaction.UnitMoney().Mul(quantity).Sub(money.New(transaction.Discount, transaction.Currency))
+	// The parts are converted one by one and the total worked out from
+	// them, so quantity times unit price less discount adds up on screen.
+	unitPrice, converted := displayMoney(ctx, t.rates, transaction.UnitMoney())
+	discount, _ := displayMoney(ctx, t.rates, money.New(transaction.Discount, transaction.Currency))
+	response := dto.TransactionResponse{
+		ID:        transaction.ID,
+		BookID:    transaction.BookID,
+		UserID:    transaction.UserID,
+		Quantity:  transaction.Quantity,
+		UnitPrice: unitPrice,
+		Discount:  discount,
+		Total:     unitPrice.Mul(quantity).Sub(discount),
+		CreatedAt: transaction.CreatedAt,
+	}
+	if converted {
+		response.BaseTotal = &total
+	}
+	for _, promotion := range transaction.Promotions {
+		promotionDiscount, _ := displayMoney(ctx, t.rates, promotion.Money())
+		response.Promotions = append(response.Promotions, dto.AppliedPromotionResponse{
+			ID:       promotion.PromotionID,
+			Name:     promotion.Name,
+			Code:     promotion.Code,
+			Discount: promotionDiscount,
+		})
+	}
+	return response
+}
+
+// displayMoney converts price into the currency the client asked for and
+// reports whether it did. Without a request, or when no rate is known, the
+// price comes back as stored.
+func displayMoney(ctx context.Context, rates money.Rates, price money.Money) (money.Money, bool) {
+	currency := money.DisplayFrom(ctx)
+	if currency == "" || currency == price.Currency || rates == nil {
+		return price, false
+	}
+	converted, err := money.Convert(price, currency, rates)
+	if err != nil {
+		slog.WarnContext(ctx, "price conversion failed", "from", price.Currency, "to", currency, "error", err)
+		return price, false
+	}
+	return converted, true
+}
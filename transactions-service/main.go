@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"os"
+	"transactions-service/internal"
+	"transactions-service/internal/client"
+	"transactions-service/migrations"
+	"transactions-service/pkg"
+	"transactions-service/routes"
+	"transactions-service/seeds"
+
+	configs "github.com/fahrizalvianaz/shared-configuration/configs"
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-observability/logging"
+	"github.com/fahrizalvianaz/shared-observability/tracing"
+	"github.com/fahrizalvianaz/shared-server/health"
+	"github.com/fahrizalvianaz/shared-server/idempotency"
+	"github.com/fahrizalvianaz/shared-server/roles"
+	"github.com/fahrizalvianaz/shared-server/server"
+	"gorm.io/gorm"
+)
+
+func main() {
+	logging.Setup("transactions-service")
+
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		logging.Fatal("Failed to load config", err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		connect := func() (*gorm.DB, error) { return pkg.ConnectDB(cfg) }
+		if err := migrations.Command(os.Args[2:], connect); err != nil {
+			logging.Fatal("Migration command failed", err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		db, err := pkg.ConnectDB(cfg)
+		if err != nil {
+			logging.Fatal("Failed to connect to database", err)
+		}
+		if err := seeds.Command(os.Args[2:], db); err != nil {
+			logging.Fatal("Seed command failed", err)
+		}
+		return
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.LoadConfig("transactions-service"))
+	if err != nil {
+		logging.Fatal("Failed to set up tracing", err)
+	}
+
+	db, err := pkg.ConnectDB(cfg)
+	if err != nil {
+		logging.Fatal("Failed to connect to database", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		logging.Fatal("Failed to get database handle", err)
+	}
+
+	if err := migrations.Migrate(db); err != nil {
+		logging.Fatal("Failed to run migrations", err)
+	}
+
+	serviceToken := os.Getenv("SERVICE_TOKEN")
+	bookConn, err := client.Dial(os.Getenv("BOOK_SERVICE_GRPC_ADDR"), serviceToken)
+	if err != nil {
+		logging.Fatal("Failed to connect to book service", err)
+	}
+
+	userConn, err := client.Dial(os.Getenv("USER_SERVICE_GRPC_ADDR"), serviceToken)
+	if err != nil {
+		logging.Fatal("Failed to connect to user service", err)
+	}
+
+	rates, err := money.RatesFromEnv()
+	if err != nil {
+		logging.Fatal("Failed to load exchange rates", err)
+	}
+
+	admins, err := roles.AdminsFromEnv()
+	if err != nil {
+		logging.Fatal("Failed to load admin users", err)
+	}
+
+	checker := health.New(health.DefaultTimeout)
+	checker.Add("database", health.Ping(sqlDB))
+	checker.Add("migrations", func(ctx context.Context) error { return migrations.Status(ctx, db) })
+	checker.Add("book-service", client.Ready(bookConn))
+	checker.Add("user-service", client.Ready(userConn))
+
+	router := routes.Router(db, bookConn, userConn, rates, admins, checker)
+	router.SetTrustedProxies(nil)
+
+	runner := server.New(server.LoadConfig(), router)
+	runner.OnDrain(checker.Drain)
+	runner.OnShutdown("tracing", shutdownTracing)
+	runner.OnShutdown("database", func(context.Context) error { return sqlDB.Close() })
+	runner.OnShutdown("book service connection", func(context.Context) error { return bookConn.Close() })
+	runner.OnShutdown("user service connection", func(context.Context) error { return userConn.Close() })
+	runner.AddWorker("cart-cleanup", internal.CartCleanupWorker(internal.NewCartRepository(db), internal.CartGuestTTLFromEnv(), internal.DefaultCartCleanupInterval))
+	runner.AddWorker("loan-overdue", internal.LoanOverdueWorker(internal.NewLoanRepository(db), internal.LoanOverdueIntervalFromEnv()))
+	runner.AddWorker("idempotency-cleanup", idempotency.CleanupWorker(idempotency.NewGormStore(db), idempotency.DefaultCleanupInterval))
+
+	if err := runner.Run(context.Background()); err != nil {
+		logging.Fatal("Server stopped with error", err)
+	}
+}
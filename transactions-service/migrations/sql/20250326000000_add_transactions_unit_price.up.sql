@@ -0,0 +1 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS unit_price BIGINT NOT NULL DEFAULT 0;
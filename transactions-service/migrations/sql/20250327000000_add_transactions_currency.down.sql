@@ -0,0 +1,3 @@
+UPDATE transactions SET unit_price = unit_price / 100;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS currency;
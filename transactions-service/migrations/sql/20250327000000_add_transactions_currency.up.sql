@@ -0,0 +1,5 @@
+-- Unit prices move from whole rupiah to minor units (sen) with an explicit
+-- ISO 4217 currency, matching the book service.
+ALTER TABLE transactions ADD COLUMN currency TEXT NOT NULL DEFAULT 'IDR';
+
+UPDATE transactions SET unit_price = unit_price * 100;
@@ -0,0 +1,4 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS discount;
+DROP TABLE IF EXISTS transaction_promotions;
+DROP TABLE IF EXISTS promotion_usages;
+DROP TABLE IF EXISTS promotions;
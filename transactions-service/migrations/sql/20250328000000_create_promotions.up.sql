@@ -0,0 +1,51 @@
+CREATE TABLE IF NOT EXISTS promotions (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('percentage', 'fixed', 'buy_x_get_y')),
+    code TEXT,
+    percent_off INTEGER NOT NULL DEFAULT 0 CHECK (percent_off BETWEEN 0 AND 100),
+    amount_off BIGINT NOT NULL DEFAULT 0 CHECK (amount_off >= 0),
+    currency TEXT NOT NULL DEFAULT 'IDR',
+    buy_quantity INTEGER NOT NULL DEFAULT 0 CHECK (buy_quantity >= 0),
+    get_quantity INTEGER NOT NULL DEFAULT 0 CHECK (get_quantity >= 0),
+    book_id BIGINT,
+    min_subtotal BIGINT NOT NULL DEFAULT 0 CHECK (min_subtotal >= 0),
+    priority INTEGER NOT NULL DEFAULT 0,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ,
+    max_redemptions INTEGER CHECK (max_redemptions > 0),
+    max_per_user INTEGER CHECK (max_per_user > 0),
+    redemptions INTEGER NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ,
+    modified_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_promotions_code ON promotions (code);
+CREATE INDEX IF NOT EXISTS idx_promotions_running ON promotions (starts_at) WHERE code IS NULL AND active;
+CREATE INDEX IF NOT EXISTS idx_promotions_deleted_at ON promotions (deleted_at);
+
+CREATE TABLE IF NOT EXISTS promotion_usages (
+    promotion_id BIGINT NOT NULL REFERENCES promotions (id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    count INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (promotion_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS transaction_promotions (
+    id BIGSERIAL PRIMARY KEY,
+    transaction_id BIGINT NOT NULL REFERENCES transactions (id) ON DELETE CASCADE,
+    promotion_id BIGINT NOT NULL REFERENCES promotions (id),
+    name TEXT NOT NULL,
+    code TEXT,
+    discount BIGINT NOT NULL CHECK (discount > 0),
+    currency TEXT NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_promotions_transaction_id ON transaction_promotions (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_transaction_promotions_promotion_id ON transaction_promotions (promotion_id);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS discount BIGINT NOT NULL DEFAULT 0;
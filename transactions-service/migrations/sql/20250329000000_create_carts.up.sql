@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS carts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT,
+    token TEXT,
+    created_at TIMESTAMPTZ,
+    modified_at TIMESTAMPTZ,
+    CHECK ((user_id IS NULL) <> (token IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_carts_user_id ON carts (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_carts_token ON carts (token);
+CREATE INDEX IF NOT EXISTS idx_carts_guest_modified_at ON carts (modified_at) WHERE user_id IS NULL;
+
+CREATE TABLE IF NOT EXISTS cart_items (
+    id BIGSERIAL PRIMARY KEY,
+    cart_id BIGINT NOT NULL REFERENCES carts (id) ON DELETE CASCADE,
+    book_id BIGINT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price BIGINT NOT NULL CHECK (unit_price >= 0),
+    currency TEXT NOT NULL,
+    created_at TIMESTAMPTZ,
+    modified_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cart_items_cart_book ON cart_items (cart_id, book_id);
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS loans (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    book_id BIGINT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'overdue', 'returned')),
+    borrowed_at TIMESTAMPTZ NOT NULL,
+    due_at TIMESTAMPTZ NOT NULL,
+    returned_at TIMESTAMPTZ,
+    renewals INTEGER NOT NULL DEFAULT 0 CHECK (renewals >= 0),
+    daily_fine BIGINT NOT NULL DEFAULT 0 CHECK (daily_fine >= 0),
+    fine BIGINT NOT NULL DEFAULT 0 CHECK (fine >= 0),
+    currency TEXT NOT NULL DEFAULT 'IDR',
+    fine_paid_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    modified_at TIMESTAMPTZ,
+    CHECK (due_at > borrowed_at),
+    CHECK ((status = 'returned') = (returned_at IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_loans_user_id ON loans (user_id);
+CREATE INDEX IF NOT EXISTS idx_loans_book_id ON loans (book_id);
+-- A user borrows at most one copy of a book at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_loans_open_user_book ON loans (user_id, book_id) WHERE returned_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_loans_open_due_at ON loans (due_at) WHERE returned_at IS NULL;
@@ -0,0 +1,50 @@
+package pkg
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	configs "github.com/fahrizalvianaz/shared-configuration/configs"
+	"github.com/fahrizalvianaz/shared-observability/logging"
+	"github.com/fahrizalvianaz/shared-observability/metrics"
+	"github.com/fahrizalvianaz/shared-observability/tracing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func ConnectDB(cfg *configs.Config) (*gorm.DB, error) {
+	dsn := fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName)
+	dialector := postgres.Open(dsn)
+
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger:         logging.NewGormLogger(slog.Default(), logging.SlowThresholdFromEnv()),
+		TranslateError: true,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Use(tracing.GormPlugin()); err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB.SetMaxIdleConns(10)
+	sqlDB.SetMaxOpenConns(100)
+	sqlDB.SetConnMaxLifetime(time.Hour)
+
+	if err := metrics.RegisterDBStats(sqlDB, cfg.DBName); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"transactions-service/internal/api"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"github.com/fahrizalvianaz/shared-errors/validation"
+	"github.com/fahrizalvianaz/shared-observability/logging"
+	"github.com/fahrizalvianaz/shared-observability/metrics"
+	"github.com/fahrizalvianaz/shared-observability/tracing"
+	"github.com/fahrizalvianaz/shared-server/health"
+	"github.com/fahrizalvianaz/shared-server/roles"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+func Router(db *gorm.DB, bookConn, userConn grpc.ClientConnInterface, rates money.Rates, admins roles.Admins, checker *health.Checker) *gin.Engine {
+	validation.Register()
+
+	router := gin.New()
+	router.Use(logging.RequestID())
+	router.Use(tracing.Middleware("transactions-service")...)
+	router.Use(metrics.Middleware("transactions-service"))
+	router.Use(logging.Middleware(), logging.Recovery())
+	router.NoRoute(apperror.NoRoute)
+	router.GET("/metrics", metrics.Handler())
+	router.GET("/healthz", gin.WrapF(checker.Liveness))
+	router.GET("/readyz", gin.WrapF(checker.Readiness))
+	group := router.Group("/api/v1")
+
+	api.TransactionRoutes(group.Group("/transactions"), db, bookConn, userConn, rates)
+	api.PromotionRoutes(group.Group("/promotions"), db, bookConn, rates, admins)
+	api.CartRoutes(group.Group("/cart"), db, bookConn, userConn, rates)
+	api.LoanRoutes(group.Group("/loans"), db, bookConn, userConn, rates)
+
+	return router
+}
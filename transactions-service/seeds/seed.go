@@ -0,0 +1,116 @@
+package seeds
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"log/slog"
+	model "transactions-service/internal"
+
+	"github.com/fahrizalvianaz/shared-migrations/seed"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:embed fixtures.yaml
+var defaultFixtures []byte
+
+type TransactionFixture struct {
+	ID       uint `yaml:"id" json:"id"`
+	BookID   uint `yaml:"bookId" json:"bookId"`
+	UserID   uint `yaml:"userId" json:"userId"`
+	Quantity int  `yaml:"quantity" json:"quantity"`
+}
+
+type Fixtures struct {
+	Transactions []TransactionFixture `yaml:"transactions" json:"transactions"`
+}
+
+// Command runs the `seed` subcommand.
+func Command(args []string, db *gorm.DB) error {
+	opts, err := seed.ParseArgs(args)
+	if err != nil {
+		return err
+	}
+
+	var fixtures Fixtures
+	switch {
+	case opts.Generate > 0:
+		fixtures = Generate(opts.Generate)
+	case opts.File != "":
+		err = seed.ReadFile(opts.File, &fixtures)
+	default:
+		err = seed.Decode(defaultFixtures, false, &fixtures)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	count, err := Load(context.Background(), db, fixtures)
+	if err != nil {
+		return err
+	}
+	slog.Info("Seeded transactions", "count", count)
+	return nil
+}
+
+// Load inserts transactions by explicit id and skips ids that already
+// exist. Transactions have no natural key, so the id is what makes seeding
+// repeatable. The id sequence is moved past the seeded rows afterwards.
+func Load(ctx context.Context, db *gorm.DB, fixtures Fixtures) (int, error) {
+	if len(fixtures.Transactions) == 0 {
+		return 0, nil
+	}
+
+	transactions := make([]model.Transaction, 0, len(fixtures.Transactions))
+	for _, fixture := range fixtures.Transactions {
+		if fixture.ID == 0 {
+			return 0, fmt.Errorf("transaction fixture for book %d has no id", fixture.BookID)
+		}
+		quantity := fixture.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+		transactions = append(transactions, model.Transaction{
+			ID:       fixture.ID,
+			BookID:   fixture.BookID,
+			UserID:   fixture.UserID,
+			Quantity: quantity,
+		})
+	}
+
+	var inserted int64
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&transactions, 500)
+		if result.Error != nil {
+			return fmt.Errorf("failed to seed transactions: %w", result.Error)
+		}
+		inserted = result.RowsAffected
+
+		return tx.Exec(`SELECT setval(pg_get_serial_sequence('transactions', 'id'), (SELECT MAX(id) FROM transactions))`).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(inserted), nil
+}
+
+// Generate builds n fake transactions with ids 1..n. They reference books
+// and users 1..n/5 and 1..n/10, matching `seed --generate` runs of the
+// other services with proportionally smaller counts.
+func Generate(n int) Fixtures {
+	books := max(n/5, 1)
+	users := max(n/10, 1)
+
+	fixtures := Fixtures{Transactions: make([]TransactionFixture, 0, n)}
+	for i := 0; i < n; i++ {
+		r := seed.Rand(i)
+		fixtures.Transactions = append(fixtures.Transactions, TransactionFixture{
+			ID:       uint(i + 1),
+			BookID:   uint(seed.Between(r, 1, books)),
+			UserID:   uint(seed.Between(r, 1, users)),
+			Quantity: seed.Between(r, 1, 3),
+		})
+	}
+	return fixtures
+}
@@ -0,0 +1,101 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"transactions-service/internal/api"
+
+	"github.com/fahrizalvianaz/shared-errors/validation"
+	shared_middleware "github.com/fahrizalvianaz/shared-middleware"
+	"github.com/fahrizalvianaz/shared-server/roles"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSecret = "test-secret"
+	adminID    = 1
+	customerID = 2
+)
+
+// newPromotionRouter mounts the real promotion routes. JWTAuth insists on
+// an .env file, so the test runs from a directory holding an empty one.
+// Requests checked here never reach the database or the book service.
+func newPromotionRouter(t *testing.T) *gin.Engine {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), nil, 0o600))
+	t.Chdir(dir)
+	t.Setenv("SECRET_KEY", testSecret)
+	gin.SetMode(gin.TestMode)
+	validation.Register()
+
+	router := gin.New()
+	api.PromotionRoutes(router.Group("/promotions"), nil, nil, nil, roles.Admins{adminID: true})
+	return router
+}
+
+func bearer(t *testing.T, userID uint) string {
+	claims := shared_middleware.Claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return "Bearer " + token
+}
+
+func send(router http.Handler, method, path, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPromotionRoutes_RequireAdmin(t *testing.T) {
+	router := newPromotionRouter(t)
+	routes := []struct{ method, path string }{
+		{http.MethodGet, "/promotions"},
+		{http.MethodPost, "/promotions"},
+		{http.MethodDelete, "/promotions/1"},
+	}
+	for _, route := range routes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			assert.Equal(t, http.StatusUnauthorized, send(router, route.method, route.path, "").Code)
+
+			w := send(router, route.method, route.path, bearer(t, customerID))
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			assert.Contains(t, w.Body.String(), "ADMIN_ONLY")
+		})
+	}
+}
+
+func TestPromotionRoutes_AdminPassesThrough(t *testing.T) {
+	router := newPromotionRouter(t)
+
+	w := send(router, http.MethodPost, "/promotions", bearer(t, adminID))
+
+	// The create handler ran and rejected the empty body.
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestPromotionRoutes_QuoteIsForEveryUser(t *testing.T) {
+	router := newPromotionRouter(t)
+
+	w := send(router, http.MethodPost, "/promotions/quote", bearer(t, customerID))
+
+	assert.NotEqual(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
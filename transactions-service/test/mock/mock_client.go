@@ -0,0 +1,134 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: transactions-service/internal/client (interfaces: BookClient,UserClient)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	client "transactions-service/internal/client"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBookClient is a mock of BookClient interface.
+type MockBookClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookClientMockRecorder
+}
+
+// MockBookClientMockRecorder is the mock recorder for MockBookClient.
+type MockBookClientMockRecorder struct {
+	mock *MockBookClient
+}
+
+// NewMockBookClient creates a new mock instance.
+func NewMockBookClient(ctrl *gomock.Controller) *MockBookClient {
+	mock := &MockBookClient{ctrl: ctrl}
+	mock.recorder = &MockBookClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookClient) EXPECT() *MockBookClientMockRecorder {
+	return m.recorder
+}
+
+// FindByIDs mocks base method.
+func (m *MockBookClient) FindByIDs(arg0 context.Context, arg1 []uint) ([]client.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]client.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockBookClientMockRecorder) FindByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockBookClient)(nil).FindByIDs), arg0, arg1)
+}
+
+// ReleaseStock mocks base method.
+func (m *MockBookClient) ReleaseStock(arg0 context.Context, arg1 uint, arg2 int) (*client.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseStock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*client.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseStock indicates an expected call of ReleaseStock.
+func (mr *MockBookClientMockRecorder) ReleaseStock(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseStock", reflect.TypeOf((*MockBookClient)(nil).ReleaseStock), arg0, arg1, arg2)
+}
+
+// ReserveStock mocks base method.
+func (m *MockBookClient) ReserveStock(arg0 context.Context, arg1 uint, arg2 int) (*client.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveStock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*client.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveStock indicates an expected call of ReserveStock.
+func (mr *MockBookClientMockRecorder) ReserveStock(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveStock", reflect.TypeOf((*MockBookClient)(nil).ReserveStock), arg0, arg1, arg2)
+}
+
+// ReserveStocks mocks base method.
+func (m *MockBookClient) ReserveStocks(arg0 context.Context, arg1 map[uint]int) ([]client.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveStocks", arg0, arg1)
+	ret0, _ := ret[0].([]client.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveStocks indicates an expected call of ReserveStocks.
+func (mr *MockBookClientMockRecorder) ReserveStocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveStocks", reflect.TypeOf((*MockBookClient)(nil).ReserveStocks), arg0, arg1)
+}
+
+// MockUserClient is a mock of UserClient interface.
+type MockUserClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserClientMockRecorder
+}
+
+// MockUserClientMockRecorder is the mock recorder for MockUserClient.
+type MockUserClientMockRecorder struct {
+	mock *MockUserClient
+}
+
+// NewMockUserClient creates a new mock instance.
+func NewMockUserClient(ctrl *gomock.Controller) *MockUserClient {
+	mock := &MockUserClient{ctrl: ctrl}
+	mock.recorder = &MockUserClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserClient) EXPECT() *MockUserClientMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockUserClient) FindByID(arg0 context.Context, arg1 uint) (*client.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*client.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockUserClientMockRecorder) FindByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserClient)(nil).FindByID), arg0, arg1)
+}
@@ -0,0 +1,493 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: transactions-service/internal (interfaces: TransactionRepository,CartRepository,PromotionRepository,LoanRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	internal "transactions-service/internal"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTransactionRepository is a mock of TransactionRepository interface.
+type MockTransactionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionRepositoryMockRecorder
+}
+
+// MockTransactionRepositoryMockRecorder is the mock recorder for MockTransactionRepository.
+type MockTransactionRepositoryMockRecorder struct {
+	mock *MockTransactionRepository
+}
+
+// NewMockTransactionRepository creates a new mock instance.
+func NewMockTransactionRepository(ctrl *gomock.Controller) *MockTransactionRepository {
+	mock := &MockTransactionRepository{ctrl: ctrl}
+	mock.recorder = &MockTransactionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionRepository) EXPECT() *MockTransactionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Checkout mocks base method.
+func (m *MockTransactionRepository) Checkout(arg0 context.Context, arg1 uint, arg2 []internal.Transaction, arg3 []uint, arg4 uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockTransactionRepositoryMockRecorder) Checkout(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockTransactionRepository)(nil).Checkout), arg0, arg1, arg2, arg3, arg4)
+}
+
+// FindByUserID mocks base method.
+func (m *MockTransactionRepository) FindByUserID(arg0 context.Context, arg1 uint, arg2, arg3 int) ([]internal.Transaction, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]internal.Transaction)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockTransactionRepositoryMockRecorder) FindByUserID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockTransactionRepository)(nil).FindByUserID), arg0, arg1, arg2, arg3)
+}
+
+// MockCartRepository is a mock of CartRepository interface.
+type MockCartRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartRepositoryMockRecorder
+}
+
+// MockCartRepositoryMockRecorder is the mock recorder for MockCartRepository.
+type MockCartRepositoryMockRecorder struct {
+	mock *MockCartRepository
+}
+
+// NewMockCartRepository creates a new mock instance.
+func NewMockCartRepository(ctrl *gomock.Controller) *MockCartRepository {
+	mock := &MockCartRepository{ctrl: ctrl}
+	mock.recorder = &MockCartRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCartRepository) EXPECT() *MockCartRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *MockCartRepository) Clear(arg0 context.Context, arg1 uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockCartRepositoryMockRecorder) Clear(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockCartRepository)(nil).Clear), arg0, arg1)
+}
+
+// Create mocks base method.
+func (m *MockCartRepository) Create(arg0 context.Context, arg1 *internal.Cart) (*internal.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*internal.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCartRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCartRepository)(nil).Create), arg0, arg1)
+}
+
+// DeleteIdleGuests mocks base method.
+func (m *MockCartRepository) DeleteIdleGuests(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIdleGuests", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteIdleGuests indicates an expected call of DeleteIdleGuests.
+func (mr *MockCartRepositoryMockRecorder) DeleteIdleGuests(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdleGuests", reflect.TypeOf((*MockCartRepository)(nil).DeleteIdleGuests), arg0, arg1)
+}
+
+// FindByToken mocks base method.
+func (m *MockCartRepository) FindByToken(arg0 context.Context, arg1 string) (*internal.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByToken", arg0, arg1)
+	ret0, _ := ret[0].(*internal.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByToken indicates an expected call of FindByToken.
+func (mr *MockCartRepositoryMockRecorder) FindByToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByToken", reflect.TypeOf((*MockCartRepository)(nil).FindByToken), arg0, arg1)
+}
+
+// FindOrCreateByUser mocks base method.
+func (m *MockCartRepository) FindOrCreateByUser(arg0 context.Context, arg1 uint) (*internal.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrCreateByUser", arg0, arg1)
+	ret0, _ := ret[0].(*internal.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrCreateByUser indicates an expected call of FindOrCreateByUser.
+func (mr *MockCartRepositoryMockRecorder) FindOrCreateByUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreateByUser", reflect.TypeOf((*MockCartRepository)(nil).FindOrCreateByUser), arg0, arg1)
+}
+
+// Merge mocks base method.
+func (m *MockCartRepository) Merge(arg0 context.Context, arg1, arg2 uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockCartRepositoryMockRecorder) Merge(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockCartRepository)(nil).Merge), arg0, arg1, arg2)
+}
+
+// RemoveItem mocks base method.
+func (m *MockCartRepository) RemoveItem(arg0 context.Context, arg1, arg2 uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveItem", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveItem indicates an expected call of RemoveItem.
+func (mr *MockCartRepositoryMockRecorder) RemoveItem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItem", reflect.TypeOf((*MockCartRepository)(nil).RemoveItem), arg0, arg1, arg2)
+}
+
+// SetItem mocks base method.
+func (m *MockCartRepository) SetItem(arg0 context.Context, arg1 internal.CartItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItem", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItem indicates an expected call of SetItem.
+func (mr *MockCartRepositoryMockRecorder) SetItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItem", reflect.TypeOf((*MockCartRepository)(nil).SetItem), arg0, arg1)
+}
+
+// MockPromotionRepository is a mock of PromotionRepository interface.
+type MockPromotionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionRepositoryMockRecorder
+}
+
+// MockPromotionRepositoryMockRecorder is the mock recorder for MockPromotionRepository.
+type MockPromotionRepositoryMockRecorder struct {
+	mock *MockPromotionRepository
+}
+
+// NewMockPromotionRepository creates a new mock instance.
+func NewMockPromotionRepository(ctrl *gomock.Controller) *MockPromotionRepository {
+	mock := &MockPromotionRepository{ctrl: ctrl}
+	mock.recorder = &MockPromotionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionRepository) EXPECT() *MockPromotionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPromotionRepository) Create(arg0 context.Context, arg1 *internal.Promotion) (*internal.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*internal.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPromotionRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromotionRepository)(nil).Create), arg0, arg1)
+}
+
+// Deactivate mocks base method.
+func (m *MockPromotionRepository) Deactivate(arg0 context.Context, arg1 uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deactivate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deactivate indicates an expected call of Deactivate.
+func (mr *MockPromotionRepositoryMockRecorder) Deactivate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deactivate", reflect.TypeOf((*MockPromotionRepository)(nil).Deactivate), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockPromotionRepository) FindAll(arg0 context.Context, arg1 *bool, arg2, arg3 int) ([]internal.Promotion, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]internal.Promotion)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPromotionRepositoryMockRecorder) FindAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPromotionRepository)(nil).FindAll), arg0, arg1, arg2, arg3)
+}
+
+// FindByCode mocks base method.
+func (m *MockPromotionRepository) FindByCode(arg0 context.Context, arg1 string) (*internal.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCode", arg0, arg1)
+	ret0, _ := ret[0].(*internal.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCode indicates an expected call of FindByCode.
+func (mr *MockPromotionRepositoryMockRecorder) FindByCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCode", reflect.TypeOf((*MockPromotionRepository)(nil).FindByCode), arg0, arg1)
+}
+
+// FindByID mocks base method.
+func (m *MockPromotionRepository) FindByID(arg0 context.Context, arg1 uint) (*internal.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*internal.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockPromotionRepositoryMockRecorder) FindByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPromotionRepository)(nil).FindByID), arg0, arg1)
+}
+
+// FindRunning mocks base method.
+func (m *MockPromotionRepository) FindRunning(arg0 context.Context, arg1 time.Time) ([]internal.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRunning", arg0, arg1)
+	ret0, _ := ret[0].([]internal.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRunning indicates an expected call of FindRunning.
+func (mr *MockPromotionRepositoryMockRecorder) FindRunning(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRunning", reflect.TypeOf((*MockPromotionRepository)(nil).FindRunning), arg0, arg1)
+}
+
+// Usage mocks base method.
+func (m *MockPromotionRepository) Usage(arg0 context.Context, arg1 uint, arg2 []uint) (map[uint]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Usage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[uint]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Usage indicates an expected call of Usage.
+func (mr *MockPromotionRepositoryMockRecorder) Usage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usage", reflect.TypeOf((*MockPromotionRepository)(nil).Usage), arg0, arg1, arg2)
+}
+
+// MockLoanRepository is a mock of LoanRepository interface.
+type MockLoanRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoanRepositoryMockRecorder
+}
+
+// MockLoanRepositoryMockRecorder is the mock recorder for MockLoanRepository.
+type MockLoanRepositoryMockRecorder struct {
+	mock *MockLoanRepository
+}
+
+// NewMockLoanRepository creates a new mock instance.
+func NewMockLoanRepository(ctrl *gomock.Controller) *MockLoanRepository {
+	mock := &MockLoanRepository{ctrl: ctrl}
+	mock.recorder = &MockLoanRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoanRepository) EXPECT() *MockLoanRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLoanRepository) Create(arg0 context.Context, arg1 *internal.Loan, arg2 int) (*internal.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*internal.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLoanRepositoryMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLoanRepository)(nil).Create), arg0, arg1, arg2)
+}
+
+// FindByID mocks base method.
+func (m *MockLoanRepository) FindByID(arg0 context.Context, arg1, arg2 uint) (*internal.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*internal.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockLoanRepositoryMockRecorder) FindByID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockLoanRepository)(nil).FindByID), arg0, arg1, arg2)
+}
+
+// FindByUserID mocks base method.
+func (m *MockLoanRepository) FindByUserID(arg0 context.Context, arg1 uint, arg2 string, arg3 time.Time, arg4, arg5 int) ([]internal.Loan, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]internal.Loan)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockLoanRepositoryMockRecorder) FindByUserID(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockLoanRepository)(nil).FindByUserID), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// FindOpen mocks base method.
+func (m *MockLoanRepository) FindOpen(arg0 context.Context, arg1 uint) ([]internal.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpen", arg0, arg1)
+	ret0, _ := ret[0].([]internal.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpen indicates an expected call of FindOpen.
+func (mr *MockLoanRepositoryMockRecorder) FindOpen(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpen", reflect.TypeOf((*MockLoanRepository)(nil).FindOpen), arg0, arg1)
+}
+
+// FindOutstanding mocks base method.
+func (m *MockLoanRepository) FindOutstanding(arg0 context.Context, arg1 uint, arg2 time.Time) ([]internal.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOutstanding", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]internal.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOutstanding indicates an expected call of FindOutstanding.
+func (mr *MockLoanRepositoryMockRecorder) FindOutstanding(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOutstanding", reflect.TypeOf((*MockLoanRepository)(nil).FindOutstanding), arg0, arg1, arg2)
+}
+
+// MarkOverdue mocks base method.
+func (m *MockLoanRepository) MarkOverdue(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOverdue", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOverdue indicates an expected call of MarkOverdue.
+func (mr *MockLoanRepositoryMockRecorder) MarkOverdue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOverdue", reflect.TypeOf((*MockLoanRepository)(nil).MarkOverdue), arg0, arg1)
+}
+
+// PayFine mocks base method.
+func (m *MockLoanRepository) PayFine(arg0 context.Context, arg1, arg2 uint, arg3 time.Time) (*internal.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayFine", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*internal.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayFine indicates an expected call of PayFine.
+func (mr *MockLoanRepositoryMockRecorder) PayFine(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayFine", reflect.TypeOf((*MockLoanRepository)(nil).PayFine), arg0, arg1, arg2, arg3)
+}
+
+// Renew mocks base method.
+func (m *MockLoanRepository) Renew(arg0 context.Context, arg1, arg2 uint, arg3 time.Duration, arg4 int, arg5 time.Time) (*internal.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*internal.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Renew indicates an expected call of Renew.
+func (mr *MockLoanRepositoryMockRecorder) Renew(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockLoanRepository)(nil).Renew), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// Return mocks base method.
+func (m *MockLoanRepository) Return(arg0 context.Context, arg1, arg2 uint, arg3 time.Time) (*internal.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Return", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*internal.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Return indicates an expected call of Return.
+func (mr *MockLoanRepositoryMockRecorder) Return(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Return", reflect.TypeOf((*MockLoanRepository)(nil).Return), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: transactions-service/internal (interfaces: PromotionService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	internal "transactions-service/internal"
+	dto "transactions-service/internal/api/dto"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPromotionService is a mock of PromotionService interface.
+type MockPromotionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionServiceMockRecorder
+}
+
+// MockPromotionServiceMockRecorder is the mock recorder for MockPromotionService.
+type MockPromotionServiceMockRecorder struct {
+	mock *MockPromotionService
+}
+
+// NewMockPromotionService creates a new mock instance.
+func NewMockPromotionService(ctrl *gomock.Controller) *MockPromotionService {
+	mock := &MockPromotionService{ctrl: ctrl}
+	mock.recorder = &MockPromotionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionService) EXPECT() *MockPromotionServiceMockRecorder {
+	return m.recorder
+}
+
+// Candidates mocks base method.
+func (m *MockPromotionService) Candidates(arg0 context.Context, arg1 uint, arg2 string) ([]internal.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Candidates", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]internal.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Candidates indicates an expected call of Candidates.
+func (mr *MockPromotionServiceMockRecorder) Candidates(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Candidates", reflect.TypeOf((*MockPromotionService)(nil).Candidates), arg0, arg1, arg2)
+}
+
+// Create mocks base method.
+func (m *MockPromotionService) Create(arg0 context.Context, arg1 dto.PromotionRequest) (*dto.PromotionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*dto.PromotionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPromotionServiceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromotionService)(nil).Create), arg0, arg1)
+}
+
+// Deactivate mocks base method.
+func (m *MockPromotionService) Deactivate(arg0 context.Context, arg1 uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deactivate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deactivate indicates an expected call of Deactivate.
+func (mr *MockPromotionServiceMockRecorder) Deactivate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deactivate", reflect.TypeOf((*MockPromotionService)(nil).Deactivate), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockPromotionService) FindAll(arg0 context.Context, arg1 dto.PromotionListRequest) (*dto.PromotionListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
+	ret0, _ := ret[0].(*dto.PromotionListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPromotionServiceMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPromotionService)(nil).FindAll), arg0, arg1)
+}
+
+// FindByID mocks base method.
+func (m *MockPromotionService) FindByID(arg0 context.Context, arg1 uint) (*dto.PromotionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*dto.PromotionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockPromotionServiceMockRecorder) FindByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPromotionService)(nil).FindByID), arg0, arg1)
+}
+
+// Quote mocks base method.
+func (m *MockPromotionService) Quote(arg0 context.Context, arg1 uint, arg2 dto.QuoteRequest) (*dto.QuoteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quote", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.QuoteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quote indicates an expected call of Quote.
+func (mr *MockPromotionServiceMockRecorder) Quote(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quote", reflect.TypeOf((*MockPromotionService)(nil).Quote), arg0, arg1, arg2)
+}
@@ -0,0 +1,34 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"transactions-service/internal"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCartRepository_Merge(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repository := internal.NewCartRepository(db)
+
+	// A book in both carts keeps the user cart's price and adds up its
+	// quantities, capped at MaxCartQuantity.
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`ON CONFLICT (cart_id, book_id) DO UPDATE SET
+				quantity = LEAST(cart_items.quantity + EXCLUDED.quantity, $4),
+				modified_at = EXCLUDED.modified_at`)).
+		WithArgs(2, sqlmock.AnyArg(), 1, internal.MaxCartQuantity).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "carts" WHERE "carts"."id" = $1`)).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "carts" SET "modified_at"=$1 WHERE id = $2`)).WithArgs(sqlmock.AnyArg(), 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repository.Merge(context.Background(), 1, 2)
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,55 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+	"transactions-service/internal"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	lockUserLoans  = regexp.QuoteMeta(`SELECT pg_advisory_xact_lock(hashtextextended('loans:' || CAST($1 AS TEXT), 0))`)
+	countOpenLoans = regexp.QuoteMeta(`SELECT count(*) FROM "loans" WHERE user_id = $1 AND returned_at IS NULL`)
+)
+
+func loan() *internal.Loan {
+	now := time.Now()
+	return &internal.Loan{UserID: 7, BookID: 1, Status: internal.LoanActive, BorrowedAt: now, DueAt: now.Add(14 * 24 * time.Hour), DailyFine: 1000, Currency: "IDR"}
+}
+
+func TestLoanRepository_CreateCountsOpenLoansUnderLock(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repository := internal.NewLoanRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(lockUserLoans).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(countOpenLoans).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "loans"`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	created, err := repository.Create(context.Background(), loan(), 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), created.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoanRepository_CreateAtLimit(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repository := internal.NewLoanRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(lockUserLoans).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(countOpenLoans).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectRollback()
+
+	_, err := repository.Create(context.Background(), loan(), 2)
+
+	assert.ErrorIs(t, err, internal.ErrOpenLoanLimit)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
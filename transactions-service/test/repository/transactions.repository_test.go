@@ -0,0 +1,96 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"transactions-service/internal"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	dialector := postgres.New(postgres.Config{
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		Conn:                 db,
+		PreferSimpleProtocol: true,
+	})
+
+	gormDB, err := gorm.Open(dialector, &gorm.Config{})
+	require.NoError(t, err)
+
+	return gormDB, mock
+}
+
+var (
+	redeemPromotion = regexp.QuoteMeta(`UPDATE "promotions" SET "redemptions"=redemptions + 1`)
+	findPromotion   = regexp.QuoteMeta(`SELECT "id","max_per_user" FROM "promotions"`)
+	countUsage      = regexp.QuoteMeta(`INSERT INTO "promotion_usages"`)
+)
+
+func transactions() []internal.Transaction {
+	return []internal.Transaction{{BookID: 1, UserID: 7, Quantity: 2, UnitPrice: 50000, Currency: "IDR", Discount: 10000}}
+}
+
+func TestTransactionRepository_CheckoutRedeemsPromotions(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repository := internal.NewTransactionRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(redeemPromotion).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(findPromotion).WillReturnRows(sqlmock.NewRows([]string{"id", "max_per_user"}).AddRow(1, 2))
+	mock.ExpectQuery(countUsage).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transactions"`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	err := repository.Checkout(context.Background(), 7, transactions(), []uint{1}, 0)
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionRepository_CheckoutOverGlobalCap(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repository := internal.NewTransactionRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(redeemPromotion).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repository.Checkout(context.Background(), 7, transactions(), []uint{1}, 0)
+
+	var capErr *internal.CapError
+	require.ErrorAs(t, err, &capErr)
+	assert.Equal(t, uint(1), capErr.PromotionID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionRepository_CheckoutOverUserCap(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repository := internal.NewTransactionRepository(db)
+
+	// Promotions are redeemed in id order; the second is over its cap for
+	// this user, so nothing is stored.
+	mock.ExpectBegin()
+	mock.ExpectExec(redeemPromotion).WithArgs(sqlmock.AnyArg(), 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(findPromotion).WillReturnRows(sqlmock.NewRows([]string{"id", "max_per_user"}).AddRow(2, nil))
+	mock.ExpectQuery(countUsage).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+	mock.ExpectExec(redeemPromotion).WithArgs(sqlmock.AnyArg(), 5).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(findPromotion).WillReturnRows(sqlmock.NewRows([]string{"id", "max_per_user"}).AddRow(5, 1))
+	mock.ExpectQuery(countUsage).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectRollback()
+
+	err := repository.Checkout(context.Background(), 7, transactions(), []uint{5, 2}, 0)
+
+	var capErr *internal.CapError
+	require.ErrorAs(t, err, &capErr)
+	assert.Equal(t, uint(5), capErr.PromotionID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,240 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"transactions-service/internal"
+	"transactions-service/internal/api/dto"
+	"transactions-service/internal/client"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func item(cartID, bookID uint, quantity int, unitPrice int64) internal.CartItem {
+	return internal.CartItem{CartID: cartID, BookID: bookID, Quantity: quantity, UnitPrice: unitPrice, Currency: "IDR"}
+}
+
+func userCart(items ...internal.CartItem) *internal.Cart {
+	userID := uint(7)
+	return &internal.Cart{ID: 2, UserID: &userID, Items: items}
+}
+
+func TestCartService_FindMergesGuestCart(t *testing.T) {
+	d := setup(t)
+	token := "guest-token"
+	guest := &internal.Cart{ID: 1, Token: &token, Items: []internal.CartItem{item(1, 1, 60, 40000)}}
+	d.carts.EXPECT().FindByToken(gomock.Any(), token).Return(guest, nil)
+	gomock.InOrder(
+		d.carts.EXPECT().FindOrCreateByUser(gomock.Any(), uint(7)).Return(userCart(item(2, 1, 50, 45000)), nil),
+		d.carts.EXPECT().Merge(gomock.Any(), uint(1), uint(2)).Return(nil),
+		d.carts.EXPECT().FindOrCreateByUser(gomock.Any(), uint(7)).Return(userCart(item(2, 1, internal.MaxCartQuantity, 45000)), nil),
+	)
+	d.books.EXPECT().FindByIDs(gomock.Any(), []uint{1}).Return([]client.Book{{ID: 1, Title: "Dune", Price: idr(45000), Stock: 200}}, nil)
+
+	response, err := d.cartService().Find(context.Background(), internal.CartOwner{UserID: 7, Token: token})
+
+	require.NoError(t, err)
+	assert.Empty(t, response.Token, "the merged cart belongs to the user")
+	require.Len(t, response.Items, 1)
+	assert.Equal(t, internal.MaxCartQuantity, response.Items[0].Quantity)
+	assert.Equal(t, idr(45000), response.Items[0].UnitPrice)
+	assert.Nil(t, response.Items[0].AddedPrice)
+	assert.Empty(t, response.Items[0].Problem)
+}
+
+func TestCartService_FindGuestCartGone(t *testing.T) {
+	d := setup(t)
+	d.carts.EXPECT().FindByToken(gomock.Any(), "expired").Return(nil, gorm.ErrRecordNotFound)
+
+	response, err := d.cartService().Find(context.Background(), internal.CartOwner{Token: "expired"})
+
+	require.NoError(t, err)
+	assert.Empty(t, response.Items)
+	assert.Nil(t, response.Subtotal)
+}
+
+func TestCartService_AddItemCreatesGuestCart(t *testing.T) {
+	d := setup(t)
+	d.carts.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, cart *internal.Cart) (*internal.Cart, error) {
+		require.NotNil(t, cart.Token)
+		assert.NotEmpty(t, *cart.Token)
+		cart.ID = 1
+		return cart, nil
+	})
+	d.books.EXPECT().FindByIDs(gomock.Any(), []uint{1}).Return([]client.Book{{ID: 1, Price: idr(50000), Stock: 5}}, nil).Times(2)
+	d.carts.EXPECT().SetItem(gomock.Any(), item(1, 1, 2, 50000)).Return(nil)
+
+	response, err := d.cartService().AddItem(context.Background(), internal.CartOwner{}, dto.CartItemRequest{BookID: 1, Quantity: 2})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.Token)
+	require.Len(t, response.Items, 1)
+	assert.Equal(t, idr(100000), response.Items[0].Total)
+	require.NotNil(t, response.Subtotal)
+	assert.Equal(t, idr(100000), *response.Subtotal)
+}
+
+func TestCartService_AddItemLimits(t *testing.T) {
+	full := make([]internal.CartItem, internal.MaxCartItems)
+	for i := range full {
+		full[i] = item(2, uint(i+10), 1, 10000)
+	}
+
+	tests := []struct {
+		name  string
+		cart  *internal.Cart
+		books []client.Book
+		err   error
+	}{
+		{"adds up past the quantity cap", userCart(item(2, 1, internal.MaxCartQuantity-1, 50000)), nil, internal.ErrCartQuantity},
+		{"no room for another book", userCart(full...), nil, internal.ErrCartFull},
+		{"book does not exist", userCart(), []client.Book{}, internal.ErrBookNotFound},
+		{"not enough stock", userCart(item(2, 1, 3, 50000)), []client.Book{{ID: 1, Price: idr(50000), Stock: 4}}, internal.ErrOutOfStock},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := setup(t)
+			d.carts.EXPECT().FindOrCreateByUser(gomock.Any(), uint(7)).Return(tt.cart, nil)
+			if tt.books != nil {
+				d.books.EXPECT().FindByIDs(gomock.Any(), []uint{1}).Return(tt.books, nil)
+			}
+
+			_, err := d.cartService().AddItem(context.Background(), internal.CartOwner{UserID: 7}, dto.CartItemRequest{BookID: 1, Quantity: 2})
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestCartService_UpdateItemTakesCurrentPrice(t *testing.T) {
+	d := setup(t)
+	d.carts.EXPECT().FindOrCreateByUser(gomock.Any(), uint(7)).Return(userCart(item(2, 1, 1, 40000)), nil)
+	d.books.EXPECT().FindByIDs(gomock.Any(), []uint{1}).Return([]client.Book{{ID: 1, Price: idr(45000), Stock: 5}}, nil).Times(2)
+	d.carts.EXPECT().SetItem(gomock.Any(), item(2, 1, 3, 45000)).Return(nil)
+
+	response, err := d.cartService().UpdateItem(context.Background(), internal.CartOwner{UserID: 7}, 1, dto.CartQuantityRequest{Quantity: 3})
+
+	require.NoError(t, err)
+	require.Len(t, response.Items, 1)
+	assert.Equal(t, 3, response.Items[0].Quantity)
+	assert.Nil(t, response.Items[0].AddedPrice, "the item was stored at the price shown")
+}
+
+func TestCartService_FindPointsOutProblems(t *testing.T) {
+	d := setup(t)
+	d.carts.EXPECT().FindOrCreateByUser(gomock.Any(), uint(7)).Return(userCart(
+		item(2, 1, 2, 50000),
+		item(2, 2, 5, 30000),
+		item(2, 3, 1, 20000),
+		item(2, 4, 1, 10000),
+	), nil)
+	d.books.EXPECT().FindByIDs(gomock.Any(), []uint{1, 2, 3, 4}).Return([]client.Book{
+		{ID: 1, Price: idr(55000), Stock: 5},
+		{ID: 2, Price: idr(30000), Stock: 3},
+		{ID: 4, Price: idr(10000), Stock: 1},
+	}, nil)
+
+	response, err := d.cartService().Find(context.Background(), internal.CartOwner{UserID: 7})
+
+	require.NoError(t, err)
+	require.Len(t, response.Items, 4)
+	assert.Equal(t, internal.CartItemRepriced, response.Items[0].Problem)
+	assert.Equal(t, idr(55000), response.Items[0].UnitPrice)
+	require.NotNil(t, response.Items[0].AddedPrice)
+	assert.Equal(t, idr(50000), *response.Items[0].AddedPrice)
+	assert.Equal(t, internal.CartItemOutOfStock, response.Items[1].Problem)
+	assert.Equal(t, internal.CartItemUnavailable, response.Items[2].Problem)
+	assert.Empty(t, response.Items[3].Problem)
+}
+
+func TestTransactionService_CheckoutEmptyCart(t *testing.T) {
+	d := setup(t)
+	d.users.EXPECT().FindByID(gomock.Any(), uint(7)).Return(&client.User{ID: 7}, nil)
+	d.carts.EXPECT().FindOrCreateByUser(gomock.Any(), uint(7)).Return(userCart(), nil)
+
+	_, err := d.transactionService().Checkout(context.Background(), internal.CartOwner{UserID: 7}, dto.CheckoutRequest{})
+
+	assert.ErrorIs(t, err, internal.ErrCartEmpty)
+}
+
+func TestTransactionService_CheckoutListsChangedItems(t *testing.T) {
+	d := setup(t)
+	d.users.EXPECT().FindByID(gomock.Any(), uint(7)).Return(&client.User{ID: 7}, nil)
+	d.carts.EXPECT().FindOrCreateByUser(gomock.Any(), uint(7)).Return(userCart(
+		item(2, 1, 2, 50000),
+		item(2, 2, 5, 30000),
+		item(2, 3, 1, 20000),
+		item(2, 4, 1, 10000),
+	), nil)
+	d.books.EXPECT().FindByIDs(gomock.Any(), []uint{1, 2, 3, 4}).Return([]client.Book{
+		{ID: 1, Price: idr(55000), Stock: 5},
+		{ID: 2, Price: idr(30000), Stock: 3},
+		{ID: 4, Price: idr(10000), Stock: 1},
+	}, nil)
+	// The new price is stored so the next checkout goes through at it.
+	d.carts.EXPECT().SetItem(gomock.Any(), item(2, 1, 2, 55000)).Return(nil)
+
+	_, err := d.transactionService().Checkout(context.Background(), internal.CartOwner{UserID: 7}, dto.CheckoutRequest{})
+
+	require.ErrorIs(t, err, internal.ErrCartChanged)
+	assert.Equal(t, apperror.KindConflict, apperror.KindOf(err))
+	fields := map[string]string{}
+	for _, field := range apperror.From(err).Fields {
+		fields[field.Field] = field.Code
+	}
+	assert.Equal(t, map[string]string{
+		"items.1": internal.CartItemRepriced,
+		"items.2": internal.CartItemOutOfStock,
+		"items.3": internal.CartItemUnavailable,
+	}, fields)
+}
+
+func TestTransactionService_CheckoutReleasesStockWhenNotStored(t *testing.T) {
+	d := setup(t)
+	d.users.EXPECT().FindByID(gomock.Any(), uint(7)).Return(&client.User{ID: 7}, nil)
+	d.carts.EXPECT().FindOrCreateByUser(gomock.Any(), uint(7)).Return(userCart(item(2, 1, 2, 50000), item(2, 2, 1, 30000)), nil)
+	d.books.EXPECT().FindByIDs(gomock.Any(), []uint{1, 2}).Return([]client.Book{
+		{ID: 1, Price: idr(50000), Stock: 5},
+		{ID: 2, Price: idr(30000), Stock: 5},
+	}, nil)
+	d.candidates.EXPECT().Candidates(gomock.Any(), uint(7), "").Return(nil, nil)
+	d.books.EXPECT().ReserveStocks(gomock.Any(), map[uint]int{1: 2, 2: 1}).Return([]client.Book{
+		{ID: 1, Price: idr(50000), Stock: 3},
+		{ID: 2, Price: idr(30000), Stock: 4},
+	}, nil)
+	d.transactions.EXPECT().Checkout(gomock.Any(), uint(7), gomock.Any(), []uint{}, uint(2)).Return(errors.New("connection reset"))
+	d.books.EXPECT().ReleaseStock(gomock.Any(), uint(1), 2).Return(&client.Book{ID: 1, Stock: 5}, nil)
+	d.books.EXPECT().ReleaseStock(gomock.Any(), uint(2), 1).Return(&client.Book{ID: 2, Stock: 5}, nil)
+
+	_, err := d.transactionService().Checkout(context.Background(), internal.CartOwner{UserID: 7}, dto.CheckoutRequest{})
+
+	assert.Equal(t, apperror.KindInternal, apperror.KindOf(err))
+}
+
+func TestCartCleanupWorker(t *testing.T) {
+	d := setup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ttl := time.Hour
+	d.carts.EXPECT().DeleteIdleGuests(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, before time.Time) (int64, error) {
+		assert.WithinDuration(t, time.Now().Add(-ttl), before, time.Second)
+		cancel()
+		return 3, nil
+	})
+
+	done := make(chan error, 1)
+	go func() { done <- internal.CartCleanupWorker(d.carts, ttl, 10*time.Millisecond)(ctx) }()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop when its context was cancelled")
+	}
+}
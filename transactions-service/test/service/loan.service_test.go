@@ -0,0 +1,208 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"transactions-service/internal"
+	"transactions-service/internal/api/dto"
+	"transactions-service/internal/client"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func policy() internal.LoanPolicy {
+	return internal.LoanPolicy{Period: 14 * 24 * time.Hour, MaxRenewals: 2, MaxActive: 2, DailyFine: idr(1000)}
+}
+
+func TestLoan_FineAt(t *testing.T) {
+	due := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	returned := due.Add(72 * time.Hour)
+
+	tests := []struct {
+		name string
+		loan internal.Loan
+		now  time.Time
+		fine int64
+	}{
+		{"before the due date", internal.Loan{DueAt: due, DailyFine: 1000}, due.Add(-time.Hour), 0},
+		{"at the due instant", internal.Loan{DueAt: due, DailyFine: 1000}, due, 0},
+		{"a moment late", internal.Loan{DueAt: due, DailyFine: 1000}, due.Add(time.Second), 1000},
+		{"a whole day late", internal.Loan{DueAt: due, DailyFine: 1000}, due.Add(24 * time.Hour), 1000},
+		{"part of a second day", internal.Loan{DueAt: due, DailyFine: 1000}, due.Add(25 * time.Hour), 2000},
+		{"returned keeps its fine", internal.Loan{DueAt: due, DailyFine: 1000, ReturnedAt: &returned, Fine: 3000}, due.Add(30 * 24 * time.Hour), 3000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.fine, tt.loan.FineAt(tt.now))
+		})
+	}
+}
+
+func TestLoanService_BorrowRefusals(t *testing.T) {
+	overdue := internal.Loan{ID: 3, UserID: 7, BookID: 2, DueAt: time.Now().Add(-time.Hour)}
+
+	tests := []struct {
+		name        string
+		open        []internal.Loan
+		outstanding []internal.Loan
+		err         error
+	}{
+		{"already borrowing the book", []internal.Loan{{ID: 1, UserID: 7, BookID: 1}}, nil, internal.ErrAlreadyBorrowed},
+		{"at the loan limit", []internal.Loan{{ID: 1, UserID: 7, BookID: 2}, {ID: 2, UserID: 7, BookID: 3}}, nil, internal.ErrLoanLimit},
+		{"overdue books or unpaid fines", []internal.Loan{overdue}, []internal.Loan{overdue}, internal.ErrLoansOutstanding},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := setup(t)
+			d.users.EXPECT().FindByID(gomock.Any(), uint(7)).Return(&client.User{ID: 7}, nil)
+			d.loans.EXPECT().FindOpen(gomock.Any(), uint(7)).Return(tt.open, nil)
+			if tt.outstanding != nil {
+				d.loans.EXPECT().FindOutstanding(gomock.Any(), uint(7), gomock.Any()).Return(tt.outstanding, nil)
+			}
+
+			_, err := d.loanService(policy()).Borrow(context.Background(), 7, dto.BorrowRequest{BookID: 1})
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestLoanService_Borrow(t *testing.T) {
+	d := setup(t)
+	d.users.EXPECT().FindByID(gomock.Any(), uint(7)).Return(&client.User{ID: 7}, nil)
+	d.loans.EXPECT().FindOpen(gomock.Any(), uint(7)).Return(nil, nil)
+	d.loans.EXPECT().FindOutstanding(gomock.Any(), uint(7), gomock.Any()).Return(nil, nil)
+	d.books.EXPECT().ReserveStock(gomock.Any(), uint(1), 1).Return(&client.Book{ID: 1, Stock: 2}, nil)
+	d.loans.EXPECT().Create(gomock.Any(), gomock.Any(), 2).DoAndReturn(func(ctx context.Context, loan *internal.Loan, maxActive int) (*internal.Loan, error) {
+		loan.ID = 1
+		return loan, nil
+	})
+
+	response, err := d.loanService(policy()).Borrow(context.Background(), 7, dto.BorrowRequest{BookID: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, internal.LoanActive, response.Status)
+	assert.Equal(t, 14*24*time.Hour, response.DueAt.Sub(response.BorrowedAt))
+	assert.Equal(t, 2, response.RenewalsLeft)
+	assert.Equal(t, idr(0), response.Fine)
+}
+
+func TestLoanService_BorrowReleasesCopyWhenNotStored(t *testing.T) {
+	tests := []struct {
+		name   string
+		stored error
+		err    error
+	}{
+		// Another borrow by the same user got in between the checks and
+		// the insert.
+		{"limit reached meanwhile", internal.ErrOpenLoanLimit, internal.ErrLoanLimit},
+		{"same book borrowed meanwhile", gorm.ErrDuplicatedKey, internal.ErrAlreadyBorrowed},
+		{"database down", errors.New("connection reset"), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := setup(t)
+			d.users.EXPECT().FindByID(gomock.Any(), uint(7)).Return(&client.User{ID: 7}, nil)
+			d.loans.EXPECT().FindOpen(gomock.Any(), uint(7)).Return(nil, nil)
+			d.loans.EXPECT().FindOutstanding(gomock.Any(), uint(7), gomock.Any()).Return(nil, nil)
+			d.books.EXPECT().ReserveStock(gomock.Any(), uint(1), 1).Return(&client.Book{ID: 1, Stock: 2}, nil)
+			d.loans.EXPECT().Create(gomock.Any(), gomock.Any(), 2).Return(nil, tt.stored)
+			d.books.EXPECT().ReleaseStock(gomock.Any(), uint(1), 1).Return(&client.Book{ID: 1, Stock: 3}, nil)
+
+			_, err := d.loanService(policy()).Borrow(context.Background(), 7, dto.BorrowRequest{BookID: 1})
+
+			require.Error(t, err)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestLoanService_RenewRefusals(t *testing.T) {
+	returnedAt := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name string
+		loan *internal.Loan
+		err  error
+	}{
+		{"no such loan", nil, internal.ErrLoanNotFound},
+		{"returned", &internal.Loan{ID: 1, DueAt: time.Now().Add(time.Hour), ReturnedAt: &returnedAt}, internal.ErrLoanReturned},
+		{"overdue", &internal.Loan{ID: 1, DueAt: time.Now().Add(-time.Hour)}, internal.ErrLoanOverdue},
+		{"out of renewals", &internal.Loan{ID: 1, DueAt: time.Now().Add(time.Hour), Renewals: 2}, internal.ErrRenewalLimit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := setup(t)
+			d.loans.EXPECT().Renew(gomock.Any(), uint(1), uint(7), 14*24*time.Hour, 2, gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+			if tt.loan != nil {
+				d.loans.EXPECT().FindByID(gomock.Any(), uint(1), uint(7)).Return(tt.loan, nil)
+			} else {
+				d.loans.EXPECT().FindByID(gomock.Any(), uint(1), uint(7)).Return(nil, gorm.ErrRecordNotFound)
+			}
+
+			_, err := d.loanService(policy()).Renew(context.Background(), 7, 1)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestLoanService_ReturnRefusals(t *testing.T) {
+	returnedAt := time.Now().Add(-time.Hour)
+	d := setup(t)
+	d.loans.EXPECT().Return(gomock.Any(), uint(1), uint(7), gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+	d.loans.EXPECT().FindByID(gomock.Any(), uint(1), uint(7)).Return(&internal.Loan{ID: 1, ReturnedAt: &returnedAt}, nil)
+	d.loans.EXPECT().Return(gomock.Any(), uint(2), uint(7), gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+	d.loans.EXPECT().FindByID(gomock.Any(), uint(2), uint(7)).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := d.loanService(policy()).Return(context.Background(), 7, 1)
+	assert.ErrorIs(t, err, internal.ErrLoanReturned)
+
+	_, err = d.loanService(policy()).Return(context.Background(), 7, 2)
+	assert.ErrorIs(t, err, internal.ErrLoanNotFound)
+}
+
+func TestLoanService_ReturnReleasesCopy(t *testing.T) {
+	d := setup(t)
+	now := time.Now()
+	d.loans.EXPECT().Return(gomock.Any(), uint(1), uint(7), gomock.Any()).Return(&internal.Loan{
+		ID: 1, UserID: 7, BookID: 4, Status: internal.LoanReturned, DueAt: now.Add(-48 * time.Hour), ReturnedAt: &now, Fine: 2000, Currency: "IDR",
+	}, nil)
+	d.books.EXPECT().ReleaseStock(gomock.Any(), uint(4), 1).Return(&client.Book{ID: 4, Stock: 1}, nil)
+
+	response, err := d.loanService(policy()).Return(context.Background(), 7, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, internal.LoanReturned, response.Status)
+	assert.Equal(t, idr(2000), response.Fine)
+}
+
+func TestLoanOverdueWorker(t *testing.T) {
+	d := setup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gomock.InOrder(
+		d.loans.EXPECT().MarkOverdue(gomock.Any(), gomock.Any()).Return(int64(2), nil),
+		d.loans.EXPECT().MarkOverdue(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, now time.Time) (int64, error) {
+			cancel()
+			return 0, ctx.Err()
+		}),
+	)
+
+	done := make(chan error, 1)
+	go func() { done <- internal.LoanOverdueWorker(d.loans, 10*time.Millisecond)(ctx) }()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop when its context was cancelled")
+	}
+}
@@ -0,0 +1,208 @@
+package service_test
+
+import (
+	"testing"
+	"transactions-service/internal"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func line(bookID uint, quantity int, unitPrice int64) internal.CartLine {
+	return internal.CartLine{BookID: bookID, Quantity: quantity, UnitPrice: idr(unitPrice)}
+}
+
+func percentage(id uint, percentOff int) internal.Promotion {
+	return internal.Promotion{ID: id, Name: "Percentage", Kind: internal.PromotionPercentage, PercentOff: percentOff, Currency: "IDR", Active: true}
+}
+
+func fixed(id uint, amountOff int64, currency string) internal.Promotion {
+	return internal.Promotion{ID: id, Name: "Fixed", Kind: internal.PromotionFixed, AmountOff: amountOff, Currency: currency, Active: true}
+}
+
+func TestEvaluate(t *testing.T) {
+	// Two copies at 50000 and one at 30000, 130000 in all.
+	cart := []internal.CartLine{line(1, 2, 50000), line(2, 1, 30000)}
+	bookTwo := uint(2)
+
+	tests := []struct {
+		name       string
+		cart       []internal.CartLine
+		promotions func() []internal.Promotion
+		lines      []int64
+		applied    []uint
+	}{
+		{
+			name:       "percentage",
+			cart:       cart,
+			promotions: func() []internal.Promotion { return []internal.Promotion{percentage(1, 10)} },
+			lines:      []int64{10000, 3000},
+			applied:    []uint{1},
+		},
+		{
+			name: "percentage on one book",
+			cart: cart,
+			promotions: func() []internal.Promotion {
+				promotion := percentage(1, 20)
+				promotion.BookID = &bookTwo
+				return []internal.Promotion{promotion}
+			},
+			lines:   []int64{0, 6000},
+			applied: []uint{1},
+		},
+		{
+			name:       "fixed spreads over lines",
+			cart:       cart,
+			promotions: func() []internal.Promotion { return []internal.Promotion{fixed(1, 120000, "IDR")} },
+			lines:      []int64{100000, 20000},
+			applied:    []uint{1},
+		},
+		{
+			name:       "fixed never goes below zero",
+			cart:       cart,
+			promotions: func() []internal.Promotion { return []internal.Promotion{fixed(1, 500000, "IDR")} },
+			lines:      []int64{100000, 30000},
+			applied:    []uint{1},
+		},
+		{
+			name: "buy two get one",
+			cart: []internal.CartLine{line(1, 7, 10000), line(2, 2, 30000)},
+			promotions: func() []internal.Promotion {
+				return []internal.Promotion{{ID: 1, Kind: internal.PromotionBuyXGetY, BuyQuantity: 2, GetQuantity: 1, Currency: "IDR", Active: true}}
+			},
+			lines:   []int64{20000, 0},
+			applied: []uint{1},
+		},
+		{
+			name: "higher priority applies first",
+			cart: cart,
+			promotions: func() []internal.Promotion {
+				half, off := percentage(1, 50), fixed(2, 10000, "IDR")
+				half.Priority, off.Priority = 1, 5
+				return []internal.Promotion{half, off}
+			},
+			lines:   []int64{10000 + 45000, 15000},
+			applied: []uint{2, 1},
+		},
+		{
+			name: "equal priority goes by id",
+			cart: cart,
+			promotions: func() []internal.Promotion {
+				return []internal.Promotion{percentage(2, 50), fixed(1, 100000, "IDR")}
+			},
+			lines:   []int64{100000, 15000},
+			applied: []uint{1, 2},
+		},
+		{
+			name: "minimum subtotal not met",
+			cart: cart,
+			promotions: func() []internal.Promotion {
+				promotion := percentage(1, 10)
+				promotion.MinSubtotal = 130001
+				return []internal.Promotion{promotion}
+			},
+			lines: []int64{0, 0},
+		},
+		{
+			name: "minimum subtotal met",
+			cart: cart,
+			promotions: func() []internal.Promotion {
+				promotion := percentage(1, 10)
+				promotion.MinSubtotal = 130000
+				return []internal.Promotion{promotion}
+			},
+			lines:   []int64{10000, 3000},
+			applied: []uint{1},
+		},
+		{
+			name:       "fixed amount in another currency",
+			cart:       cart,
+			promotions: func() []internal.Promotion { return []internal.Promotion{fixed(1, 10, "USD")} },
+			lines:      []int64{0, 0},
+		},
+		{
+			name: "minimum subtotal in another currency",
+			cart: cart,
+			promotions: func() []internal.Promotion {
+				promotion := percentage(1, 10)
+				promotion.MinSubtotal, promotion.Currency = 1, "USD"
+				return []internal.Promotion{promotion}
+			},
+			lines: []int64{0, 0},
+		},
+		{
+			name: "percentage has no currency to match",
+			cart: cart,
+			promotions: func() []internal.Promotion {
+				promotion := percentage(1, 10)
+				promotion.Currency = "USD"
+				return []internal.Promotion{promotion}
+			},
+			lines:   []int64{10000, 3000},
+			applied: []uint{1},
+		},
+		{
+			name:       "rounds down per line",
+			cart:       []internal.CartLine{line(1, 1, 333), line(2, 1, 333), line(3, 1, 333)},
+			promotions: func() []internal.Promotion { return []internal.Promotion{percentage(1, 10)} },
+			lines:      []int64{33, 33, 33},
+			applied:    []uint{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pricing, err := internal.Evaluate(tt.cart, tt.promotions())
+			require.NoError(t, err)
+
+			var discount int64
+			lines := make([]int64, len(pricing.Lines))
+			for i, line := range pricing.Lines {
+				lines[i] = line.Discount
+				discount += line.Discount
+			}
+			assert.Equal(t, tt.lines, lines)
+			assert.Equal(t, discount, pricing.Discount, "line discounts add up to the cart's")
+			assert.Equal(t, pricing.Subtotal-pricing.Discount, pricing.Total())
+
+			applied := make([]uint, 0, len(pricing.Applied))
+			for _, promotion := range pricing.Applied {
+				applied = append(applied, promotion.Promotion.ID)
+				var share int64
+				for _, line := range pricing.Lines {
+					share += line.Promotions[promotion.Promotion.ID]
+				}
+				assert.Equal(t, promotion.Discount, share, "shares of promotion %d add up", promotion.Promotion.ID)
+			}
+			assert.Equal(t, append([]uint{}, tt.applied...), applied)
+		})
+	}
+}
+
+func TestEvaluate_MixedCurrency(t *testing.T) {
+	cart := []internal.CartLine{line(1, 1, 50000), {BookID: 2, Quantity: 1, UnitPrice: money.New(300, "USD")}}
+
+	_, err := internal.Evaluate(cart, nil)
+
+	assert.ErrorIs(t, err, internal.ErrMixedCurrency)
+}
+
+func TestPriceCart(t *testing.T) {
+	code := "SPRING10"
+	coupon := percentage(2, 10)
+	coupon.Code = &code
+	coupon.MinSubtotal = 200000
+	cart := []internal.CartLine{line(1, 2, 50000)}
+
+	pricing, err := internal.PriceCart(cart, []internal.Promotion{percentage(1, 5)}, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), pricing.Discount)
+
+	_, err = internal.PriceCart(cart, []internal.Promotion{percentage(1, 5), coupon}, code)
+	assert.ErrorIs(t, err, internal.ErrCouponNotApplicable, "the automatic promotion applying does not count")
+
+	pricing, err = internal.PriceCart([]internal.CartLine{line(1, 4, 50000)}, []internal.Promotion{coupon}, code)
+	require.NoError(t, err)
+	assert.True(t, pricing.Applies(coupon.ID))
+	assert.Equal(t, int64(20000), pricing.Discount)
+}
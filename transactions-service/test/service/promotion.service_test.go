@@ -0,0 +1,129 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"transactions-service/internal"
+	"transactions-service/internal/api/dto"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestPromotionService_CreateValidatesRules(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+	tests := []struct {
+		name    string
+		request dto.PromotionRequest
+		err     error
+	}{
+		{"ends before it starts", dto.PromotionRequest{Kind: internal.PromotionPercentage, PercentOff: 10, StartsAt: &now, EndsAt: &earlier}, internal.ErrPromotionWindow},
+		{"ends as it starts", dto.PromotionRequest{Kind: internal.PromotionPercentage, PercentOff: 10, StartsAt: &now, EndsAt: &now}, internal.ErrPromotionWindow},
+		{"percentage without percent", dto.PromotionRequest{Kind: internal.PromotionPercentage}, internal.ErrPromotionRule},
+		{"fixed without amount", dto.PromotionRequest{Kind: internal.PromotionFixed, PercentOff: 10}, internal.ErrPromotionRule},
+		{"buy without get", dto.PromotionRequest{Kind: internal.PromotionBuyXGetY, BuyQuantity: 2}, internal.ErrPromotionRule},
+		{"amounts in two currencies", dto.PromotionRequest{
+			Kind:        internal.PromotionFixed,
+			AmountOff:   &dto.MoneyRequest{Amount: 500, Currency: "USD"},
+			MinSubtotal: &dto.MoneyRequest{Amount: 100000},
+		}, internal.ErrPromotionRule},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := setup(t)
+
+			_, err := d.promotionService().Create(context.Background(), tt.request)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestPromotionService_CreateCoupon(t *testing.T) {
+	d := setup(t)
+	d.promotions.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, promotion *internal.Promotion) (*internal.Promotion, error) {
+		promotion.ID = 1
+		return promotion, nil
+	})
+
+	response, err := d.promotionService().Create(context.Background(), dto.PromotionRequest{
+		Name:        "Spring",
+		Kind:        internal.PromotionFixed,
+		Code:        " spring5 ",
+		AmountOff:   &dto.MoneyRequest{Amount: 500, Currency: "USD"},
+		MinSubtotal: &dto.MoneyRequest{Amount: 2000, Currency: "USD"},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, response.Code)
+	assert.Equal(t, "SPRING5", *response.Code)
+	assert.Equal(t, "USD", response.AmountOff.Currency)
+	assert.Equal(t, int64(2000), response.MinSubtotal.Amount)
+	assert.True(t, response.Active)
+}
+
+func TestPromotionService_Candidates(t *testing.T) {
+	code, one := "SPRING10", 1
+	coupon := percentage(3, 10)
+	coupon.Code, coupon.MaxPerUser = &code, &one
+	exhausted := percentage(1, 5)
+	exhausted.MaxRedemptions, exhausted.Redemptions = &one, 1
+	running := []internal.Promotion{exhausted, percentage(2, 5)}
+	ended := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		coupon     func() (*internal.Promotion, error)
+		usage      map[uint]int
+		candidates []uint
+		err        error
+	}{
+		{"automatic only", nil, map[uint]int{}, []uint{2}, nil},
+		{"coupon", func() (*internal.Promotion, error) { return &coupon, nil }, map[uint]int{}, []uint{2, 3}, nil},
+		{"unknown coupon", func() (*internal.Promotion, error) { return nil, gorm.ErrRecordNotFound }, nil, nil, internal.ErrCouponInvalid},
+		{"deactivated coupon", func() (*internal.Promotion, error) {
+			inactive := coupon
+			inactive.Active = false
+			return &inactive, nil
+		}, nil, nil, internal.ErrCouponInvalid},
+		{"expired coupon", func() (*internal.Promotion, error) {
+			expired := coupon
+			expired.EndsAt = &ended
+			return &expired, nil
+		}, nil, nil, internal.ErrCouponExpired},
+		{"coupon used up by the user", func() (*internal.Promotion, error) { return &coupon, nil }, map[uint]int{3: 1}, nil, internal.ErrPromotionExhausted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := setup(t)
+			d.promotions.EXPECT().FindRunning(gomock.Any(), gomock.Any()).Return(append([]internal.Promotion(nil), running...), nil)
+			code := ""
+			if tt.coupon != nil {
+				code = "SPRING10"
+				d.promotions.EXPECT().FindByCode(gomock.Any(), code).DoAndReturn(func(ctx context.Context, code string) (*internal.Promotion, error) {
+					return tt.coupon()
+				})
+			}
+			if tt.usage != nil {
+				d.promotions.EXPECT().Usage(gomock.Any(), uint(7), gomock.Any()).Return(tt.usage, nil)
+			}
+
+			candidates, err := d.promotionService().Candidates(context.Background(), 7, code)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			ids := make([]uint, len(candidates))
+			for i, promotion := range candidates {
+				ids[i] = promotion.ID
+			}
+			assert.Equal(t, tt.candidates, ids)
+		})
+	}
+}
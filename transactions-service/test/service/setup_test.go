@@ -0,0 +1,60 @@
+package service_test
+
+import (
+	"math/big"
+	"testing"
+	"transactions-service/internal"
+	mocks "transactions-service/test/mock"
+
+	"github.com/fahrizalvianaz/shared-contracts/money"
+	"github.com/golang/mock/gomock"
+)
+
+// deps holds the mocks the services under test are built from and a rate
+// table with IDR as its base. Transaction tests stub promotion candidates
+// through candidates; promotion tests go through the real service and the
+// promotions repository.
+type deps struct {
+	transactions *mocks.MockTransactionRepository
+	carts        *mocks.MockCartRepository
+	promotions   *mocks.MockPromotionRepository
+	loans        *mocks.MockLoanRepository
+	candidates   *mocks.MockPromotionService
+	books        *mocks.MockBookClient
+	users        *mocks.MockUserClient
+	rates        *money.Table
+}
+
+func setup(t *testing.T) *deps {
+	ctrl := gomock.NewController(t)
+	return &deps{
+		transactions: mocks.NewMockTransactionRepository(ctrl),
+		carts:        mocks.NewMockCartRepository(ctrl),
+		promotions:   mocks.NewMockPromotionRepository(ctrl),
+		loans:        mocks.NewMockLoanRepository(ctrl),
+		candidates:   mocks.NewMockPromotionService(ctrl),
+		books:        mocks.NewMockBookClient(ctrl),
+		users:        mocks.NewMockUserClient(ctrl),
+		rates:        money.NewTable("IDR", map[string]*big.Rat{"USD": big.NewRat(1, 16000)}),
+	}
+}
+
+func (d *deps) promotionService() internal.PromotionService {
+	return internal.NewPromotionService(d.promotions, d.books, d.rates)
+}
+
+func (d *deps) transactionService() internal.TransactionService {
+	return internal.NewTransactionService(d.transactions, d.carts, d.candidates, d.books, d.users, d.rates)
+}
+
+func (d *deps) cartService() internal.CartService {
+	return internal.NewCartService(d.carts, d.books, d.rates)
+}
+
+func (d *deps) loanService(policy internal.LoanPolicy) internal.LoanService {
+	return internal.NewLoanService(d.loans, d.books, d.users, d.rates, policy)
+}
+
+func idr(amount int64) money.Money {
+	return money.New(amount, "IDR")
+}
@@ -0,0 +1,73 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"transactions-service/internal"
+	"transactions-service/internal/api/dto"
+	"transactions-service/internal/client"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// purchase expects a purchase of two copies of book 1 at 50000 by user 7
+// up to the point where it is stored.
+func purchase(d *deps, code string, candidates ...internal.Promotion) {
+	d.users.EXPECT().FindByID(gomock.Any(), uint(7)).Return(&client.User{ID: 7}, nil)
+	d.candidates.EXPECT().Candidates(gomock.Any(), uint(7), code).Return(candidates, nil)
+	if code != "" {
+		d.books.EXPECT().FindByIDs(gomock.Any(), []uint{1}).Return([]client.Book{{ID: 1, Price: idr(50000), Stock: 5}}, nil)
+	}
+	d.books.EXPECT().ReserveStock(gomock.Any(), uint(1), 2).Return(&client.Book{ID: 1, Price: idr(50000), Stock: 3}, nil)
+}
+
+func TestTransactionService_PurchaseDropsCappedPromotion(t *testing.T) {
+	d := setup(t)
+	purchase(d, "", percentage(1, 10), percentage(2, 5))
+	gomock.InOrder(
+		d.transactions.EXPECT().Checkout(gomock.Any(), uint(7), gomock.Any(), []uint{1, 2}, uint(0)).Return(&internal.CapError{PromotionID: 1}),
+		d.transactions.EXPECT().Checkout(gomock.Any(), uint(7), gomock.Any(), []uint{2}, uint(0)).
+			DoAndReturn(func(ctx context.Context, userID uint, transactions []internal.Transaction, promotionIDs []uint, cartID uint) error {
+				require.Len(t, transactions, 1)
+				assert.Equal(t, int64(5000), transactions[0].Discount, "the cart is priced again without the capped promotion")
+				return nil
+			}),
+	)
+
+	response, err := d.transactionService().Purchase(context.Background(), 7, dto.PurchaseRequest{BookID: 1, Quantity: 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, idr(5000), response.Discount)
+	assert.Equal(t, idr(95000), response.Total)
+	require.Len(t, response.Promotions, 1)
+	assert.Equal(t, uint(2), response.Promotions[0].ID)
+}
+
+func TestTransactionService_PurchaseFailsOnCappedCoupon(t *testing.T) {
+	d := setup(t)
+	code := "SPRING10"
+	coupon := percentage(3, 10)
+	coupon.Code = &code
+	purchase(d, code, coupon)
+	d.transactions.EXPECT().Checkout(gomock.Any(), uint(7), gomock.Any(), []uint{3}, uint(0)).Return(&internal.CapError{PromotionID: 3})
+	d.books.EXPECT().ReleaseStock(gomock.Any(), uint(1), 2).Return(&client.Book{ID: 1, Stock: 5}, nil)
+
+	_, err := d.transactionService().Purchase(context.Background(), 7, dto.PurchaseRequest{BookID: 1, Quantity: 2, Coupon: "spring10"})
+
+	assert.ErrorIs(t, err, internal.ErrPromotionExhausted)
+}
+
+func TestTransactionService_PurchaseReleasesStockWhenNotStored(t *testing.T) {
+	d := setup(t)
+	purchase(d, "")
+	d.transactions.EXPECT().Checkout(gomock.Any(), uint(7), gomock.Any(), []uint{}, uint(0)).Return(errors.New("connection reset"))
+	d.books.EXPECT().ReleaseStock(gomock.Any(), uint(1), 2).Return(&client.Book{ID: 1, Stock: 5}, nil)
+
+	_, err := d.transactionService().Purchase(context.Background(), 7, dto.PurchaseRequest{BookID: 1, Quantity: 2})
+
+	assert.Equal(t, apperror.KindInternal, apperror.KindOf(err))
+}
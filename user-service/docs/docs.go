@@ -23,6 +23,65 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/users/batch": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get public user data for a batch of user ids",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get Users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "1,2,3",
+                        "description": "Comma separated user ids",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Users retrieve successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.UserResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid Request format",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/users/login": {
             "post": {
                 "description": "Login user account",
@@ -66,7 +125,13 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
+                    "401": {
+                        "description": "Invalid password or username",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.Response"
+                        }
+                    },
+                    "422": {
                         "description": "Invalid Request format",
                         "schema": {
                             "$ref": "#/definitions/pkg.Response"
@@ -112,8 +177,14 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid Request format",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/pkg.Response"
                         }
@@ -143,6 +214,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.RegisterRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the stored response when a retry reuses the key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -164,7 +241,13 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
+                    "409": {
+                        "description": "Username or email is already registered",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.Response"
+                        }
+                    },
+                    "422": {
                         "description": "Invalid Request format",
                         "schema": {
                             "$ref": "#/definitions/pkg.Response"
@@ -185,10 +268,12 @@ const docTemplate = `{
             "properties": {
                 "password": {
                     "type": "string",
-                    "example": "xxxxxxx"
+                    "maxLength": 72,
+                    "example": "secret123"
                 },
                 "username": {
                     "type": "string",
+                    "maxLength": 32,
                     "example": "johndoe"
                 }
             }
@@ -236,15 +321,17 @@ const docTemplate = `{
             "properties": {
                 "email": {
                     "type": "string",
+                    "maxLength": 254,
                     "example": "johndoe@gmail.com"
                 },
                 "name": {
                     "type": "string",
+                    "maxLength": 100,
                     "example": "johndoe"
                 },
                 "password": {
                     "type": "string",
-                    "example": "xxxxxxx"
+                    "example": "secret123"
                 },
                 "username": {
                     "type": "string",
@@ -269,6 +356,20 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.UserResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
         "pkg.Response": {
             "type": "object",
             "properties": {
@@ -3,15 +3,15 @@ package dto
 // RegisterRequest represents a registration request
 // @Description Registration request payload
 type RegisterRequest struct {
-	Name     string `json:"name" binding:"required" example:"johndoe"`
-	Username string `json:"username" binding:"required" example:"johndoe"`
-	Email    string `json:"email" binding:"required" example:"johndoe@gmail.com"`
-	Password string `json:"password" binding:"required" example:"xxxxxxx"`
+	Name     string `json:"name" binding:"required,notblank,max=100" example:"johndoe"`
+	Username string `json:"username" binding:"required,username" example:"johndoe"`
+	Email    string `json:"email" binding:"required,email,max=254" example:"johndoe@gmail.com"`
+	Password string `json:"password" binding:"required,password" example:"secret123"`
 }
 
 // LoginRequest represents a registration request
 // @Description Login request payload
 type LoginRequest struct {
-	Username string `json:"username" binding:"required" example:"johndoe"`
-	Password string `json:"password" binding:"required" example:"xxxxxxx"`
+	Username string `json:"username" binding:"required,max=32" example:"johndoe"`
+	Password string `json:"password" binding:"required,max=72" example:"secret123"`
 }
@@ -3,8 +3,11 @@ package api
 import (
 	users "bookstore-framework/internal"
 	"bookstore-framework/internal/api/dto"
-	"net/http"
+	"errors"
+	"strconv"
+	"strings"
 
+	"github.com/fahrizalvianaz/shared-errors/apperror"
 	genericResponse "github.com/fahrizalvianaz/shared-response/httputil"
 	"github.com/gin-gonic/gin"
 )
@@ -26,19 +29,21 @@ func NewUserHandler(userService users.UserService) *UserHandler {
 // @Accept       json
 // @Produce      json
 // @Param        request body     dto.RegisterRequest true "User information"
+// @Param        Idempotency-Key header string false "Replays the stored response when a retry for the same username reuses the key"
 // @Success      201  {object}    pkg.Response{data=dto.RegisterResponse} "User registered successfully"
-// @Failure      400  {object}    pkg.Response "Invalid Request format"
+// @Failure      422  {object}    pkg.Response "Invalid Request format"
+// @Failure      409  {object}    pkg.Response "Username or email is already registered"
 // @Router       /users/register [post]
 func (h *UserHandler) RegisterHandler(ctx *gin.Context) {
 	var req dto.RegisterRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		genericResponse.BadRequestResponse(ctx, "Invalid Request format", err.Error())
+		apperror.Respond(ctx, apperror.BindError(err))
 		return
 	}
 
 	response, err := h.userService.Register(ctx.Request.Context(), req)
 	if err != nil {
-		genericResponse.ErrorResponse(ctx, http.StatusBadRequest, err.Error(), nil)
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -53,18 +58,19 @@ func (h *UserHandler) RegisterHandler(ctx *gin.Context) {
 // @Produce      json
 // @Param        request body     dto.LoginRequest true "User information"
 // @Success      201  {object}    pkg.Response{data=dto.LoginResponse} "Login successfully"
-// @Failure      400  {object}    pkg.Response "Invalid Request format"
+// @Failure      422  {object}    pkg.Response "Invalid Request format"
+// @Failure      401  {object}    pkg.Response "Invalid password or username"
 // @Router       /users/login [post]
 func (h *UserHandler) LoginHandler(ctx *gin.Context) {
 	var req dto.LoginRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		genericResponse.BadRequestResponse(ctx, "Invalid Request format", err.Error())
+		apperror.Respond(ctx, apperror.BindError(err))
 		return
 	}
 
 	response, err := h.userService.Login(ctx.Request.Context(), req)
 	if err != nil {
-		genericResponse.ErrorResponse(ctx, http.StatusBadRequest, err.Error(), nil)
+		apperror.Respond(ctx, err)
 		return
 	}
 
@@ -79,20 +85,59 @@ func (h *UserHandler) LoginHandler(ctx *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Success      201  {object}    pkg.Response{data=dto.ProfileResponse} "Profile retrieve successfully"
-// @Failure      400  {object}    pkg.Response "Invalid Request format"
+// @Failure      401  {object}    pkg.Response "Unauthorized"
+// @Failure      404  {object}    pkg.Response "User not found"
 // @Router       /users/profile [get]
 func (h *UserHandler) GetProfile(ctx *gin.Context) {
 	userID, exist := ctx.Get("userID")
 	if !exist {
-		genericResponse.ErrorResponse(ctx, http.StatusUnauthorized, "User not found", nil)
+		apperror.Respond(ctx, apperror.Unauthorized(apperror.CodeUnauthorized, "User not found"))
 		return
 	}
 
 	profile, err := h.userService.GetProfile(ctx, userID.(uint))
 	if err != nil {
-		genericResponse.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to retrieve profile", err.Error())
+		apperror.Respond(ctx, err)
 		return
 	}
 
 	genericResponse.OkResponse(ctx, "Profile retrieve successfully", profile)
 }
+
+// GetUsers godoc
+// @Summary      Get Users
+// @Description  Get public user data for a batch of user ids
+// @Tags         users
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        ids  query    string true "Comma separated user ids" example(1,2,3)
+// @Success      200  {object}    pkg.Response{data=[]dto.UserResponse} "Users retrieve successfully"
+// @Failure      422  {object}    pkg.Response "Invalid Request format"
+// @Router       /users/batch [get]
+func (h *UserHandler) GetUsers(ctx *gin.Context) {
+	var ids []uint
+	for _, part := range strings.Split(ctx.Query("ids"), ",") {
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			apperror.Respond(ctx, apperror.ErrInvalidID.Wrap(err))
+			return
+		}
+		ids = append(ids, uint(id))
+	}
+	if len(ids) == 0 {
+		apperror.Respond(ctx, apperror.BindError(errors.New("ids is required")))
+		return
+	}
+
+	users, err := h.userService.GetUsers(ctx.Request.Context(), ids)
+	if err != nil {
+		apperror.Respond(ctx, err)
+		return
+	}
+
+	genericResponse.OkResponse(ctx, "Users retrieve successfully", users)
+}
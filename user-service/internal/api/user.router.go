@@ -2,9 +2,12 @@ package api
 
 import (
 	users "bookstore-framework/internal"
+	"bookstore-framework/internal/api/dto"
+	"encoding/json"
 
 	pkg "github.com/fahrizalvianaz/shared-middleware"
 	"github.com/fahrizalvianaz/shared-middleware/middleware"
+	"github.com/fahrizalvianaz/shared-server/idempotency"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -16,10 +19,21 @@ func UsersRoutes(router *gin.RouterGroup, db *gorm.DB) {
 	userService := users.NewUserService(userRepository, jwtGenerator)
 	userHandler := NewUserHandler(userService)
 
-	router.POST("/register", userHandler.RegisterHandler)
+	router.POST("/register", idempotency.AnonymousMiddleware(idempotency.NewGormStore(db), idempotency.TTLFromEnv(), registrant), userHandler.RegisterHandler)
 	router.POST("/login", userHandler.LoginHandler)
 
 	protected := router.Group("/")
 	protected.Use(middleware.JWTAuth())
 	protected.GET("/profile", userHandler.GetProfile)
+	protected.GET("/batch", userHandler.GetUsers)
+}
+
+// registrant tells registrations apart by the username they claim, so only
+// retries of the same sign-up share an Idempotency-Key.
+func registrant(ctx *gin.Context, body []byte) (string, bool) {
+	var request dto.RegisterRequest
+	if err := json.Unmarshal(body, &request); err != nil || request.Username == "" {
+		return "", false
+	}
+	return request.Username, true
 }
@@ -0,0 +1,34 @@
+package rpc
+
+import (
+	users "bookstore-framework/internal"
+	"time"
+
+	"github.com/fahrizalvianaz/shared-contracts/interceptor"
+	"github.com/fahrizalvianaz/shared-contracts/userpb"
+	pkg "github.com/fahrizalvianaz/shared-middleware"
+	"github.com/fahrizalvianaz/shared-observability/tracing"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+const maxCallDuration = 10 * time.Second
+
+// NewServer builds the internal gRPC server that runs next to the gin router
+// and shares its service layer.
+func NewServer(db *gorm.DB, secretKey string) *grpc.Server {
+	userRepository := users.NewUserRepository(db)
+	jwtGenerator := &pkg.Claims{}
+	userService := users.NewUserService(userRepository, jwtGenerator)
+
+	server := grpc.NewServer(
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(
+			interceptor.UnaryServerDeadline(maxCallDuration),
+			interceptor.UnaryServerAuth(secretKey),
+		),
+	)
+	userpb.RegisterUserServiceServer(server, NewUserServer(userService))
+
+	return server
+}
@@ -0,0 +1,56 @@
+package rpc
+
+import (
+	users "bookstore-framework/internal"
+	"context"
+
+	"github.com/fahrizalvianaz/shared-contracts/userpb"
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+)
+
+type UserServer struct {
+	userpb.UnimplementedUserServiceServer
+	userService users.UserService
+}
+
+func NewUserServer(userService users.UserService) *UserServer {
+	return &UserServer{
+		userService: userService,
+	}
+}
+
+func (s *UserServer) GetUser(ctx context.Context, req *userpb.GetUserRequest) (*userpb.User, error) {
+	profile, err := s.userService.GetProfile(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, apperror.ToStatus(err)
+	}
+
+	return &userpb.User{
+		Id:       uint64(profile.ID),
+		Name:     profile.Name,
+		Username: profile.Username,
+		Email:    profile.Email,
+	}, nil
+}
+
+func (s *UserServer) GetUsers(ctx context.Context, req *userpb.GetUsersRequest) (*userpb.GetUsersResponse, error) {
+	ids := make([]uint, len(req.GetIds()))
+	for i, id := range req.GetIds() {
+		ids[i] = uint(id)
+	}
+
+	result, err := s.userService.GetUsers(ctx, ids)
+	if err != nil {
+		return nil, apperror.ToStatus(err)
+	}
+
+	response := &userpb.GetUsersResponse{Users: make([]*userpb.User, 0, len(result))}
+	for _, user := range result {
+		response.Users = append(response.Users, &userpb.User{
+			Id:       uint64(user.ID),
+			Name:     user.Name,
+			Username: user.Username,
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,26 @@
+package users
+
+import (
+	"errors"
+
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUserNotFound       = apperror.NotFound("USER_NOT_FOUND", "User not found")
+	ErrUserAlreadyExists  = apperror.Conflict("USER_ALREADY_EXISTS", "Username or email is already registered")
+	ErrInvalidCredentials = apperror.Unauthorized("INVALID_CREDENTIALS", "invalid password or username")
+)
+
+// userError translates repository errors into domain errors.
+func userError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrUserNotFound.Wrap(err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return ErrUserAlreadyExists.Wrap(err)
+	default:
+		return apperror.Internal(err)
+	}
+}
@@ -0,0 +1,21 @@
+package users
+
+import (
+	"github.com/fahrizalvianaz/shared-observability/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	registrationsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "user_registrations_total",
+		Help:      "Number of users registered.",
+	})
+
+	loginsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "user_logins_total",
+		Help:      "Number of login attempts, by result.",
+	}, []string{"result"})
+)
@@ -10,6 +10,7 @@ type UserRepository interface {
 	Register(ctx context.Context, user *User) (*User, error)
 	FindUserByUsername(ctx context.Context, username string) (*User, error)
 	FindUserByID(ctx context.Context, idUser uint) (*User, error)
+	FindUsersByIDs(ctx context.Context, idUsers []uint) ([]User, error)
 }
 
 type userRepository struct {
@@ -49,3 +50,13 @@ func (r *userRepository) FindUserByID(ctx context.Context, idUser uint) (*User,
 
 	return user, nil
 }
+
+func (r *userRepository) FindUsersByIDs(ctx context.Context, idUsers []uint) ([]User, error) {
+	var users []User
+	result := r.db.WithContext(ctx).Where("id IN ?", idUsers).Find(&users)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return users, nil
+}
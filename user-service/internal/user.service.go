@@ -5,7 +5,9 @@ import (
 	"context"
 	"errors"
 
+	"github.com/fahrizalvianaz/shared-errors/apperror"
 	pkg "github.com/fahrizalvianaz/shared-middleware"
+	"gorm.io/gorm"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -14,6 +16,7 @@ type UserService interface {
 	Register(ctx context.Context, req dto.RegisterRequest) (*dto.RegisterResponse, error)
 	Login(ctx context.Context, req dto.LoginRequest) (*dto.LoginResponse, error)
 	GetProfile(ctx context.Context, userId uint) (*dto.ProfileResponse, error)
+	GetUsers(ctx context.Context, userIds []uint) ([]dto.UserResponse, error)
 }
 
 type userService struct {
@@ -31,7 +34,7 @@ func NewUserService(userRepo UserRepository, jwtGen pkg.JWTGenerator) UserServic
 func (s *userService) Register(ctx context.Context, req dto.RegisterRequest) (*dto.RegisterResponse, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return nil, err
+		return nil, apperror.Internal(err)
 	}
 	user := User{
 		Username: req.Username,
@@ -42,8 +45,9 @@ func (s *userService) Register(ctx context.Context, req dto.RegisterRequest) (*d
 
 	registerUser, err := s.userRepo.Register(ctx, &user)
 	if err != nil {
-		return nil, err
+		return nil, userError(err)
 	}
+	registrationsTotal.Inc()
 
 	respone := &dto.RegisterResponse{
 		ID:        registerUser.ID,
@@ -59,17 +63,25 @@ func (s *userService) Register(ctx context.Context, req dto.RegisterRequest) (*d
 func (s *userService) Login(ctx context.Context, req dto.LoginRequest) (*dto.LoginResponse, error) {
 	user, err := s.userRepo.FindUserByUsername(ctx, req.Username)
 	if err != nil {
-		return nil, err
+		loginsTotal.WithLabelValues("failure").Inc()
+		// An unknown username gets the same answer as a wrong password so
+		// logins cannot be used to discover accounts.
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidCredentials.Wrap(err)
+		}
+		return nil, apperror.Internal(err)
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		return nil, errors.New("invalid password or username")
+		loginsTotal.WithLabelValues("failure").Inc()
+		return nil, ErrInvalidCredentials
 	}
 
 	token, err := s.jwtGen.GenerateToken(user.ID, user.Username, user.Email)
 	if err != nil {
-		return nil, err
+		return nil, apperror.Internal(err)
 	}
+	loginsTotal.WithLabelValues("success").Inc()
 	respose := &dto.LoginResponse{
 		TokenAccess: token,
 	}
@@ -81,7 +93,7 @@ func (s *userService) Login(ctx context.Context, req dto.LoginRequest) (*dto.Log
 func (s *userService) GetProfile(ctx context.Context, userId uint) (*dto.ProfileResponse, error) {
 	user, err := s.userRepo.FindUserByID(ctx, userId)
 	if err != nil {
-		return nil, err
+		return nil, userError(err)
 	}
 
 	response := &dto.ProfileResponse{
@@ -95,3 +107,21 @@ func (s *userService) GetProfile(ctx context.Context, userId uint) (*dto.Profile
 
 	return response, nil
 }
+
+func (s *userService) GetUsers(ctx context.Context, userIds []uint) ([]dto.UserResponse, error) {
+	users, err := s.userRepo.FindUsersByIDs(ctx, userIds)
+	if err != nil {
+		return nil, userError(err)
+	}
+
+	response := make([]dto.UserResponse, 0, len(users))
+	for _, user := range users {
+		response = append(response, dto.UserResponse{
+			ID:       user.ID,
+			Name:     user.Name,
+			Username: user.Username,
+		})
+	}
+
+	return response, nil
+}
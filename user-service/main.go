@@ -1,12 +1,22 @@
 package main
 
 import (
+	"bookstore-framework/internal/rpc"
 	"bookstore-framework/migrations"
 	"bookstore-framework/pkg"
 	"bookstore-framework/routes"
-	"log"
+	"bookstore-framework/seeds"
+	"context"
+	"net"
+	"os"
 
 	configs "github.com/fahrizalvianaz/shared-configuration/configs"
+	"github.com/fahrizalvianaz/shared-observability/logging"
+	"github.com/fahrizalvianaz/shared-observability/tracing"
+	"github.com/fahrizalvianaz/shared-server/health"
+	"github.com/fahrizalvianaz/shared-server/idempotency"
+	"github.com/fahrizalvianaz/shared-server/server"
+	"gorm.io/gorm"
 
 	_ "bookstore-framework/docs"
 
@@ -33,22 +43,76 @@ import (
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token
 func main() {
+	logging.Setup("user-service")
+
 	cfg, err := configs.LoadConfig()
 	if err != nil {
-		log.Fatalf("Failed to load config : %v", err)
+		logging.Fatal("Failed to load config", err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		connect := func() (*gorm.DB, error) { return pkg.ConnectDB(cfg) }
+		if err := migrations.Command(os.Args[2:], connect); err != nil {
+			logging.Fatal("Migration command failed", err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		db, err := pkg.ConnectDB(cfg)
+		if err != nil {
+			logging.Fatal("Failed to connect to database", err)
+		}
+		if err := seeds.Command(os.Args[2:], db); err != nil {
+			logging.Fatal("Seed command failed", err)
+		}
+		return
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.LoadConfig("user-service"))
+	if err != nil {
+		logging.Fatal("Failed to set up tracing", err)
 	}
 
 	db, err := pkg.ConnectDB(cfg)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		logging.Fatal("Failed to connect to database", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		logging.Fatal("Failed to get database handle", err)
 	}
 
 	if err := migrations.Migrate(db); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
+		logging.Fatal("Failed to run migrations", err)
+	}
+
+	grpcAddr := os.Getenv("GRPC_ADDR")
+	if grpcAddr == "" {
+		grpcAddr = ":9090"
+	}
+	listener, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		logging.Fatal("Failed to listen for grpc", err)
 	}
+	grpcServer := rpc.NewServer(db, cfg.SecretKey)
 
-	router := routes.Router(db)
+	checker := health.New(health.DefaultTimeout)
+	checker.Add("database", health.Ping(sqlDB))
+	checker.Add("migrations", func(ctx context.Context) error { return migrations.Status(ctx, db) })
+
+	router := routes.Router(db, checker)
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-	router.Run(":8080")
+
+	runner := server.New(server.LoadConfig(), router)
+	runner.OnDrain(checker.Drain)
+	runner.OnShutdown("tracing", shutdownTracing)
+	runner.OnShutdown("database", func(context.Context) error { return sqlDB.Close() })
+	runner.AddWorker("grpc", server.ServeWorker(grpcServer, listener))
+	runner.AddWorker("idempotency-cleanup", idempotency.CleanupWorker(idempotency.NewGormStore(db), idempotency.DefaultCleanupInterval))
+
+	if err := runner.Run(context.Background()); err != nil {
+		logging.Fatal("Server stopped with error", err)
+	}
 }
@@ -3,12 +3,28 @@ package routes
 import (
 	"bookstore-framework/internal/api"
 
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"github.com/fahrizalvianaz/shared-errors/validation"
+	"github.com/fahrizalvianaz/shared-observability/logging"
+	"github.com/fahrizalvianaz/shared-observability/metrics"
+	"github.com/fahrizalvianaz/shared-observability/tracing"
+	"github.com/fahrizalvianaz/shared-server/health"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func Router(db *gorm.DB) *gin.Engine {
-	router := gin.Default()
+func Router(db *gorm.DB, checker *health.Checker) *gin.Engine {
+	validation.Register()
+
+	router := gin.New()
+	router.Use(logging.RequestID())
+	router.Use(tracing.Middleware("user-service")...)
+	router.Use(metrics.Middleware("user-service"))
+	router.Use(logging.Middleware(), logging.Recovery())
+	router.NoRoute(apperror.NoRoute)
+	router.GET("/metrics", metrics.Handler())
+	router.GET("/healthz", gin.WrapF(checker.Liveness))
+	router.GET("/readyz", gin.WrapF(checker.Readiness))
 	group := router.Group("/api/v1")
 
 	api.UsersRoutes(group.Group("/users"), db)
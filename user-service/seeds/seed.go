@@ -0,0 +1,123 @@
+package seeds
+
+import (
+	users "bookstore-framework/internal"
+	"context"
+	_ "embed"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/fahrizalvianaz/shared-migrations/seed"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+//go:embed fixtures.yaml
+var defaultFixtures []byte
+
+// GeneratedPassword is the password of every user created by --generate.
+const GeneratedPassword = "password123"
+
+type UserFixture struct {
+	Name     string `yaml:"name" json:"name"`
+	Username string `yaml:"username" json:"username"`
+	Email    string `yaml:"email" json:"email"`
+	Password string `yaml:"password" json:"password"`
+}
+
+type Fixtures struct {
+	Users []UserFixture `yaml:"users" json:"users"`
+}
+
+// Command runs the `seed` subcommand.
+func Command(args []string, db *gorm.DB) error {
+	opts, err := seed.ParseArgs(args)
+	if err != nil {
+		return err
+	}
+
+	var fixtures Fixtures
+	switch {
+	case opts.Generate > 0:
+		fixtures = Generate(opts.Generate)
+	case opts.File != "":
+		err = seed.ReadFile(opts.File, &fixtures)
+	default:
+		err = seed.Decode(defaultFixtures, false, &fixtures)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	count, err := Load(context.Background(), db, fixtures)
+	if err != nil {
+		return err
+	}
+	slog.Info("Seeded users", "count", count)
+	return nil
+}
+
+// Load creates the users that do not exist yet, keyed by username. Existing
+// users keep their password so seeding never invalidates a login.
+func Load(ctx context.Context, db *gorm.DB, fixtures Fixtures) (int, error) {
+	// bcrypt is deliberately slow; generated users share one password, so
+	// hash each distinct password once.
+	hashes := make(map[string]string)
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, fixture := range fixtures.Users {
+			user, err := ToUser(fixture, hashes)
+			if err != nil {
+				return err
+			}
+			err = tx.Where(users.User{Username: user.Username}).
+				Attrs(users.User{Name: user.Name, Email: user.Email, Password: user.Password}).
+				FirstOrCreate(&users.User{}).Error
+			if err != nil {
+				return fmt.Errorf("failed to seed user %q: %w", fixture.Username, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(fixtures.Users), nil
+}
+
+// ToUser hashes the fixture password the same way registration does,
+// reusing a hash from hashes when the same password was seen before.
+func ToUser(fixture UserFixture, hashes map[string]string) (users.User, error) {
+	hashedPassword, ok := hashes[fixture.Password]
+	if !ok {
+		hashed, err := bcrypt.GenerateFromPassword([]byte(fixture.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return users.User{}, fmt.Errorf("failed to hash password for %q: %w", fixture.Username, err)
+		}
+		hashedPassword = string(hashed)
+		hashes[fixture.Password] = hashedPassword
+	}
+	return users.User{
+		Name:     fixture.Name,
+		Username: fixture.Username,
+		Email:    fixture.Email,
+		Password: hashedPassword,
+	}, nil
+}
+
+// Generate builds n fake users, all with GeneratedPassword. User i is the
+// same on every run.
+func Generate(n int) Fixtures {
+	fixtures := Fixtures{Users: make([]UserFixture, 0, n)}
+	for i := 0; i < n; i++ {
+		name := seed.PersonName(i)
+		username := fmt.Sprintf("%s%d", strings.ToLower(strings.ReplaceAll(name, " ", ".")), i+1)
+		fixtures.Users = append(fixtures.Users, UserFixture{
+			Name:     name,
+			Username: username,
+			Email:    username + "@example.com",
+			Password: GeneratedPassword,
+		})
+	}
+	return fixtures
+}
@@ -1,6 +1,7 @@
 package handler_test
 
 import (
+	users "bookstore-framework/internal"
 	"bookstore-framework/internal/api"
 	"bookstore-framework/internal/api/dto"
 	mocks "bookstore-framework/test/mock"
@@ -12,6 +13,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fahrizalvianaz/shared-errors/apperror"
+	"github.com/fahrizalvianaz/shared-errors/validation"
 	"github.com/fahrizalvianaz/shared-response/httputil"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -21,6 +24,7 @@ import (
 
 func TestUserHandler_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
+	validation.Register()
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -156,10 +160,41 @@ func TestUserHandler_Success(t *testing.T) {
 		assert.Equal(t, res.Email, profileResponse.Email)
 
 	})
+
+	t.Run("GetUsers", func(t *testing.T) {
+		res := []dto.UserResponse{
+			{ID: 1, Name: "first", Username: "firstuser"},
+			{ID: 2, Name: "second", Username: "seconduser"},
+		}
+
+		mockService.EXPECT().GetUsers(gomock.Any(), []uint{1, 2}).
+			Return(res, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/users/batch?ids=1,2", nil)
+
+		handler.GetUsers(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response httputil.Response
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Users retrieve successfully", response.Message)
+
+		var usersResponse []dto.UserResponse
+		dataBytes, _ := json.Marshal(response.Data)
+		json.Unmarshal(dataBytes, &usersResponse)
+
+		assert.Equal(t, res, usersResponse)
+	})
 }
 
 func TestUserHandler_Error(t *testing.T) {
 	gin.SetMode(gin.TestMode)
+	validation.Register()
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -184,13 +219,13 @@ func TestUserHandler_Error(t *testing.T) {
 
 		handler.RegisterHandler(c)
 
-		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
 
 		var response httputil.Response
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		require.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
 		assert.Equal(t, false, response.Status)
 		assert.Equal(t, "Invalid Request format", response.Message)
 
@@ -202,17 +237,51 @@ func TestUserHandler_Error(t *testing.T) {
 
 	})
 
+	t.Run("Register_InvalidFields", func(t *testing.T) {
+		req := dto.RegisterRequest{
+			Username: "a b",
+			Name:     "testuser",
+			Email:    "not-an-email",
+			Password: "password",
+		}
+
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/users/register", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.RegisterHandler(c)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		var response struct {
+			Data apperror.Body `json:"data"`
+		}
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, apperror.CodeValidationFailed, response.Data.Error)
+		require.Len(t, response.Data.Fields, 3)
+		assert.Equal(t, "username", response.Data.Fields[0].Field)
+		assert.Equal(t, "email", response.Data.Fields[1].Field)
+		assert.Equal(t, "must be a valid email address", response.Data.Fields[1].Message)
+		assert.Equal(t, "password", response.Data.Fields[2].Code)
+
+	})
+
 	t.Run("Register_ServiceError", func(t *testing.T) {
 		req := dto.RegisterRequest{
-			Username: "XXXX",
+			Username: "testuser",
 			Name:     "testuser",
-			Email:    "XXXXXXXXXXXXXX",
-			Password: "XXXXXXXXXXX",
+			Email:    "test@gmail.com",
+			Password: "password123",
 		}
 
-		errorMsg := "Email already exist"
 		mockService.EXPECT().Register(gomock.Any(), gomock.Eq(req)).
-			Return(nil, errors.New(errorMsg))
+			Return(nil, users.ErrUserAlreadyExists)
 
 		body, err := json.Marshal(req)
 		require.NoError(t, err)
@@ -224,15 +293,16 @@ func TestUserHandler_Error(t *testing.T) {
 
 		handler.RegisterHandler(c)
 
-		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, http.StatusConflict, w.Code)
 
 		var response httputil.Response
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		require.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Equal(t, http.StatusConflict, response.Code)
 		assert.Equal(t, false, response.Status)
-		assert.Equal(t, errorMsg, response.Message)
+		assert.Equal(t, users.ErrUserAlreadyExists.Message, response.Message)
+		assert.Equal(t, map[string]interface{}{"error": "USER_ALREADY_EXISTS"}, response.Data)
 
 	})
 
@@ -252,13 +322,13 @@ func TestUserHandler_Error(t *testing.T) {
 
 		handler.RegisterHandler(c)
 
-		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
 
 		var response httputil.Response
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		require.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
 		assert.Equal(t, false, response.Status)
 		assert.Equal(t, "Invalid Request format", response.Message)
 
@@ -276,10 +346,8 @@ func TestUserHandler_Error(t *testing.T) {
 			Password: "test123",
 		}
 
-		errorMsg := "Invalid username or password"
-
 		mockService.EXPECT().Login(gomock.Any(), gomock.Eq(req)).
-			Return(nil, errors.New(errorMsg))
+			Return(nil, users.ErrInvalidCredentials)
 
 		body, err := json.Marshal(req)
 		require.NoError(t, err)
@@ -291,15 +359,48 @@ func TestUserHandler_Error(t *testing.T) {
 
 		handler.LoginHandler(c)
 
-		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
 
 		var response httputil.Response
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		require.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
 		assert.Equal(t, false, response.Status)
-		assert.Equal(t, errorMsg, response.Message)
+		assert.Equal(t, "invalid password or username", response.Message)
+
+	})
+
+	t.Run("Login_ServiceError_ProblemJSON", func(t *testing.T) {
+		req := dto.LoginRequest{
+			Username: "test",
+			Password: "test123",
+		}
+
+		mockService.EXPECT().Login(gomock.Any(), gomock.Eq(req)).
+			Return(nil, users.ErrInvalidCredentials)
+
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/users/login", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("Accept", "application/problem+json")
+
+		handler.LoginHandler(c)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
+		var problem apperror.Problem
+		err = json.Unmarshal(w.Body.Bytes(), &problem)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusUnauthorized, problem.Status)
+		assert.Equal(t, "INVALID_CREDENTIALS", problem.Code)
+		assert.Equal(t, "/api/v1/users/login", problem.Instance)
 
 	})
 
@@ -344,7 +445,7 @@ func TestUserHandler_Error(t *testing.T) {
 
 		assert.Equal(t, http.StatusInternalServerError, response.Code)
 		assert.Equal(t, false, response.Status)
-		assert.Equal(t, "Failed to retrieve profile", response.Message)
+		assert.Equal(t, "Internal Server Error", response.Message)
 
 	})
 
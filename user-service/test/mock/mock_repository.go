@@ -65,6 +65,21 @@ func (mr *MockUserRepositoryMockRecorder) FindUserByUsername(ctx, username inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByUsername", reflect.TypeOf((*MockUserRepository)(nil).FindUserByUsername), ctx, username)
 }
 
+// FindUsersByIDs mocks base method.
+func (m *MockUserRepository) FindUsersByIDs(ctx context.Context, idUsers []uint) ([]users.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUsersByIDs", ctx, idUsers)
+	ret0, _ := ret[0].([]users.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUsersByIDs indicates an expected call of FindUsersByIDs.
+func (mr *MockUserRepositoryMockRecorder) FindUsersByIDs(ctx, idUsers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUsersByIDs", reflect.TypeOf((*MockUserRepository)(nil).FindUsersByIDs), ctx, idUsers)
+}
+
 // Register mocks base method.
 func (m *MockUserRepository) Register(ctx context.Context, user *users.User) (*users.User, error) {
 	m.ctrl.T.Helper()
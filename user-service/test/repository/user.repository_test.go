@@ -99,6 +99,25 @@ func TestUserRepository_Success(t *testing.T) {
 
 	})
 
+	t.Run("FindUsersByIDs", func(t *testing.T) {
+		columns := []string{"id", "username", "name", "email", "password", "created_at", "modified_at", "deleted_at"}
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id IN ($1,$2) AND "users"."deleted_at" IS NULL`)).
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "first", "first user", "first@example.com", "hashedpassword", time.Now(), time.Now(), nil).
+				AddRow(2, "second", "second user", "second@example.com", "hashedpassword", time.Now(), time.Now(), nil))
+
+		result, err := repo.FindUsersByIDs(context.Background(), []uint{1, 2})
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, "second", result[1].Username)
+
+		err = mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+
+	})
+
 }
 
 func TestUserRepository_Error(t *testing.T) {
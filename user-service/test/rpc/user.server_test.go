@@ -0,0 +1,64 @@
+package rpc_test
+
+import (
+	users "bookstore-framework/internal"
+	"bookstore-framework/internal/api/dto"
+	"bookstore-framework/internal/rpc"
+	mocks "bookstore-framework/test/mock"
+	"context"
+	"testing"
+
+	"github.com/fahrizalvianaz/shared-contracts/userpb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUserServer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockUserService(ctrl)
+	server := rpc.NewUserServer(mockService)
+
+	t.Run("GetUser", func(t *testing.T) {
+		mockService.EXPECT().GetProfile(gomock.Any(), uint(1)).Return(&dto.ProfileResponse{
+			ID:       1,
+			Name:     "test",
+			Username: "testuser",
+			Email:    "test@gmail.com",
+		}, nil)
+
+		user, err := server.GetUser(context.Background(), &userpb.GetUserRequest{Id: 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), user.GetId())
+		assert.Equal(t, "testuser", user.GetUsername())
+		assert.Equal(t, "test@gmail.com", user.GetEmail())
+	})
+
+	t.Run("GetUser_NotFound", func(t *testing.T) {
+		mockService.EXPECT().GetProfile(gomock.Any(), uint(2)).Return(nil, users.ErrUserNotFound)
+
+		user, err := server.GetUser(context.Background(), &userpb.GetUserRequest{Id: 2})
+
+		assert.Nil(t, user)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("GetUsers", func(t *testing.T) {
+		mockService.EXPECT().GetUsers(gomock.Any(), []uint{1, 2}).Return([]dto.UserResponse{
+			{ID: 1, Name: "first", Username: "firstuser"},
+			{ID: 2, Name: "second", Username: "seconduser"},
+		}, nil)
+
+		response, err := server.GetUsers(context.Background(), &userpb.GetUsersRequest{Ids: []uint64{1, 2}})
+
+		require.NoError(t, err)
+		require.Len(t, response.GetUsers(), 2)
+		assert.Equal(t, "seconduser", response.GetUsers()[1].GetUsername())
+		assert.Empty(t, response.GetUsers()[1].GetEmail())
+	})
+}
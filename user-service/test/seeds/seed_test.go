@@ -0,0 +1,38 @@
+package seeds_test
+
+import (
+	"bookstore-framework/seeds"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestGenerate_UniqueUsers(t *testing.T) {
+	fixtures := seeds.Generate(500)
+
+	require.Len(t, fixtures.Users, 500)
+	usernames := make(map[string]bool)
+	for _, user := range fixtures.Users {
+		assert.False(t, usernames[user.Username], "duplicate username %q", user.Username)
+		usernames[user.Username] = true
+		assert.Equal(t, seeds.GeneratedPassword, user.Password)
+	}
+	assert.Equal(t, fixtures, seeds.Generate(500))
+}
+
+func TestToUser_HashesPasswordOnce(t *testing.T) {
+	hashes := make(map[string]string)
+	fixture := seeds.UserFixture{Name: "Ayu Lestari", Username: "ayu", Email: "ayu@bookstore.local", Password: "password123"}
+
+	first, err := seeds.ToUser(fixture, hashes)
+	require.NoError(t, err)
+	second, err := seeds.ToUser(fixture, hashes)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, "password123", first.Password)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(first.Password), []byte("password123")))
+	assert.Equal(t, first.Password, second.Password)
+	assert.Len(t, hashes, 1)
+}
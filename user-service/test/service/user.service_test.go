@@ -9,9 +9,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fahrizalvianaz/shared-errors/apperror"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 func TestUserService_Success(t *testing.T) {
@@ -104,6 +106,25 @@ func TestUserService_Success(t *testing.T) {
 
 	})
 
+	t.Run("GetUsers", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockUsers := []users.User{
+			{ID: 1, Username: "first", Name: "First User"},
+			{ID: 2, Username: "second", Name: "Second User"},
+		}
+
+		mockRepo.EXPECT().FindUsersByIDs(gomock.Any(), []uint{1, 2}).Return(mockUsers, nil)
+
+		result, err := service.GetUsers(ctx, []uint{1, 2})
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, mockUsers[1].ID, result[1].ID)
+		assert.Equal(t, mockUsers[1].Username, result[1].Username)
+
+	})
+
 }
 
 func TestUserService_Error(t *testing.T) {
@@ -115,6 +136,23 @@ func TestUserService_Error(t *testing.T) {
 	service := users.NewUserService(mockRepo, jwtGen)
 
 	t.Run("Register", func(t *testing.T) {
+		ctx := context.Background()
+		req := dto.RegisterRequest{
+			Username: "test",
+			Name:     "testuser",
+			Email:    "test@gmail.com",
+			Password: "password123",
+		}
+		mockRepo.EXPECT().Register(gomock.Any(), gomock.Any()).Return(nil, gorm.ErrDuplicatedKey)
+
+		result, err := service.Register(ctx, req)
+
+		assert.ErrorIs(t, err, users.ErrUserAlreadyExists)
+		assert.Nil(t, result)
+		assert.Equal(t, apperror.KindConflict, apperror.KindOf(err))
+	})
+
+	t.Run("Register_DatabaseError", func(t *testing.T) {
 		ctx := context.Background()
 		req := dto.RegisterRequest{
 			Username: "test",
@@ -128,7 +166,8 @@ func TestUserService_Error(t *testing.T) {
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
-		assert.Equal(t, "Error", err.Error())
+		assert.Equal(t, apperror.KindInternal, apperror.KindOf(err))
+		assert.Equal(t, "Internal Server Error", apperror.From(err).Message)
 	})
 
 	t.Run("Login", func(t *testing.T) {
@@ -155,19 +194,36 @@ func TestUserService_Error(t *testing.T) {
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Equal(t, "invalid password or username", err.Error())
+		assert.ErrorIs(t, err, users.ErrInvalidCredentials)
+
+	})
 
+	t.Run("Login_UnknownUser", func(t *testing.T) {
+		ctx := context.Background()
+		req := dto.LoginRequest{
+			Username: "unknown",
+			Password: "password123",
+		}
+		mockRepo.EXPECT().FindUserByUsername(gomock.Any(), req.Username).Return(nil, gorm.ErrRecordNotFound)
+
+		result, err := service.Login(ctx, req)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, users.ErrInvalidCredentials)
+		assert.Equal(t, apperror.KindUnauthorized, apperror.KindOf(err))
 	})
 
 	t.Run("GetProfile", func(t *testing.T) {
 		ctx := context.Background()
 
-		mockRepo.EXPECT().FindUserByID(gomock.Any(), uint(1)).Return(nil, errors.New("User not found"))
+		mockRepo.EXPECT().FindUserByID(gomock.Any(), uint(1)).Return(nil, gorm.ErrRecordNotFound)
 
 		result, err := service.GetProfile(ctx, 1)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
-		assert.Equal(t, "User not found", err.Error())
+		assert.ErrorIs(t, err, users.ErrUserNotFound)
+		assert.Equal(t, "User not found", apperror.From(err).Message)
 
 	})
 }